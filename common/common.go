@@ -1,12 +1,15 @@
 package common
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/csv"
 	"encoding/hex"
@@ -26,6 +29,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -62,6 +66,76 @@ func NewHTTPClientWithTimeout(t time.Duration) *http.Client {
 	return h
 }
 
+// NewHTTP1ClientWithTimeout initialises a new HTTP client with the specified
+// timeout duration which is restricted to HTTP/1.1
+func NewHTTP1ClientWithTimeout(t time.Duration) *http.Client {
+	h := &http.Client{
+		Timeout: t,
+		Transport: &http.Transport{
+			Proxy:        http.ProxyFromEnvironment,
+			TLSNextProto: make(map[string]func(string, *tls.Conn) http.RoundTripper),
+		},
+	}
+	return h
+}
+
+// ErrCertificatePinMismatch is returned when a server does not present a
+// certificate matching any of the pinned public keys
+var ErrCertificatePinMismatch = errors.New("TLS certificate does not match any pinned public key")
+
+// NewPinnedHTTPClientWithTimeout initialises a new HTTP client with the
+// specified timeout duration which only accepts servers presenting a
+// certificate matching one of the pins. Each pin is the base64 encoded SHA256
+// hash of a certificate's SubjectPublicKeyInfo
+func NewPinnedHTTPClientWithTimeout(t time.Duration, pins []string) (*http.Client, error) {
+	verify, err := VerifyPinnedCertificate(pins)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &http.Client{
+		Timeout: t,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{
+				VerifyPeerCertificate: verify,
+			},
+		},
+	}
+	return h, nil
+}
+
+// VerifyPinnedCertificate returns a tls.Config VerifyPeerCertificate function
+// which returns ErrCertificatePinMismatch unless a certificate in the verified
+// chains matches one of the pins
+func VerifyPinnedCertificate(pins []string) (func([][]byte, [][]*x509.Certificate) error, error) {
+	if len(pins) == 0 {
+		return nil, errors.New("no certificate pins supplied")
+	}
+
+	pinned := make(map[[sha256.Size]byte]bool, len(pins))
+	for x := range pins {
+		hash, err := Base64Decode(pins[x])
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("invalid certificate pin %s", pins[x])
+		}
+		var h [sha256.Size]byte
+		copy(h[:], hash)
+		pinned[h] = true
+	}
+
+	return func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+		for x := range verifiedChains {
+			for y := range verifiedChains[x] {
+				if pinned[sha256.Sum256(verifiedChains[x][y].RawSubjectPublicKeyInfo)] {
+					return nil
+				}
+			}
+		}
+		return ErrCertificatePinMismatch
+	}, nil
+}
+
 // GetRandomSalt returns a random salt
 func GetRandomSalt(input []byte, saltLen int) ([]byte, error) {
 	if saltLen <= 0 {
@@ -134,6 +208,12 @@ func GetHMAC(hashType int, input, key []byte) []byte {
 	return hmac.Sum(nil)
 }
 
+// GetHMACBase64 returns a base64 encoded keyed-hash message authentication
+// code using the desired hashtype
+func GetHMACBase64(hashType int, input, key []byte) string {
+	return Base64Encode(GetHMAC(hashType, input, key))
+}
+
 // Sha1ToHex takes a string, sha1 hashes it and return a hex string of the
 // result
 func Sha1ToHex(data string) string {
@@ -261,6 +341,24 @@ func StringToLower(input string) string {
 	return strings.ToLower(input)
 }
 
+// StringSliceToUpper changes every string in a slice to uppercase
+func StringSliceToUpper(input []string) []string {
+	output := make([]string, len(input))
+	for x := range input {
+		output[x] = strings.ToUpper(input[x])
+	}
+	return output
+}
+
+// StringSliceToLower changes every string in a slice to lowercase
+func StringSliceToLower(input []string) []string {
+	output := make([]string, len(input))
+	for x := range input {
+		output[x] = strings.ToLower(input[x])
+	}
+	return output
+}
+
 // RoundFloat rounds your floating point number to the desired decimal place
 func RoundFloat(x float64, prec int) float64 {
 	var rounder float64
@@ -282,6 +380,20 @@ func RoundFloat(x float64, prec int) float64 {
 	return rounder / pow
 }
 
+// RoundToDecimals rounds a floating point number to the supplied number of
+// decimal places using round half to even (bankers rounding) to avoid a
+// directional bias when rounding order prices and amounts
+func RoundToDecimals(value float64, places int) float64 {
+	pow := math.Pow(10, float64(places))
+	// Trim binary representation noise (e.g. 2.675 * 100 = 267.49999999999997)
+	// before rounding so exact halves are detected
+	scaled, err := strconv.ParseFloat(strconv.FormatFloat(value*pow, 'f', 6, 64), 64)
+	if err != nil {
+		scaled = value * pow
+	}
+	return math.RoundToEven(scaled) / pow
+}
+
 // IsEnabled takes in a boolean param  and returns a string if it is enabled
 // or disabled
 func IsEnabled(isEnabled bool) string {
@@ -342,6 +454,25 @@ func CalculateNetProfit(amount, priceThen, priceNow, costs float64) float64 {
 	return (priceNow * amount) - (priceThen * amount) - costs
 }
 
+// CalculateVWAP returns the volume weighted average price of the supplied
+// prices and volumes, or zero if the total volume is zero
+func CalculateVWAP(prices, volumes []float64) (float64, error) {
+	if len(prices) != len(volumes) {
+		return 0, errors.New("prices and volumes length mismatch")
+	}
+
+	var total, totalVolume float64
+	for x := range prices {
+		total += prices[x] * volumes[x]
+		totalVolume += volumes[x]
+	}
+
+	if totalVolume == 0 {
+		return 0, nil
+	}
+	return total / totalVolume, nil
+}
+
 // SendHTTPRequest sends a request using the http package and returns a response
 // as a string and an error
 func SendHTTPRequest(method, path string, headers map[string]string, body io.Reader) (string, error) {
@@ -440,6 +571,44 @@ func EncodeURLValues(url string, values url.Values) string {
 	return path
 }
 
+// EncodeURLValuesOrdered encodes url values in "URL encoded" form with the keys
+// listed in order encoded first, in that order, followed by any remaining keys
+// sorted alphabetically. Multiple values for a key keep their insertion order.
+// With no order supplied the output is identical to url.Values.Encode
+func EncodeURLValuesOrdered(values url.Values, order []string) string {
+	var keys []string
+	seen := make(map[string]bool)
+	for x := range order {
+		if _, ok := values[order[x]]; ok && !seen[order[x]] {
+			keys = append(keys, order[x])
+			seen[order[x]] = true
+		}
+	}
+
+	var remaining []string
+	for k := range values {
+		if !seen[k] {
+			remaining = append(remaining, k)
+		}
+	}
+	sort.Strings(remaining)
+	keys = append(keys, remaining...)
+
+	var buf bytes.Buffer
+	for x := range keys {
+		escapedKey := url.QueryEscape(keys[x])
+		for _, v := range values[keys[x]] {
+			if buf.Len() > 0 {
+				buf.WriteByte('&')
+			}
+			buf.WriteString(escapedKey)
+			buf.WriteByte('=')
+			buf.WriteString(url.QueryEscape(v))
+		}
+	}
+	return buf.String()
+}
+
 // ExtractHost returns the hostname out of a string
 func ExtractHost(address string) string {
 	host := SplitStrings(address, ":")[0]
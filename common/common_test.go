@@ -2,7 +2,10 @@ package common
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"strings"
@@ -71,6 +74,66 @@ func TestIsValidCryptoAddress(t *testing.T) {
 	}
 }
 
+func TestNewHTTP1ClientWithTimeout(t *testing.T) {
+	t.Parallel()
+	client := NewHTTP1ClientWithTimeout(time.Second * 5)
+	if client.Timeout != time.Second*5 {
+		t.Error("Test failed. NewHTTP1ClientWithTimeout unexpected timeout")
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok || transport.TLSNextProto == nil || len(transport.TLSNextProto) != 0 {
+		t.Error("Test failed. NewHTTP1ClientWithTimeout HTTP/2 not disabled")
+	}
+}
+
+func TestNewPinnedHTTPClientWithTimeout(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("rawr"))
+	}))
+	defer srv.Close()
+
+	hash := sha256.Sum256(srv.Certificate().RawSubjectPublicKeyInfo)
+	pin := Base64Encode(hash[:])
+	roots := srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+
+	_, err := NewPinnedHTTPClientWithTimeout(time.Second*5, nil)
+	if err == nil {
+		t.Error("Test failed. NewPinnedHTTPClientWithTimeout accepted no pins")
+	}
+
+	_, err = NewPinnedHTTPClientWithTimeout(time.Second*5, []string{"rawr"})
+	if err == nil {
+		t.Error("Test failed. NewPinnedHTTPClientWithTimeout accepted an invalid pin")
+	}
+
+	client, err := NewPinnedHTTPClientWithTimeout(time.Second*5, []string{pin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Transport.(*http.Transport).TLSClientConfig.RootCAs = roots
+
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Test failed. NewPinnedHTTPClientWithTimeout pinned request error %s", err)
+	}
+	resp.Body.Close()
+
+	other := sha256.Sum256([]byte("rawr"))
+	client, err = NewPinnedHTTPClientWithTimeout(time.Second*5, []string{Base64Encode(other[:])})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Transport.(*http.Transport).TLSClientConfig.RootCAs = roots
+
+	_, err = client.Get(srv.URL)
+	urlErr, ok := err.(*url.Error)
+	if !ok || urlErr.Err != ErrCertificatePinMismatch {
+		t.Errorf("Test failed. NewPinnedHTTPClientWithTimeout unexpected error %v", err)
+	}
+}
+
 func TestGetRandomSalt(t *testing.T) {
 	t.Parallel()
 
@@ -197,6 +260,16 @@ func TestGetHMAC(t *testing.T) {
 
 }
 
+func TestGetHMACBase64(t *testing.T) {
+	t.Parallel()
+	expected := "NkQGDCCeUBaOCINv+JERyuA7h84LqprFtxyWT6hpPmY="
+	actual := GetHMACBase64(HashSHA256, []byte("Hello,World"), []byte("1234"))
+	if actual != expected {
+		t.Errorf("Test failed. Common GetHMACBase64 error: Expected '%s'. Actual '%s'",
+			expected, actual)
+	}
+}
+
 func TestSha1Tohex(t *testing.T) {
 	t.Parallel()
 	expectedResult := "fcfbfcd7d31d994ef660f6972399ab5d7a890149"
@@ -218,6 +291,36 @@ func TestStringToLower(t *testing.T) {
 	}
 }
 
+func TestStringSliceToLower(t *testing.T) {
+	t.Parallel()
+	actualResult := StringSliceToLower([]string{"HEY", "Man", "bro"})
+	expectedResult := []string{"hey", "man", "bro"}
+	if !reflect.DeepEqual(actualResult, expectedResult) {
+		t.Errorf("Test failed. Expected '%s'. Actual '%s'",
+			expectedResult, actualResult)
+	}
+
+	actualResult = StringSliceToLower([]string{})
+	if len(actualResult) != 0 {
+		t.Errorf("Test failed. Expected empty slice. Actual '%s'", actualResult)
+	}
+}
+
+func TestStringSliceToUpper(t *testing.T) {
+	t.Parallel()
+	actualResult := StringSliceToUpper([]string{"hey", "Man", "BRO"})
+	expectedResult := []string{"HEY", "MAN", "BRO"}
+	if !reflect.DeepEqual(actualResult, expectedResult) {
+		t.Errorf("Test failed. Expected '%s'. Actual '%s'",
+			expectedResult, actualResult)
+	}
+
+	actualResult = StringSliceToUpper(nil)
+	if len(actualResult) != 0 {
+		t.Errorf("Test failed. Expected empty slice. Actual '%s'", actualResult)
+	}
+}
+
 func TestStringToUpper(t *testing.T) {
 	t.Parallel()
 	upperCaseString := "hey man"
@@ -442,6 +545,31 @@ func TestRoundFloat(t *testing.T) {
 	}
 }
 
+func TestRoundToDecimals(t *testing.T) {
+	t.Parallel()
+	testTable := []struct {
+		input    float64
+		places   int
+		expected float64
+	}{
+		{2.3232323, 2, 2.32},
+		{-2.3232323, 2, -2.32},
+		{2.675, 2, 2.68},
+		{2.665, 2, 2.66},
+		{0.5, 0, 0},
+		{1.5, 0, 2},
+		{-2.5, 0, -2},
+		{0.123456789, 8, 0.12345679},
+	}
+	for _, test := range testTable {
+		actualOutput := RoundToDecimals(test.input, test.places)
+		if actualOutput != test.expected {
+			t.Errorf("Test failed. RoundToDecimals Expected '%f'. Actual '%f'.",
+				test.expected, actualOutput)
+		}
+	}
+}
+
 func TestYesOrNo(t *testing.T) {
 	t.Parallel()
 	if !YesOrNo("y") {
@@ -517,6 +645,27 @@ func TestCalculateNetProfit(t *testing.T) {
 	}
 }
 
+func TestCalculateVWAP(t *testing.T) {
+	t.Parallel()
+	actualResult, err := CalculateVWAP([]float64{10, 13}, []float64{1, 2})
+	if err != nil {
+		t.Fatal("Test failed. CalculateVWAP() error", err)
+	}
+	if actualResult != 12 {
+		t.Errorf("Test failed. Expected '%f'. Actual '%f'.", float64(12), actualResult)
+	}
+
+	actualResult, err = CalculateVWAP([]float64{10, 13}, []float64{0, 0})
+	if err != nil || actualResult != 0 {
+		t.Errorf("Test failed. Expected zero volume to return '0'. Actual '%f'.", actualResult)
+	}
+
+	_, err = CalculateVWAP([]float64{10}, nil)
+	if err == nil {
+		t.Error("Test failed. CalculateVWAP() expected length mismatch error")
+	}
+}
+
 func TestSendHTTPRequest(t *testing.T) {
 	methodPost := "pOst"
 	methodGet := "GeT"
@@ -677,6 +826,32 @@ func TestEncodeURLValues(t *testing.T) {
 	}
 }
 
+func TestEncodeURLValuesOrdered(t *testing.T) {
+	t.Parallel()
+	values := url.Values{}
+	values.Set("nonce", "1")
+	values.Set("method", "getInfo")
+	values.Add("pair", "eth_btc")
+	values.Add("pair", "ltc/btc")
+
+	output := EncodeURLValuesOrdered(values, nil)
+	if output != values.Encode() {
+		t.Errorf("Test Failed - common EncodeURLValuesOrdered expected %s received %s",
+			values.Encode(), output)
+	}
+
+	expectedOutput := "nonce=1&pair=eth_btc&pair=ltc%2Fbtc&method=getInfo"
+	output = EncodeURLValuesOrdered(values, []string{"nonce", "missing", "pair", "nonce"})
+	if output != expectedOutput {
+		t.Errorf("Test Failed - common EncodeURLValuesOrdered expected %s received %s",
+			expectedOutput, output)
+	}
+
+	if EncodeURLValuesOrdered(url.Values{}, nil) != "" {
+		t.Error("Test Failed - common EncodeURLValuesOrdered expected empty output")
+	}
+}
+
 func TestExtractHost(t *testing.T) {
 	t.Parallel()
 	address := "localhost:1337"
@@ -99,6 +99,7 @@ type Config struct {
 	Name              string               `json:"name"`
 	EncryptConfig     int                  `json:"encryptConfig"`
 	GlobalHTTPTimeout time.Duration        `json:"globalHTTPTimeout"`
+	CacheTTL          time.Duration        `json:"cacheTTL,omitempty"`
 	Currency          CurrencyConfig       `json:"currencyConfig"`
 	Communications    CommunicationsConfig `json:"communications"`
 	Portfolio         portfolio.Base       `json:"portfolioAddresses"`
@@ -123,6 +124,10 @@ type ExchangeConfig struct {
 	RESTPollingDelay          time.Duration             `json:"restPollingDelay"`
 	HTTPTimeout               time.Duration             `json:"httpTimeout"`
 	HTTPUserAgent             string                    `json:"httpUserAgent"`
+	DisableHTTP2              bool                      `json:"disableHttp2,omitempty"`
+	SyncNonceWithServerTime   bool                      `json:"syncNonceWithServerTime,omitempty"`
+	CurrencyPairsCacheTTL     time.Duration             `json:"currencyPairsCacheTTL,omitempty"`
+	EnableRESTPolling         bool                      `json:"enableRestPolling,omitempty"`
 	AuthenticatedAPISupport   bool                      `json:"authenticatedApiSupport"`
 	APIKey                    string                    `json:"apiKey"`
 	APISecret                 string                    `json:"apiSecret"`
@@ -131,6 +136,15 @@ type ExchangeConfig struct {
 	APIURL                    string                    `json:"apiUrl"`
 	APIURLSecondary           string                    `json:"apiUrlSecondary"`
 	ProxyAddress              string                    `json:"proxyAddress"`
+	ProxyFallback             int                       `json:"proxyFallback,omitempty"`
+	ProxyBypass               time.Duration             `json:"proxyBypassDuration,omitempty"`
+	TLSPins                   []string                  `json:"tlsPins,omitempty"`
+	BreakerThreshold          int                       `json:"circuitBreakerThreshold,omitempty"`
+	BreakerWindow             time.Duration             `json:"circuitBreakerWindow,omitempty"`
+	BreakerCooldown           time.Duration             `json:"circuitBreakerCooldown,omitempty"`
+	MaxResponseSize           int64                     `json:"maxResponseSize,omitempty"`
+	WebsocketPingInterval     time.Duration             `json:"websocketPingInterval,omitempty"`
+	WebsocketPongTimeout      time.Duration             `json:"websocketPongTimeout,omitempty"`
 	WebsocketURL              string                    `json:"websocketUrl"`
 	ClientID                  string                    `json:"clientId,omitempty"`
 	AvailablePairs            string                    `json:"availablePairs"`
@@ -138,10 +152,27 @@ type ExchangeConfig struct {
 	BaseCurrencies            string                    `json:"baseCurrencies"`
 	AssetTypes                string                    `json:"assetTypes"`
 	SupportsAutoPairUpdates   bool                      `json:"supportsAutoPairUpdates"`
+	DisableAutoPairUpdates    bool                      `json:"disableAutoPairUpdates,omitempty"`
+	AllowWithdrawPermission   bool                      `json:"allowWithdrawPermission,omitempty"`
+	TradingEnabled            *bool                     `json:"tradingEnabled,omitempty"`
+	InfoRefreshInterval       time.Duration             `json:"infoRefreshInterval,omitempty"`
+	WithdrawalAllowlist       map[string][]string       `json:"withdrawalAllowlist,omitempty"`
+	MaxOrderNotional          float64                   `json:"maxOrderNotional,omitempty"`
+	MaxPairOrderNotional      map[string]float64        `json:"maxPairOrderNotional,omitempty"`
+	CheckOrderBalance         bool                      `json:"checkOrderBalance,omitempty"`
 	PairsLastUpdated          int64                     `json:"pairsLastUpdated,omitempty"`
 	ConfigCurrencyPairFormat  *CurrencyPairFormatConfig `json:"configCurrencyPairFormat"`
 	RequestCurrencyPairFormat *CurrencyPairFormatConfig `json:"requestCurrencyPairFormat"`
 	BankAccounts              []BankAccount             `json:"bankAccounts"`
+	FeeTiers                  []FeeTier                 `json:"feeTiers,omitempty"`
+}
+
+// FeeTier holds the maker and taker trading fee rates which apply once the
+// 30 day trading volume reaches the tier volume
+type FeeTier struct {
+	Volume float64 `json:"volume"`
+	Maker  float64 `json:"maker"`
+	Taker  float64 `json:"taker"`
 }
 
 // BankAccount holds differing bank account details by supported funding
@@ -1201,6 +1232,7 @@ func (c *Config) UpdateConfig(configPath string, newCfg Config) error {
 	c.EncryptConfig = newCfg.EncryptConfig
 	c.Currency = newCfg.Currency
 	c.GlobalHTTPTimeout = newCfg.GlobalHTTPTimeout
+	c.CacheTTL = newCfg.CacheTTL
 	c.Portfolio = newCfg.Portfolio
 	c.Communications = newCfg.Communications
 	c.Webserver = newCfg.Webserver
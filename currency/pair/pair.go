@@ -3,6 +3,7 @@ package pair
 import (
 	"math/rand"
 	"strings"
+	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 )
@@ -26,6 +27,55 @@ func (c CurrencyItem) String() string {
 	return string(c)
 }
 
+// currencyAliases maps known stablecoin and exchange specific currency aliases
+// to their canonical currency, used when matching pairs across exchanges
+var currencyAliases = map[string]string{
+	"USDT": "USD",
+	"TUSD": "USD",
+	"USDC": "USD",
+	"ZUSD": "USD",
+}
+
+var aliasMtx sync.RWMutex
+
+// SetCurrencyAlias sets the canonical currency for an alias, overriding any
+// existing mapping
+func SetCurrencyAlias(alias, canonical string) {
+	aliasMtx.Lock()
+	currencyAliases[common.StringToUpper(alias)] = common.StringToUpper(canonical)
+	aliasMtx.Unlock()
+}
+
+// RemoveCurrencyAlias removes the mapping for an alias
+func RemoveCurrencyAlias(alias string) {
+	aliasMtx.Lock()
+	delete(currencyAliases, common.StringToUpper(alias))
+	aliasMtx.Unlock()
+}
+
+// NormalizeCurrency returns the canonical currency for a currency alias, or
+// the uppercase currency if it is not an alias
+func NormalizeCurrency(c CurrencyItem) CurrencyItem {
+	aliasMtx.RLock()
+	defer aliasMtx.RUnlock()
+	if canonical, ok := currencyAliases[c.Upper().String()]; ok {
+		return CurrencyItem(canonical)
+	}
+	return c.Upper()
+}
+
+// isCanonicalCurrency returns whether a currency is the target of an alias
+func isCanonicalCurrency(c CurrencyItem) bool {
+	aliasMtx.RLock()
+	defer aliasMtx.RUnlock()
+	for _, canonical := range currencyAliases {
+		if canonical == c.String() {
+			return true
+		}
+	}
+	return false
+}
+
 // CurrencyPair holds currency pair information
 type CurrencyPair struct {
 	Delimiter      string       `json:"delimiter"`
@@ -73,6 +123,24 @@ func (c CurrencyPair) Equal(p CurrencyPair, exact bool) bool {
 	return false
 }
 
+// Normalize returns the pair with currency aliases mapped to their canonical
+// currency and the canonical currency as the second currency, so that pairs
+// such as BTC_USDT and USDT_BTC both normalize to BTC_USD. A pair whose
+// currencies normalize to the same currency, such as USDT_USD, is returned
+// unchanged
+func (c CurrencyPair) Normalize() CurrencyPair {
+	p := c
+	p.FirstCurrency = NormalizeCurrency(c.FirstCurrency)
+	p.SecondCurrency = NormalizeCurrency(c.SecondCurrency)
+	if p.FirstCurrency == p.SecondCurrency {
+		return c
+	}
+	if isCanonicalCurrency(p.FirstCurrency) && !isCanonicalCurrency(p.SecondCurrency) {
+		return p.Swap()
+	}
+	return p
+}
+
 // Swap swaps the pairs first and second currencies
 func (c CurrencyPair) Swap() CurrencyPair {
 	p := c
@@ -141,6 +141,32 @@ func TestEqual(t *testing.T) {
 	}
 }
 
+func TestNormalize(t *testing.T) {
+	expected := NewCurrencyPairDelimiter("BTC_USD", "_")
+	for _, x := range []string{"BTC_USD", "BTC_USDT", "USDT_BTC", "btc_usdt", "BTC_TUSD"} {
+		p := NewCurrencyPairDelimiter(x, "_").Normalize()
+		if !p.Equal(expected, true) {
+			t.Errorf("Test failed. TestNormalize: %s normalized to %s", x, p.Pair())
+		}
+	}
+
+	p := NewCurrencyPairDelimiter("USDT_USD", "_").Normalize()
+	if p.Pair() != "USDT_USD" {
+		t.Errorf("Test failed. TestNormalize: Unexpected value %s", p.Pair())
+	}
+
+	SetCurrencyAlias("xbt", "btc")
+	p = NewCurrencyPair("XBT", "ZUSD").Normalize()
+	if p.FirstCurrency != "BTC" || p.SecondCurrency != "USD" {
+		t.Errorf("Test failed. TestNormalize: Unexpected value %s", p.Pair())
+	}
+
+	RemoveCurrencyAlias("XBT")
+	if NormalizeCurrency("xbt") != "XBT" {
+		t.Error("Test failed. TestNormalize: Alias not removed")
+	}
+}
+
 func TestSwap(t *testing.T) {
 	t.Parallel()
 	pair := NewCurrencyPair("BTC", "USD")
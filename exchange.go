@@ -85,11 +85,41 @@ func ReloadExchange(name string) error {
 	}
 
 	e := GetExchangeByName(nameLower)
-	e.Setup(exchCfg)
+	err = e.Setup(exchCfg)
+	if err != nil {
+		return err
+	}
 	log.Printf("%s exchange reloaded successfully.\n", name)
 	return nil
 }
 
+// ReconfigureExchange applies an exchange config by name to the running
+// exchange without reloading it
+func ReconfigureExchange(name string) error {
+	nameLower := common.StringToLower(name)
+
+	if len(bot.exchanges) == 0 {
+		return ErrNoExchangesLoaded
+	}
+
+	if !CheckExchangeExists(nameLower) {
+		return ErrExchangeNotFound
+	}
+
+	exchCfg, err := bot.config.GetExchangeConfig(name)
+	if err != nil {
+		return err
+	}
+
+	e := GetExchangeByName(nameLower)
+	err = e.Reconfigure(exchCfg)
+	if err != nil {
+		return err
+	}
+	log.Printf("%s exchange reconfigured successfully.\n", name)
+	return nil
+}
+
 // UnloadExchange unloads an exchange by name
 func UnloadExchange(name string) error {
 	nameLower := common.StringToLower(name)
@@ -115,15 +145,70 @@ func UnloadExchange(name string) error {
 
 	for x := range bot.exchanges {
 		if bot.exchanges[x].GetName() == name {
-			bot.exchanges[x].SetEnabled(false)
+			err = bot.exchanges[x].Disable()
 			bot.exchanges = append(bot.exchanges[:x], bot.exchanges[x+1:]...)
-			return nil
+			return err
 		}
 	}
 
 	return ErrExchangeNotFound
 }
 
+// EnableExchange enables a disabled exchange at runtime and restarts its
+// routines, which re-initialise its currency pairs and websocket connection.
+// The routines run until the exchange is disabled, so EnableExchange does not
+// wait for them when useWG is false
+func EnableExchange(name string, useWG bool, wg *sync.WaitGroup) error {
+	nameLower := common.StringToLower(name)
+
+	if len(bot.exchanges) == 0 {
+		return ErrNoExchangesLoaded
+	}
+
+	if !CheckExchangeExists(nameLower) {
+		return ErrExchangeNotFound
+	}
+
+	e := GetExchangeByName(nameLower)
+	if e.IsEnabled() {
+		return nil
+	}
+
+	err := e.Enable()
+	if err != nil {
+		return err
+	}
+
+	if useWG {
+		e.Start(wg)
+	} else {
+		e.Start(&sync.WaitGroup{})
+	}
+	log.Printf("%s exchange enabled successfully.\n", name)
+	return nil
+}
+
+// DisableExchange disables a loaded exchange at runtime, stopping its
+// routines without unloading it
+func DisableExchange(name string) error {
+	nameLower := common.StringToLower(name)
+
+	if len(bot.exchanges) == 0 {
+		return ErrNoExchangesLoaded
+	}
+
+	if !CheckExchangeExists(nameLower) {
+		return ErrExchangeNotFound
+	}
+
+	err := GetExchangeByName(nameLower).Disable()
+	if err != nil {
+		return err
+	}
+	log.Printf("%s exchange disabled successfully.\n", name)
+	return nil
+}
+
 // LoadExchange loads an exchange by name
 func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 	nameLower := common.StringToLower(name)
@@ -205,14 +290,17 @@ func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 	}
 
 	exch.SetDefaults()
-	bot.exchanges = append(bot.exchanges, exch)
 	exchCfg, err := bot.config.GetExchangeConfig(name)
 	if err != nil {
 		return err
 	}
 
 	exchCfg.Enabled = true
-	exch.Setup(exchCfg)
+	err = exch.Setup(exchCfg)
+	if err != nil {
+		return err
+	}
+	bot.exchanges = append(bot.exchanges, exch)
 
 	if useWG {
 		exch.Start(wg)
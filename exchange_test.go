@@ -1,9 +1,12 @@
 package main
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/exchanges"
 )
 
 var testSetup = false
@@ -107,6 +110,30 @@ func TestReloadExchange(t *testing.T) {
 	}
 }
 
+func TestReconfigureExchange(t *testing.T) {
+	SetupTest(t)
+
+	err := ReconfigureExchange("asdf")
+	if err != ErrExchangeNotFound {
+		t.Errorf("Test failed. TestReconfigureExchange: Incorrect result: %s",
+			err)
+	}
+
+	err = ReconfigureExchange("Bitfinex")
+	if err != nil {
+		t.Errorf("Test failed. TestReconfigureExchange: Incorrect result: %s",
+			err)
+	}
+
+	CleanupTest(t)
+
+	err = ReconfigureExchange("asdf")
+	if err != ErrNoExchangesLoaded {
+		t.Errorf("Test failed. TestReconfigureExchange: Incorrect result: %s",
+			err)
+	}
+}
+
 func TestUnloadExchange(t *testing.T) {
 	SetupTest(t)
 
@@ -131,6 +158,101 @@ func TestUnloadExchange(t *testing.T) {
 	CleanupTest(t)
 }
 
+// stubExchange is an exchange whose routines run until it is stopped, like an
+// exchange polling its REST API
+type stubExchange struct {
+	exchange.IBotExchange
+	base exchange.Base
+}
+
+func (s *stubExchange) GetName() string {
+	return s.base.GetName()
+}
+
+func (s *stubExchange) IsEnabled() bool {
+	return s.base.IsEnabled()
+}
+
+func (s *stubExchange) Enable() error {
+	return s.base.Enable()
+}
+
+func (s *stubExchange) Disable() error {
+	return s.base.Disable()
+}
+
+func (s *stubExchange) Start(wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		<-s.base.GetShutdownChannel()
+		wg.Done()
+	}()
+}
+
+func TestEnableDisableExchange(t *testing.T) {
+	stub := &stubExchange{}
+	stub.base.Name = "Stub"
+	stub.base.Enabled = true
+
+	loaded := bot.exchanges
+	bot.exchanges = []exchange.IBotExchange{stub}
+	defer func() { bot.exchanges = loaded }()
+
+	err := DisableExchange("asdf")
+	if err != ErrExchangeNotFound {
+		t.Errorf("Test failed. TestEnableDisableExchange: Incorrect result: %s",
+			err)
+	}
+
+	err = DisableExchange("Stub")
+	if err != nil {
+		t.Errorf("Test failed. TestEnableDisableExchange: Failed to disable exchange. %s",
+			err)
+	}
+
+	if GetExchangeByName("Stub").IsEnabled() {
+		t.Error("Test failed. TestEnableDisableExchange: Exchange enabled after disable")
+	}
+
+	err = EnableExchange("asdf", false, nil)
+	if err != ErrExchangeNotFound {
+		t.Errorf("Test failed. TestEnableDisableExchange: Incorrect result: %s",
+			err)
+	}
+
+	enabled := make(chan error, 1)
+	go func() { enabled <- EnableExchange("Stub", false, nil) }()
+	select {
+	case err = <-enabled:
+		if err != nil {
+			t.Errorf("Test failed. TestEnableDisableExchange: Failed to enable exchange. %s",
+				err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Test failed. TestEnableDisableExchange: EnableExchange waited for the exchange routines")
+	}
+
+	if !GetExchangeByName("Stub").IsEnabled() {
+		t.Error("Test failed. TestEnableDisableExchange: Exchange disabled after enable")
+	}
+
+	var wg sync.WaitGroup
+	stub.Start(&wg)
+	err = DisableExchange("Stub")
+	if err != nil {
+		t.Errorf("Test failed. TestEnableDisableExchange: Failed to disable exchange. %s",
+			err)
+	}
+	wg.Wait()
+
+	bot.exchanges = nil
+	err = DisableExchange("asdf")
+	if err != ErrNoExchangesLoaded {
+		t.Errorf("Test failed. TestEnableDisableExchange: Incorrect result: %s",
+			err)
+	}
+}
+
 func TestSetupExchanges(t *testing.T) {
 	SetupTest(t)
 	SetupExchanges()
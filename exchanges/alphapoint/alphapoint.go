@@ -523,7 +523,7 @@ func (a *Alphapoint) SendHTTPRequest(method, path string, data map[string]interf
 		return errors.New("SendHTTPRequest: Unable to JSON request")
 	}
 
-	return a.SendPayload(method, path, headers, bytes.NewBuffer(PayloadJSON), result, false, a.Verbose)
+	return a.SendPayload(method, path, headers, bytes.NewBuffer(PayloadJSON), result, false, a.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated request
@@ -551,5 +551,5 @@ func (a *Alphapoint) SendAuthenticatedHTTPRequest(method, path string, data map[
 		return errors.New("SendAuthenticatedHTTPRequest: Unable to JSON request")
 	}
 
-	return a.SendPayload(method, path, headers, bytes.NewBuffer(PayloadJSON), result, true, a.Verbose)
+	return a.SendPayload(method, path, headers, bytes.NewBuffer(PayloadJSON), result, true, a.IsVerbose())
 }
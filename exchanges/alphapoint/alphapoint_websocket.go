@@ -24,7 +24,7 @@ func (a *Alphapoint) WebsocketClient() {
 			continue
 		}
 
-		if a.Verbose {
+		if a.IsVerbose() {
 			log.Printf("%s Connected to Websocket.\n", a.Name)
 		}
 
@@ -2,6 +2,7 @@ package alphapoint
 
 import (
 	"errors"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
@@ -100,33 +101,60 @@ func (a *Alphapoint) GetExchangeFundTransferHistory() ([]exchange.FundHistory, e
 func (a *Alphapoint) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
-	return resp, errors.New("trade history not yet implemented")
+	return resp, exchange.ErrNotYetImplemented
+}
+
+// GetHistoricCandles returns candlestick data for a currency pair between the
+// start and end times at the specified interval
+func (a *Alphapoint) GetHistoricCandles(currencyPair pair.CurrencyPair, start, end time.Time, interval time.Duration) (exchange.Candles, error) {
+	return nil, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeServerTime returns the exchange server time
+func (a *Alphapoint) GetExchangeServerTime() (time.Time, error) {
+	return time.Time{}, exchange.ErrNotYetImplemented
+}
+
+// Ping checks that the exchange API is reachable
+func (a *Alphapoint) Ping() error {
+	return exchange.ErrNotYetImplemented
+}
+
+// AuthenticatedPing checks that the exchange accepts the API credentials
+func (a *Alphapoint) AuthenticatedPing() error {
+	return exchange.ErrNotYetImplemented
 }
 
 // SubmitExchangeOrder submits a new order and returns a true value when
 // successfully submitted
 func (a *Alphapoint) SubmitExchangeOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (int64, error) {
 	//return a.CreateOrder(p.Pair().String(), side, orderType, amount, price)
-	return 0, errors.New("not yet implemented")
+	return 0, exchange.ErrNotYetImplemented
+}
+
+// SubmitOrder submits a new order
+func (a *Alphapoint) SubmitOrder(req exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var resp exchange.SubmitOrderResponse
+	return resp, exchange.ErrNotYetImplemented
 }
 
 // ModifyExchangeOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (a *Alphapoint) ModifyExchangeOrder(orderID int64, action exchange.ModifyOrder) (int64, error) {
 	//return a.ModifyOrder(p.Pair().String(), orderID, action)
-	return 0, errors.New("not yet implemented")
+	return 0, exchange.ErrNotYetImplemented
 }
 
 // CancelExchangeOrder cancels an order by its corresponding ID number
 func (a *Alphapoint) CancelExchangeOrder(orderID int64) error {
 	//return a.CancelOrder(p.Pair().String(), orderID)
-	return errors.New("not yet implemented")
+	return exchange.ErrNotYetImplemented
 }
 
 // CancelAllExchangeOrders cancels all orders associated with a currency pair
 func (a *Alphapoint) CancelAllExchangeOrders() error {
 	//return a.CancelAllOrders(p.Pair().String())
-	return errors.New("not yet implemented")
+	return exchange.ErrNotYetImplemented
 }
 
 // GetExchangeOrderInfo returns information on a current open order
@@ -146,6 +174,18 @@ func (a *Alphapoint) GetExchangeOrderInfo(orderID int64) (float64, error) {
 	return 0, errors.New("order not found")
 }
 
+// GetExchangeOpenOrders returns all open orders for a currency pair, or across
+// all currency pairs if the pair is empty
+func (a *Alphapoint) GetExchangeOpenOrders(currencyPair pair.CurrencyPair) ([]exchange.OrderDetail, error) {
+	return nil, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeOrderHistory returns filled orders for a currency pair, or across
+// all currency pairs if the pair is empty, between the start and end times
+func (a *Alphapoint) GetExchangeOrderHistory(currencyPair pair.CurrencyPair, start, end time.Time) ([]exchange.OrderDetail, error) {
+	return nil, exchange.ErrNotYetImplemented
+}
+
 // GetExchangeDepositAddress returns a deposit address for a specified currency
 func (a *Alphapoint) GetExchangeDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	addreses, err := a.GetDepositAddresses()
@@ -164,22 +204,28 @@ func (a *Alphapoint) GetExchangeDepositAddress(cryptocurrency pair.CurrencyItem)
 // WithdrawCryptoExchangeFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (a *Alphapoint) WithdrawCryptoExchangeFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
 }
 
 // WithdrawFiatExchangeFunds returns a withdrawal ID when a withdrawal is submitted
 func (a *Alphapoint) WithdrawFiatExchangeFunds(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
+}
+
+// WithdrawExchangeFunds submits a withdrawal request and returns a withdrawal
+// ID when the withdrawal is submitted
+func (a *Alphapoint) WithdrawExchangeFunds(req exchange.WithdrawalRequest) (string, error) {
+	return "", exchange.ErrNotYetImplemented
 }
 
 // GetWebsocket returns a pointer to the exchange websocket
 func (a *Alphapoint) GetWebsocket() (*exchange.Websocket, error) {
-	return nil, errors.New("not yet implemented")
+	return nil, exchange.ErrNotYetImplemented
 }
 
 // GetFeeByType returns an estimate of fee based on type of transaction
 func (a *Alphapoint) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
-	return 0, errors.New("not yet implemented")
+	return 0, exchange.ErrNotYetImplemented
 }
 
 // GetWithdrawCapabilities returns the types of withdrawal methods permitted by the exchange
@@ -72,7 +72,7 @@ func (a *ANX) SetDefaults() {
 }
 
 //Setup is run on startup to setup exchange with config values
-func (a *ANX) Setup(exch config.ExchangeConfig) {
+func (a *ANX) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		a.SetEnabled(false)
 	} else {
@@ -88,25 +88,26 @@ func (a *ANX) Setup(exch config.ExchangeConfig) {
 		a.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := a.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = a.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = a.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = a.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = a.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetCurrencies returns a list of supported currencies (both fiat
@@ -359,7 +360,7 @@ func (a *ANX) GetDepositAddress(currency, name string, new bool) (string, error)
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (a *ANX) SendHTTPRequest(path string, result interface{}) error {
-	return a.SendPayload("GET", path, nil, nil, result, false, a.Verbose)
+	return a.SendPayload("GET", path, nil, nil, result, false, a.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends a authenticated HTTP request
@@ -390,7 +391,7 @@ func (a *ANX) SendAuthenticatedHTTPRequest(path string, params map[string]interf
 		return errors.New("SendAuthenticatedHTTPRequest: Unable to JSON request")
 	}
 
-	if a.Verbose {
+	if a.IsVerbose() {
 		log.Printf("Request JSON: %s\n", PayloadJSON)
 	}
 
@@ -400,7 +401,7 @@ func (a *ANX) SendAuthenticatedHTTPRequest(path string, params map[string]interf
 	headers["Rest-Sign"] = common.Base64Encode([]byte(hmac))
 	headers["Content-Type"] = "application/json"
 
-	return a.SendPayload("POST", a.APIUrl+path, headers, bytes.NewBuffer(PayloadJSON), result, true, a.Verbose)
+	return a.SendPayload("POST", a.APIUrl+path, headers, bytes.NewBuffer(PayloadJSON), result, true, a.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
@@ -5,6 +5,7 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -17,14 +18,14 @@ import (
 func (a *ANX) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
 	go func() {
-		a.Run()
+		a.RunRecovered(a.Run)
 		wg.Done()
 	}()
 }
 
 // Run implements the ANX wrapper
 func (a *ANX) Run() {
-	if a.Verbose {
+	if a.IsVerbose() {
 		log.Printf("%s polling delay: %ds.\n", a.GetName(), a.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", a.GetName(), len(a.EnabledPairs), a.EnabledPairs)
 	}
@@ -192,62 +193,107 @@ func (a *ANX) GetExchangeFundTransferHistory() ([]exchange.FundHistory, error) {
 func (a *ANX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
-	return resp, errors.New("trade history not yet implemented")
+	return resp, exchange.ErrNotYetImplemented
+}
+
+// GetHistoricCandles returns candlestick data for a currency pair between the
+// start and end times at the specified interval
+func (a *ANX) GetHistoricCandles(currencyPair pair.CurrencyPair, start, end time.Time, interval time.Duration) (exchange.Candles, error) {
+	return nil, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeServerTime returns the exchange server time
+func (a *ANX) GetExchangeServerTime() (time.Time, error) {
+	return time.Time{}, exchange.ErrNotYetImplemented
+}
+
+// Ping checks that the exchange API is reachable
+func (a *ANX) Ping() error {
+	return exchange.ErrNotYetImplemented
+}
+
+// AuthenticatedPing checks that the exchange accepts the API credentials
+func (a *ANX) AuthenticatedPing() error {
+	return exchange.ErrNotYetImplemented
 }
 
 // SubmitExchangeOrder submits a new order
 func (a *ANX) SubmitExchangeOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (int64, error) {
-	return 0, errors.New("not yet implemented")
+	return 0, exchange.ErrNotYetImplemented
+}
+
+// SubmitOrder submits a new order
+func (a *ANX) SubmitOrder(req exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var resp exchange.SubmitOrderResponse
+	return resp, exchange.ErrNotYetImplemented
 }
 
 // ModifyExchangeOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (a *ANX) ModifyExchangeOrder(orderID int64, action exchange.ModifyOrder) (int64, error) {
-	return 0, errors.New("not yet implemented")
+	return 0, exchange.ErrNotYetImplemented
 }
 
 // CancelExchangeOrder cancels an order by its corresponding ID number
 func (a *ANX) CancelExchangeOrder(orderID int64) error {
-	return errors.New("not yet implemented")
+	return exchange.ErrNotYetImplemented
 }
 
 // CancelAllExchangeOrders cancels all orders associated with a currency pair
 func (a *ANX) CancelAllExchangeOrders() error {
-	return errors.New("not yet implemented")
+	return exchange.ErrNotYetImplemented
 }
 
 // GetExchangeOrderInfo returns information on a current open order
 func (a *ANX) GetExchangeOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	var orderDetail exchange.OrderDetail
-	return orderDetail, errors.New("not yet implemented")
+	return orderDetail, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeOpenOrders returns all open orders for a currency pair, or across
+// all currency pairs if the pair is empty
+func (a *ANX) GetExchangeOpenOrders(currencyPair pair.CurrencyPair) ([]exchange.OrderDetail, error) {
+	return nil, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeOrderHistory returns filled orders for a currency pair, or across
+// all currency pairs if the pair is empty, between the start and end times
+func (a *ANX) GetExchangeOrderHistory(currencyPair pair.CurrencyPair, start, end time.Time) ([]exchange.OrderDetail, error) {
+	return nil, exchange.ErrNotYetImplemented
 }
 
 // GetExchangeDepositAddress returns a deposit address for a specified currency
 func (a *ANX) GetExchangeDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
 }
 
 // WithdrawCryptoExchangeFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (a *ANX) WithdrawCryptoExchangeFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
 }
 
 // WithdrawFiatExchangeFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (a *ANX) WithdrawFiatExchangeFunds(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
 }
 
 // WithdrawFiatExchangeFundsToInternationalBank returns a withdrawal ID when a withdrawal is
 // submitted
 func (a *ANX) WithdrawFiatExchangeFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
+}
+
+// WithdrawExchangeFunds submits a withdrawal request and returns a withdrawal
+// ID when the withdrawal is submitted
+func (a *ANX) WithdrawExchangeFunds(req exchange.WithdrawalRequest) (string, error) {
+	return "", exchange.ErrNotYetImplemented
 }
 
 // GetWebsocket returns a pointer to the exchange websocket
 func (a *ANX) GetWebsocket() (*exchange.Websocket, error) {
-	return nil, errors.New("not yet implemented")
+	return nil, exchange.ErrNotYetImplemented
 }
 
 // GetFeeByType returns an estimate of fee based on type of transaction
@@ -81,7 +81,7 @@ func (b *Binance) SetDefaults() {
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
-func (b *Binance) Setup(exch config.ExchangeConfig) {
+func (b *Binance) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		b.SetEnabled(false)
 	} else {
@@ -97,23 +97,23 @@ func (b *Binance) Setup(exch config.ExchangeConfig) {
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := b.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.WebsocketSetup(b.WSConnect,
 			exch.Name,
@@ -121,9 +121,10 @@ func (b *Binance) Setup(exch config.ExchangeConfig) {
 			binanceDefaultWebsocketURL,
 			exch.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetExchangeValidCurrencyPairs returns the full pair list from the exchange
@@ -570,7 +571,7 @@ func (b *Binance) GetAccount() (*Account, error) {
 
 // SendHTTPRequest sends an unauthenticated request
 func (b *Binance) SendHTTPRequest(path string, result interface{}) error {
-	return b.SendPayload("GET", path, nil, nil, result, false, b.Verbose)
+	return b.SendPayload("GET", path, nil, nil, result, false, b.IsVerbose())
 }
 
 // SendAuthHTTPRequest sends an authenticated HTTP request
@@ -593,12 +594,12 @@ func (b *Binance) SendAuthHTTPRequest(method, path string, params url.Values, re
 	headers := make(map[string]string)
 	headers["X-MBX-APIKEY"] = b.APIKey
 
-	if b.Verbose {
+	if b.IsVerbose() {
 		log.Printf("sent path: \n%s\n", path)
 	}
 	path = common.EncodeURLValues(path, params)
 
-	return b.SendPayload(method, path, headers, bytes.NewBufferString(""), result, true, b.Verbose)
+	return b.SendPayload(method, path, headers, bytes.NewBufferString(""), result, true, b.IsVerbose())
 }
 
 // CheckLimit checks value against a variable list
@@ -111,7 +111,7 @@ func (b *Bitfinex) SetDefaults() {
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
-func (b *Bitfinex) Setup(exch config.ExchangeConfig) {
+func (b *Bitfinex) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		b.SetEnabled(false)
 	} else {
@@ -128,23 +128,23 @@ func (b *Bitfinex) Setup(exch config.ExchangeConfig) {
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := b.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.WebsocketSetup(b.WsConnect,
 			exch.Name,
@@ -152,9 +152,10 @@ func (b *Bitfinex) Setup(exch config.ExchangeConfig) {
 			bitfinexWebsocket,
 			exch.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetPlatformStatus returns the Bifinex platform status
@@ -163,7 +164,7 @@ func (b *Bitfinex) GetPlatformStatus() (int, error) {
 	path := fmt.Sprintf("%s/v%s/%s", b.APIUrl, bitfinexAPIVersion2,
 		bitfinexPlatformStatus)
 
-	err := b.SendHTTPRequest(path, &response, b.Verbose)
+	err := b.SendHTTPRequest(path, &response, b.IsVerbose())
 	if err != nil {
 		return 0, err
 	}
@@ -191,7 +192,7 @@ func (b *Bitfinex) GetTicker(symbol string) (Ticker, error) {
 	response := Ticker{}
 	path := common.EncodeURLValues(b.APIUrl+bitfinexAPIVersion+bitfinexTicker+symbol, url.Values{})
 
-	if err := b.SendHTTPRequest(path, &response, b.Verbose); err != nil {
+	if err := b.SendHTTPRequest(path, &response, b.IsVerbose()); err != nil {
 		return response, err
 	}
 
@@ -208,7 +209,7 @@ func (b *Bitfinex) GetTickerV2(symbol string) (Tickerv2, error) {
 	var ticker Tickerv2
 
 	path := fmt.Sprintf("%s/v%s/%s/%s", b.APIUrl, bitfinexAPIVersion2, bitfinexTickerV2, symbol)
-	err := b.SendHTTPRequest(path, &response, b.Verbose)
+	err := b.SendHTTPRequest(path, &response, b.IsVerbose())
 	if err != nil {
 		return ticker, err
 	}
@@ -255,7 +256,7 @@ func (b *Bitfinex) GetTickersV2(symbols string) ([]Tickersv2, error) {
 		bitfinexAPIVersion2,
 		bitfinexTickersV2), v)
 
-	err := b.SendHTTPRequest(path, &response, b.Verbose)
+	err := b.SendHTTPRequest(path, &response, b.IsVerbose())
 	if err != nil {
 		return nil, err
 	}
@@ -301,7 +302,7 @@ func (b *Bitfinex) GetStats(symbol string) ([]Stat, error) {
 	response := []Stat{}
 	path := fmt.Sprint(b.APIUrl + bitfinexAPIVersion + bitfinexStats + symbol)
 
-	return response, b.SendHTTPRequest(path, &response, b.Verbose)
+	return response, b.SendHTTPRequest(path, &response, b.IsVerbose())
 }
 
 // GetFundingBook the entire margin funding book for both bids and asks sides
@@ -311,7 +312,7 @@ func (b *Bitfinex) GetFundingBook(symbol string) (FundingBook, error) {
 	response := FundingBook{}
 	path := fmt.Sprint(b.APIUrl + bitfinexAPIVersion + bitfinexLendbook + symbol)
 
-	if err := b.SendHTTPRequest(path, &response, b.Verbose); err != nil {
+	if err := b.SendHTTPRequest(path, &response, b.IsVerbose()); err != nil {
 		return response, err
 	}
 
@@ -333,7 +334,7 @@ func (b *Bitfinex) GetOrderbook(currencyPair string, values url.Values) (Orderbo
 		b.APIUrl+bitfinexAPIVersion+bitfinexOrderbook+currencyPair,
 		values,
 	)
-	return response, b.SendHTTPRequest(path, &response, b.Verbose)
+	return response, b.SendHTTPRequest(path, &response, b.IsVerbose())
 }
 
 // GetOrderbookV2 retieves the orderbook bid and ask price points for a currency
@@ -347,7 +348,7 @@ func (b *Bitfinex) GetOrderbookV2(symbol, precision string, values url.Values) (
 	var book OrderbookV2
 	path := common.EncodeURLValues(fmt.Sprintf("%s/v%s/%s/%s/%s", b.APIUrl,
 		bitfinexAPIVersion2, bitfinexOrderbookV2, symbol, precision), values)
-	err := b.SendHTTPRequest(path, &response, b.Verbose)
+	err := b.SendHTTPRequest(path, &response, b.IsVerbose())
 	if err != nil {
 		return book, err
 	}
@@ -395,7 +396,7 @@ func (b *Bitfinex) GetTrades(currencyPair string, values url.Values) ([]TradeStr
 		b.APIUrl+bitfinexAPIVersion+bitfinexTrades+currencyPair,
 		values,
 	)
-	return response, b.SendHTTPRequest(path, &response, b.Verbose)
+	return response, b.SendHTTPRequest(path, &response, b.IsVerbose())
 }
 
 // GetTradesV2 uses the V2 API to get historic trades that occurred on the
@@ -415,7 +416,7 @@ func (b *Bitfinex) GetTradesV2(currencyPair string, timestampStart, timestampEnd
 		strconv.FormatInt(timestampStart, 10),
 		strconv.FormatInt(timestampEnd, 10))
 
-	err := b.SendHTTPRequest(path, &resp, b.Verbose)
+	err := b.SendHTTPRequest(path, &resp, b.IsVerbose())
 	if err != nil {
 		return actualHistory, err
 	}
@@ -459,7 +460,7 @@ func (b *Bitfinex) GetLendbook(symbol string, values url.Values) (Lendbook, erro
 	}
 	path := common.EncodeURLValues(b.APIUrl+bitfinexAPIVersion+bitfinexLendbook+symbol, values)
 
-	return response, b.SendHTTPRequest(path, &response, b.Verbose)
+	return response, b.SendHTTPRequest(path, &response, b.IsVerbose())
 }
 
 // GetLends returns a list of the most recent funding data for the given
@@ -470,7 +471,7 @@ func (b *Bitfinex) GetLends(symbol string, values url.Values) ([]Lends, error) {
 	response := []Lends{}
 	path := common.EncodeURLValues(b.APIUrl+bitfinexAPIVersion+bitfinexLends+symbol, values)
 
-	return response, b.SendHTTPRequest(path, &response, b.Verbose)
+	return response, b.SendHTTPRequest(path, &response, b.IsVerbose())
 }
 
 // GetSymbols returns the available currency pairs on the exchange
@@ -478,7 +479,7 @@ func (b *Bitfinex) GetSymbols() ([]string, error) {
 	products := []string{}
 	path := fmt.Sprint(b.APIUrl + bitfinexAPIVersion + bitfinexSymbols)
 
-	return products, b.SendHTTPRequest(path, &products, b.Verbose)
+	return products, b.SendHTTPRequest(path, &products, b.IsVerbose())
 }
 
 // GetSymbolsDetails a list of valid symbol IDs and the pair details
@@ -486,7 +487,7 @@ func (b *Bitfinex) GetSymbolsDetails() ([]SymbolDetails, error) {
 	response := []SymbolDetails{}
 	path := fmt.Sprint(b.APIUrl + bitfinexAPIVersion + bitfinexSymbolsDetails)
 
-	return response, b.SendHTTPRequest(path, &response, b.Verbose)
+	return response, b.SendHTTPRequest(path, &response, b.IsVerbose())
 }
 
 // GetAccountInfo returns information about your account incl. trading fees
@@ -901,7 +902,7 @@ func (b *Bitfinex) SendAuthenticatedHTTPRequest(method, path string, params map[
 		return errors.New("SendAuthenticatedHTTPRequest: Unable to JSON request")
 	}
 
-	if b.Verbose {
+	if b.IsVerbose() {
 		log.Printf("Request JSON: %s\n", PayloadJSON)
 	}
 
@@ -912,7 +913,7 @@ func (b *Bitfinex) SendAuthenticatedHTTPRequest(method, path string, params map[
 	headers["X-BFX-PAYLOAD"] = PayloadBase64
 	headers["X-BFX-SIGNATURE"] = common.HexEncodeToString(hmac)
 
-	err = b.SendPayload(method, b.APIUrl+bitfinexAPIVersion+path, headers, nil, result, true, b.Verbose)
+	err = b.SendPayload(method, b.APIUrl+bitfinexAPIVersion+path, headers, nil, result, true, b.IsVerbose())
 	if err != nil {
 		return err
 	}
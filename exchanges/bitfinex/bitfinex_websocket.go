@@ -115,7 +115,7 @@ func (b *Bitfinex) WsAddSubscriptionChannel(chanID int, channel, pair string) {
 	chanInfo := WebsocketChanInfo{Pair: pair, Channel: channel}
 	b.WebsocketSubdChannels[chanID] = chanInfo
 
-	if b.Verbose {
+	if b.IsVerbose() {
 		log.Printf("%s Subscribed to Channel: %s Pair: %s ChannelID: %d\n",
 			b.GetName(),
 			channel,
@@ -159,7 +159,7 @@ func (b *Bitfinex) WsConnect() error {
 	}
 
 	if hs.Event == "info" {
-		if b.Verbose {
+		if b.IsVerbose() {
 			log.Printf("%s Connected to Websocket.\n", b.GetName())
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -17,14 +18,14 @@ import (
 func (b *Bitfinex) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
 	go func() {
-		b.Run()
+		b.RunRecovered(b.Run)
 		wg.Done()
 	}()
 }
 
 // Run implements the Bitfinex wrapper
 func (b *Bitfinex) Run() {
-	if b.Verbose {
+	if b.IsVerbose() {
 		log.Printf("%s Websocket: %s.", b.GetName(), common.IsEnabled(b.Websocket.IsEnabled()))
 		log.Printf("%s polling delay: %ds.\n", b.GetName(), b.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
@@ -170,56 +171,101 @@ func (b *Bitfinex) GetExchangeFundTransferHistory() ([]exchange.FundHistory, err
 func (b *Bitfinex) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
-	return resp, errors.New("trade history not yet implemented")
+	return resp, exchange.ErrNotYetImplemented
+}
+
+// GetHistoricCandles returns candlestick data for a currency pair between the
+// start and end times at the specified interval
+func (b *Bitfinex) GetHistoricCandles(currencyPair pair.CurrencyPair, start, end time.Time, interval time.Duration) (exchange.Candles, error) {
+	return nil, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeServerTime returns the exchange server time
+func (b *Bitfinex) GetExchangeServerTime() (time.Time, error) {
+	return time.Time{}, exchange.ErrNotYetImplemented
+}
+
+// Ping checks that the exchange API is reachable
+func (b *Bitfinex) Ping() error {
+	return exchange.ErrNotYetImplemented
+}
+
+// AuthenticatedPing checks that the exchange accepts the API credentials
+func (b *Bitfinex) AuthenticatedPing() error {
+	return exchange.ErrNotYetImplemented
 }
 
 // SubmitExchangeOrder submits a new order
 func (b *Bitfinex) SubmitExchangeOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (int64, error) {
-	return 0, errors.New("not yet implemented")
+	return 0, exchange.ErrNotYetImplemented
+}
+
+// SubmitOrder submits a new order
+func (b *Bitfinex) SubmitOrder(req exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var resp exchange.SubmitOrderResponse
+	return resp, exchange.ErrNotYetImplemented
 }
 
 // ModifyExchangeOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bitfinex) ModifyExchangeOrder(orderID int64, action exchange.ModifyOrder) (int64, error) {
-	return 0, errors.New("not yet implemented")
+	return 0, exchange.ErrNotYetImplemented
 }
 
 // CancelExchangeOrder cancels an order by its corresponding ID number
 func (b *Bitfinex) CancelExchangeOrder(orderID int64) error {
-	return errors.New("not yet implemented")
+	return exchange.ErrNotYetImplemented
 }
 
 // CancelAllExchangeOrders cancels all orders associated with a currency pair
 func (b *Bitfinex) CancelAllExchangeOrders() error {
-	return errors.New("not yet implemented")
+	return exchange.ErrNotYetImplemented
 }
 
 // GetExchangeOrderInfo returns information on a current open order
 func (b *Bitfinex) GetExchangeOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	var orderDetail exchange.OrderDetail
-	return orderDetail, errors.New("not yet implemented")
+	return orderDetail, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeOpenOrders returns all open orders for a currency pair, or across
+// all currency pairs if the pair is empty
+func (b *Bitfinex) GetExchangeOpenOrders(currencyPair pair.CurrencyPair) ([]exchange.OrderDetail, error) {
+	return nil, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeOrderHistory returns filled orders for a currency pair, or across
+// all currency pairs if the pair is empty, between the start and end times
+func (b *Bitfinex) GetExchangeOrderHistory(currencyPair pair.CurrencyPair, start, end time.Time) ([]exchange.OrderDetail, error) {
+	return nil, exchange.ErrNotYetImplemented
 }
 
 // GetExchangeDepositAddress returns a deposit address for a specified currency
 func (b *Bitfinex) GetExchangeDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
 }
 
 // WithdrawCryptoExchangeFunds returns a withdrawal ID when a withdrawal is submitted
 func (b *Bitfinex) WithdrawCryptoExchangeFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
 }
 
 // WithdrawFiatExchangeFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bitfinex) WithdrawFiatExchangeFunds(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
 }
 
 // WithdrawFiatExchangeFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bitfinex) WithdrawFiatExchangeFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
+}
+
+// WithdrawExchangeFunds submits a withdrawal request and returns a withdrawal
+// ID when the withdrawal is submitted
+func (b *Bitfinex) WithdrawExchangeFunds(req exchange.WithdrawalRequest) (string, error) {
+	return "", exchange.ErrNotYetImplemented
 }
 
 // GetWebsocket returns a pointer to the exchange websocket
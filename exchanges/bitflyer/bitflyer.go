@@ -3,7 +3,6 @@ package bitflyer
 import (
 	"errors"
 	"fmt"
-	"log"
 	"net/url"
 	"strconv"
 	"time"
@@ -101,7 +100,7 @@ func (b *Bitflyer) SetDefaults() {
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
-func (b *Bitflyer) Setup(exch config.ExchangeConfig) {
+func (b *Bitflyer) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		b.SetEnabled(false)
 	} else {
@@ -118,25 +117,26 @@ func (b *Bitflyer) Setup(exch config.ExchangeConfig) {
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := b.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetLatestBlockCA returns the latest block information from bitflyer chain
@@ -376,7 +376,7 @@ func (b *Bitflyer) GetTradingCommission() {
 
 // SendHTTPRequest sends an unauthenticated request
 func (b *Bitflyer) SendHTTPRequest(path string, result interface{}) error {
-	return b.SendPayload("GET", path, nil, nil, result, false, b.Verbose)
+	return b.SendPayload("GET", path, nil, nil, result, false, b.IsVerbose())
 }
 
 // SendAuthHTTPRequest sends an authenticated HTTP request
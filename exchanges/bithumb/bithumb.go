@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"log"
 	"net/url"
 	"strconv"
 	"time"
@@ -81,7 +80,7 @@ func (b *Bithumb) SetDefaults() {
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
-func (b *Bithumb) Setup(exch config.ExchangeConfig) {
+func (b *Bithumb) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		b.SetEnabled(false)
 	} else {
@@ -98,25 +97,26 @@ func (b *Bithumb) Setup(exch config.ExchangeConfig) {
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := b.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetTradablePairs returns a list of tradable currencies
@@ -533,7 +533,7 @@ func (b *Bithumb) MarketSellOrder(currency string, units float64) (MarketSell, e
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (b *Bithumb) SendHTTPRequest(path string, result interface{}) error {
-	return b.SendPayload("GET", path, nil, nil, result, false, b.Verbose)
+	return b.SendPayload("GET", path, nil, nil, result, false, b.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to bithumb
@@ -564,7 +564,7 @@ func (b *Bithumb) SendAuthenticatedHTTPRequest(path string, params url.Values, r
 	headers["Api-Nonce"] = b.Nonce.String()
 	headers["Content-Type"] = "application/x-www-form-urlencoded"
 
-	return b.SendPayload("POST", b.APIUrl+path, headers, bytes.NewBufferString(payload), result, true, b.Verbose)
+	return b.SendPayload("POST", b.APIUrl+path, headers, bytes.NewBufferString(payload), result, true, b.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
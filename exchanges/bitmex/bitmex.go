@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"log"
 	"strconv"
 	"time"
 
@@ -131,7 +130,7 @@ func (b *Bitmex) SetDefaults() {
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
-func (b *Bitmex) Setup(exch config.ExchangeConfig) {
+func (b *Bitmex) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		b.SetEnabled(false)
 	} else {
@@ -146,23 +145,23 @@ func (b *Bitmex) Setup(exch config.ExchangeConfig) {
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := b.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.WebsocketSetup(b.WsConnector,
 			exch.Name,
@@ -170,9 +169,10 @@ func (b *Bitmex) Setup(exch config.ExchangeConfig) {
 			bitmexWSURL,
 			exch.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetAnnouncement returns the general announcements from Bitmex
@@ -826,14 +826,14 @@ func (b *Bitmex) SendHTTPRequest(path string, params Parameter, result interface
 			if err != nil {
 				return err
 			}
-			err = b.SendPayload("GET", encodedPath, nil, nil, &respCheck, false, b.Verbose)
+			err = b.SendPayload("GET", encodedPath, nil, nil, &respCheck, false, b.IsVerbose())
 			if err != nil {
 				return err
 			}
 			return b.CaptureError(respCheck, result)
 		}
 	}
-	err := b.SendPayload("GET", path, nil, nil, &respCheck, false, b.Verbose)
+	err := b.SendPayload("GET", path, nil, nil, &respCheck, false, b.IsVerbose())
 	if err != nil {
 		return err
 	}
@@ -883,7 +883,7 @@ func (b *Bitmex) SendAuthenticatedHTTPRequest(verb, path string, params Paramete
 		bytes.NewBuffer([]byte(payload)),
 		&respCheck,
 		true,
-		b.Verbose)
+		b.IsVerbose())
 	if err != nil {
 		return err
 	}
@@ -97,7 +97,7 @@ func (b *Bitmex) WsConnector() error {
 		return err
 	}
 
-	if b.Verbose {
+	if b.IsVerbose() {
 		log.Printf("Successfully connected to Bitmex %s at time: %s Limit: %d",
 			welcomeResp.Info,
 			welcomeResp.Timestamp,
@@ -208,7 +208,7 @@ func (b *Bitmex) wsHandleIncomingData() {
 				}
 
 				if decodedResp.Success {
-					if b.Verbose {
+					if b.IsVerbose() {
 						if len(quickCapture) == 3 {
 							log.Printf("Bitmex Websocket: Successfully subscribed to %s",
 								decodedResp.Subscribe)
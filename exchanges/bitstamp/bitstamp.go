@@ -85,7 +85,7 @@ func (b *Bitstamp) SetDefaults() {
 }
 
 // Setup sets configuration values to bitstamp
-func (b *Bitstamp) Setup(exch config.ExchangeConfig) {
+func (b *Bitstamp) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		b.SetEnabled(false)
 	} else {
@@ -102,23 +102,23 @@ func (b *Bitstamp) Setup(exch config.ExchangeConfig) {
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := b.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.WebsocketSetup(b.WsConnect,
 			exch.Name,
@@ -126,9 +126,10 @@ func (b *Bitstamp) Setup(exch config.ExchangeConfig) {
 			BitstampPusherKey,
 			exch.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetFee returns an estimate of fee based on type of transaction
@@ -575,7 +576,7 @@ func (b *Bitstamp) TransferAccountBalance(amount float64, currency, subAccount s
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (b *Bitstamp) SendHTTPRequest(path string, result interface{}) error {
-	return b.SendPayload("GET", path, nil, nil, result, false, b.Verbose)
+	return b.SendPayload("GET", path, nil, nil, result, false, b.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated request
@@ -605,12 +606,12 @@ func (b *Bitstamp) SendAuthenticatedHTTPRequest(path string, v2 bool, values url
 		path = fmt.Sprintf("%s/%s/", b.APIUrl, path)
 	}
 
-	if b.Verbose {
+	if b.IsVerbose() {
 		log.Println("Sending POST request to " + path)
 	}
 
 	headers := make(map[string]string)
 	headers["Content-Type"] = "application/x-www-form-urlencoded"
 
-	return b.SendPayload("POST", path, headers, strings.NewReader(values.Encode()), result, true, b.Verbose)
+	return b.SendPayload("POST", path, headers, strings.NewReader(values.Encode()), result, true, b.IsVerbose())
 }
@@ -3,7 +3,6 @@ package bittrex
 import (
 	"errors"
 	"fmt"
-	"log"
 	"net/url"
 	"strconv"
 	"time"
@@ -86,7 +85,7 @@ func (b *Bittrex) SetDefaults() {
 }
 
 // Setup method sets current configuration details if enabled
-func (b *Bittrex) Setup(exch config.ExchangeConfig) {
+func (b *Bittrex) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		b.SetEnabled(false)
 	} else {
@@ -102,25 +101,26 @@ func (b *Bittrex) Setup(exch config.ExchangeConfig) {
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := b.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetMarkets is used to get the open and available trading markets at Bittrex
@@ -486,7 +486,7 @@ func (b *Bittrex) GetDepositHistory(currency string) (WithdrawalHistory, error)
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (b *Bittrex) SendHTTPRequest(path string, result interface{}) error {
-	return b.SendPayload("GET", path, nil, nil, result, false, b.Verbose)
+	return b.SendPayload("GET", path, nil, nil, result, false, b.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated http request to a desired
@@ -510,7 +510,7 @@ func (b *Bittrex) SendAuthenticatedHTTPRequest(path string, values url.Values, r
 	headers := make(map[string]string)
 	headers["apisign"] = common.HexEncodeToString(hmac)
 
-	return b.SendPayload("GET", rawQuery, headers, nil, result, true, b.Verbose)
+	return b.SendPayload("GET", rawQuery, headers, nil, result, true, b.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
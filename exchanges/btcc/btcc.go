@@ -1,7 +1,6 @@
 package btcc
 
 import (
-	"log"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -48,7 +47,7 @@ func (b *BTCC) SetDefaults() {
 }
 
 // Setup is run on startup to setup exchange with config values
-func (b *BTCC) Setup(exch config.ExchangeConfig) {
+func (b *BTCC) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		b.SetEnabled(false)
 	} else {
@@ -65,23 +64,23 @@ func (b *BTCC) Setup(exch config.ExchangeConfig) {
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := b.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = b.WebsocketSetup(b.WsConnect,
 			exch.Name,
@@ -89,9 +88,10 @@ func (b *BTCC) Setup(exch config.ExchangeConfig) {
 			btccSocketioAddress,
 			exch.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetFee returns an estimate of fee based on type of transaction
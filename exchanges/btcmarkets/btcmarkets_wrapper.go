@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 
@@ -17,14 +18,14 @@ import (
 func (b *BTCMarkets) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
 	go func() {
-		b.Run()
+		b.RunRecovered(b.Run)
 		wg.Done()
 	}()
 }
 
 // Run implements the BTC Markets wrapper
 func (b *BTCMarkets) Run() {
-	if b.Verbose {
+	if b.IsVerbose() {
 		log.Printf("%s polling delay: %ds.\n", b.GetName(), b.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
@@ -149,12 +150,52 @@ func (b *BTCMarkets) GetExchangeFundTransferHistory() ([]exchange.FundHistory, e
 func (b *BTCMarkets) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
-	return resp, errors.New("trade history not yet implemented")
+	return resp, exchange.ErrNotYetImplemented
+}
+
+// GetHistoricCandles returns candlestick data for a currency pair between the
+// start and end times at the specified interval
+func (b *BTCMarkets) GetHistoricCandles(currencyPair pair.CurrencyPair, start, end time.Time, interval time.Duration) (exchange.Candles, error) {
+	return nil, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeServerTime returns the exchange server time
+func (b *BTCMarkets) GetExchangeServerTime() (time.Time, error) {
+	return time.Time{}, exchange.ErrNotYetImplemented
+}
+
+// Ping checks that the exchange API is reachable
+func (b *BTCMarkets) Ping() error {
+	return exchange.ErrNotYetImplemented
+}
+
+// AuthenticatedPing checks that the exchange accepts the API credentials
+func (b *BTCMarkets) AuthenticatedPing() error {
+	return exchange.ErrNotYetImplemented
 }
 
 // SubmitExchangeOrder submits a new order
 func (b *BTCMarkets) SubmitExchangeOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (int64, error) {
-	return b.NewOrder(p.FirstCurrency.Upper().String(), p.SecondCurrency.Upper().String(), price, amount, side.Format(b.GetName()), orderType.Format(b.GetName()), clientID)
+	err := b.CheckOrderNotional(p.Pair().String(), amount, price)
+	if err != nil {
+		return 0, err
+	}
+
+	orderID, err := b.NewOrder(p.FirstCurrency.Upper().String(), p.SecondCurrency.Upper().String(), price, amount, side.Format(b.GetName()), orderType.Format(b.GetName()), clientID)
+	b.SendEvent(exchange.NewSubmitOrderEvent(exchange.OrderSubmission{
+		Pair:   p,
+		Side:   side,
+		Type:   orderType,
+		Amount: amount,
+		Price:  price,
+	}, exchange.SubmitOrderResponse{OrderID: orderID}, err))
+	return orderID, err
+}
+
+// SubmitOrder submits a new order
+func (b *BTCMarkets) SubmitOrder(req exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var resp exchange.SubmitOrderResponse
+	return resp, exchange.ErrNotYetImplemented
 }
 
 // ModifyExchangeOrder will allow of changing orderbook placement and limit to
@@ -166,10 +207,12 @@ func (b *BTCMarkets) ModifyExchangeOrder(orderID int64, action exchange.ModifyOr
 // CancelExchangeOrder cancels an order by its corresponding ID number
 func (b *BTCMarkets) CancelExchangeOrder(orderID int64) error {
 	_, err := b.CancelOrder([]int64{orderID})
-	if err != nil {
-		return err
-	}
-	return nil
+	b.SendEvent(exchange.ActionEvent{
+		Action:  exchange.ActionCancelOrder,
+		OrderID: orderID,
+		Err:     err,
+	})
+	return err
 }
 
 // CancelAllExchangeOrders cancels all orders associated with a currency pair
@@ -185,10 +228,8 @@ func (b *BTCMarkets) CancelAllExchangeOrders() error {
 	}
 
 	_, err = b.CancelOrder(orderList)
-	if err != nil {
-		return err
-	}
-	return nil
+	b.SendEvent(exchange.ActionEvent{Action: exchange.ActionCancelAllOrders, Err: err})
+	return err
 }
 
 // GetExchangeOrderInfo returns information on a current open order
@@ -225,6 +266,18 @@ func (b *BTCMarkets) GetExchangeOrderInfo(orderID int64) (exchange.OrderDetail,
 	return OrderDetail, nil
 }
 
+// GetExchangeOpenOrders returns all open orders for a currency pair, or across
+// all currency pairs if the pair is empty
+func (b *BTCMarkets) GetExchangeOpenOrders(currencyPair pair.CurrencyPair) ([]exchange.OrderDetail, error) {
+	return nil, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeOrderHistory returns filled orders for a currency pair, or across
+// all currency pairs if the pair is empty, between the start and end times
+func (b *BTCMarkets) GetExchangeOrderHistory(currencyPair pair.CurrencyPair, start, end time.Time) ([]exchange.OrderDetail, error) {
+	return nil, exchange.ErrNotYetImplemented
+}
+
 // GetExchangeDepositAddress returns a deposit address for a specified currency
 func (b *BTCMarkets) GetExchangeDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", errors.New("not supported on exchange")
@@ -232,7 +285,14 @@ func (b *BTCMarkets) GetExchangeDepositAddress(cryptocurrency pair.CurrencyItem)
 
 // WithdrawCryptoExchangeFunds returns a withdrawal ID when a withdrawal is submitted
 func (b *BTCMarkets) WithdrawCryptoExchangeFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
-	return b.WithdrawCrypto(amount, cryptocurrency.String(), address)
+	withdrawalID, err := b.WithdrawCrypto(amount, cryptocurrency.String(), address)
+	b.SendEvent(exchange.NewWithdrawalEvent(exchange.WithdrawalRequest{
+		Type:     exchange.CryptoWithdrawal,
+		Currency: cryptocurrency,
+		Amount:   amount,
+		Address:  address,
+	}, withdrawalID, err))
+	return withdrawalID, err
 }
 
 // WithdrawFiatExchangeFunds returns a withdrawal ID when a
@@ -242,18 +302,30 @@ func (b *BTCMarkets) WithdrawFiatExchangeFunds(currency pair.CurrencyItem, amoun
 	if err != nil {
 		return "", err
 	}
-	return b.WithdrawAUD(bd.AccountName, bd.AccountNumber, bd.BankName, bd.BSBNumber, amount)
+	withdrawalID, err := b.WithdrawAUD(bd.AccountName, bd.AccountNumber, bd.BankName, bd.BSBNumber, amount)
+	b.SendEvent(exchange.NewWithdrawalEvent(exchange.WithdrawalRequest{
+		Type:     exchange.FiatWithdrawal,
+		Currency: currency,
+		Amount:   amount,
+	}, withdrawalID, err))
+	return withdrawalID, err
 }
 
 // WithdrawFiatExchangeFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *BTCMarkets) WithdrawFiatExchangeFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
+}
+
+// WithdrawExchangeFunds submits a withdrawal request and returns a withdrawal
+// ID when the withdrawal is submitted
+func (b *BTCMarkets) WithdrawExchangeFunds(req exchange.WithdrawalRequest) (string, error) {
+	return "", exchange.ErrNotYetImplemented
 }
 
 // GetWebsocket returns a pointer to the exchange websocket
 func (b *BTCMarkets) GetWebsocket() (*exchange.Websocket, error) {
-	return nil, errors.New("not yet implemented")
+	return nil, exchange.ErrNotYetImplemented
 }
 
 // GetFeeByType returns an estimate of fee based on type of transaction
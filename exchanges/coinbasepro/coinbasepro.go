@@ -88,7 +88,7 @@ func (c *CoinbasePro) SetDefaults() {
 }
 
 // Setup initialises the exchange parameters with the current configuration
-func (c *CoinbasePro) Setup(exch config.ExchangeConfig) {
+func (c *CoinbasePro) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		c.SetEnabled(false)
 	} else {
@@ -108,23 +108,23 @@ func (c *CoinbasePro) Setup(exch config.ExchangeConfig) {
 		}
 		err := c.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = c.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = c.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = c.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = c.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = c.WebsocketSetup(c.WsConnect,
 			exch.Name,
@@ -132,9 +132,10 @@ func (c *CoinbasePro) Setup(exch config.ExchangeConfig) {
 			coinbaseproWebsocketURL,
 			exch.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetProducts returns supported currency pairs on the exchange with specific
@@ -789,7 +790,7 @@ func (c *CoinbasePro) GetTrailingVolume() ([]Volume, error) {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (c *CoinbasePro) SendHTTPRequest(path string, result interface{}) error {
-	return c.SendPayload("GET", path, nil, nil, result, false, c.Verbose)
+	return c.SendPayload("GET", path, nil, nil, result, false, c.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP reque
@@ -806,7 +807,7 @@ func (c *CoinbasePro) SendAuthenticatedHTTPRequest(method, path string, params m
 			return errors.New("SendAuthenticatedHTTPRequest: Unable to JSON request")
 		}
 
-		if c.Verbose {
+		if c.IsVerbose() {
 			log.Printf("Request JSON: %s\n", payload)
 		}
 	}
@@ -821,7 +822,7 @@ func (c *CoinbasePro) SendAuthenticatedHTTPRequest(method, path string, params m
 	headers["CB-ACCESS-PASSPHRASE"] = c.ClientID
 	headers["Content-Type"] = "application/json"
 
-	return c.SendPayload(method, c.APIUrl+path, headers, bytes.NewBuffer(payload), result, true, c.Verbose)
+	return c.SendPayload(method, c.APIUrl+path, headers, bytes.NewBuffer(payload), result, true, c.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
@@ -74,7 +74,7 @@ func (c *COINUT) SetDefaults() {
 }
 
 // Setup sets the current exchange configuration
-func (c *COINUT) Setup(exch config.ExchangeConfig) {
+func (c *COINUT) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		c.SetEnabled(false)
 	} else {
@@ -91,23 +91,23 @@ func (c *COINUT) Setup(exch config.ExchangeConfig) {
 		c.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := c.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = c.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = c.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = c.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = c.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = c.WebsocketSetup(c.WsConnect,
 			exch.Name,
@@ -115,9 +115,10 @@ func (c *COINUT) Setup(exch config.ExchangeConfig) {
 			coinutWebsocketURL,
 			exch.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetInstruments returns instruments
@@ -319,7 +320,7 @@ func (c *COINUT) SendHTTPRequest(apiRequest string, params map[string]interface{
 		return errors.New("SenddHTTPRequest: Unable to JSON request")
 	}
 
-	if c.Verbose {
+	if c.IsVerbose() {
 		log.Printf("Request JSON: %s\n", payload)
 	}
 
@@ -331,7 +332,7 @@ func (c *COINUT) SendHTTPRequest(apiRequest string, params map[string]interface{
 	}
 	headers["Content-Type"] = "application/json"
 
-	return c.SendPayload("POST", c.APIUrl, headers, bytes.NewBuffer(payload), result, authenticated, c.Verbose)
+	return c.SendPayload("POST", c.APIUrl, headers, bytes.NewBuffer(payload), result, authenticated, c.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
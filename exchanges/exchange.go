@@ -1,13 +1,16 @@
 package exchange
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
@@ -23,12 +26,33 @@ const (
 	warningBase64DecryptSecretKeyFailed = "WARNING -- Exchange %s unable to base64 decode secret key.. Disabling Authenticated API support."
 	// WarningAuthenticatedRequestWithoutCredentialsSet error message for authenticated request without credentials set
 	WarningAuthenticatedRequestWithoutCredentialsSet = "WARNING -- Exchange %s authenticated HTTP request called but not supported due to unset/default API keys."
+	// ErrAPICredentialsNotSet error message for authenticated request with an
+	// empty API key or secret
+	ErrAPICredentialsNotSet = "API credentials not set for %s"
 	// ErrExchangeNotFound is a constant for an error message
 	ErrExchangeNotFound = "Exchange not found in dataset."
 	// DefaultHTTPTimeout is the default HTTP/HTTPS Timeout for exchange requests
 	DefaultHTTPTimeout = time.Second * 15
+	// RESTPollingDelayUnit is the unit of the configured RESTPollingDelay,
+	// which is stored as a bare number of seconds
+	RESTPollingDelayUnit = time.Second
 )
 
+// RootCATLSPins are the SPKI hashes of the root certificate authorities used
+// by the Cloudflare fronted exchange APIs, ISRG Root X1 and X2, DigiCert Global
+// Root CA and G2, GTS Root R1 and R4 and USERTrust RSA and ECC. Pinning roots
+// rather than leaf certificates keeps the pins valid across certificate renewals
+var RootCATLSPins = []string{
+	"C5+lpZ7tcVwmwQIMcRtPbsQtWLABXhQzejna0wHFr8M=",
+	"diGVwiVYbubAI3RW4hB9xU8e/CH2GnkuvVFZE8zmgzI=",
+	"r/mIkG3eEpVdm+u/ko/cwxzOMo1bk4TyHIlByibiA5E=",
+	"i7WTqTvh0OioIruIfFR4kMPnBqrS2rdiVPl/s2uC/CY=",
+	"hxqRlPTu1bMS/0DITB1SSu0vd4u/8l8TjPgfaAp63Gc=",
+	"mEflZT5enoR1FuXLgYYGqnVEoZvmf9c2bVBpiOjYQ0c=",
+	"x4QzPSC810K5/cMjb05Qm4k3Bw5zBn4lTdO/nEW/Td4=",
+	"ICGRfpgmOUXIWcQ/HXPLQTkFPEFPoDyjvH7ohhQpjzs=",
+}
+
 // FeeType custom type for calculating fees based on method
 type FeeType string
 
@@ -81,6 +105,9 @@ type FeeBuilder struct {
 	// Used to multiply for fee calculations
 	PurchasePrice float64
 	Amount        float64
+	// 30 day trading volume used to select a fee tier, the exchanges cached
+	// trading volume is used if not set
+	TradingVolume float64
 }
 
 // Definitions for each type of withdrawal method for a given exchange
@@ -140,6 +167,16 @@ type AccountCurrencyInfo struct {
 	CurrencyName string
 	TotalValue   float64
 	Hold         float64
+	Available    float64
+}
+
+// BalanceChange holds the change in a currency balance between two account
+// info updates
+type BalanceChange struct {
+	CurrencyName string
+	Previous     float64
+	Current      float64
+	Delta        float64
 }
 
 // TradeHistory holds exchange history data
@@ -156,6 +193,7 @@ type TradeHistory struct {
 type OrderDetail struct {
 	Exchange      string
 	ID            int64
+	ClientID      string
 	BaseCurrency  string
 	QuoteCurrency string
 	OrderSide     string
@@ -191,41 +229,75 @@ type Base struct {
 	Enabled                                    bool
 	Verbose                                    bool
 	RESTPollingDelay                           time.Duration
+	RESTPollingEnabled                         bool
 	AuthenticatedAPISupport                    bool
 	APIWithdrawPermissions                     uint32
 	APIAuthPEMKeySupport                       bool
 	APISecret, APIKey, APIAuthPEMKey, ClientID string
 	Nonce                                      nonce.Nonce
+	SyncNonceWithServerTime                    bool
 	TakerFee, MakerFee, Fee                    float64
+	FeeTiers                                   []config.FeeTier
+	TradingVolume                              float64
+	WithdrawalAddressAllowlist                 map[pair.CurrencyItem][]string
+	MaxOrderNotional                           float64
+	MaxPairOrderNotional                       map[string]float64
+	CheckOrderBalance                          bool
+	Features                                   Capabilities
 	BaseCurrencies                             []string
 	AvailablePairs                             []string
 	EnabledPairs                               []string
 	AssetTypes                                 []string
 	PairsLastUpdated                           int64
 	SupportsAutoPairUpdating                   bool
+	DisableAutoPairUpdates                     bool
 	SupportsRESTTickerBatching                 bool
+	QuoteCurrencyFirst                         bool
 	HTTPTimeout                                time.Duration
 	HTTPUserAgent                              string
+	ProxyAddress                               string
+	CurrencyPairsCacheTTL                      time.Duration
+	CurrencyPairsCacheDir                      string
 	WebsocketURL                               string
 	APIUrl                                     string
 	APIUrlDefault                              string
 	APIUrlSecondary                            string
 	APIUrlSecondaryDefault                     string
+	TLSPinsDefault                             []string
 	RequestCurrencyPairFormat                  config.CurrencyPairFormatConfig
 	ConfigCurrencyPairFormat                   config.CurrencyPairFormatConfig
 	Websocket                                  *Websocket
 	*request.Requester
+
+	previousBalances map[string]float64
+	balanceMtx       sync.Mutex
+	serverTimeOffset int64
+	shutdown         chan struct{}
+	shutdownMtx      sync.Mutex
+	err              error
+	errMtx           sync.Mutex
+	enabledMtx       sync.RWMutex
+	apiKeysMtx       sync.RWMutex
+	pairsMtx         sync.RWMutex
+	apiSecretBase64  bool
+	eventHandler     EventHandler
+	eventMtx         sync.RWMutex
+	verboseMtx       sync.RWMutex
+	urlMtx           sync.RWMutex
 }
 
 // IBotExchange enforces standard functions for all exchanges supported in
 // GoCryptoTrader
 type IBotExchange interface {
-	Setup(exch config.ExchangeConfig)
+	Setup(exch config.ExchangeConfig) error
+	Reconfigure(exch config.ExchangeConfig) error
 	Start(wg *sync.WaitGroup)
 	SetDefaults()
 	GetName() string
 	IsEnabled() bool
 	SetEnabled(bool)
+	Enable() error
+	Disable() error
 	GetTickerPrice(currency pair.CurrencyPair, assetType string) (ticker.Price, error)
 	UpdateTicker(currency pair.CurrencyPair, assetType string) (ticker.Price, error)
 	GetOrderbookEx(currency pair.CurrencyPair, assetType string) (orderbook.Base, error)
@@ -240,6 +312,8 @@ type IBotExchange interface {
 	SupportsAutoPairUpdates() bool
 	GetLastPairsUpdateTime() int64
 	SupportsRESTTickerBatchUpdates() bool
+	IsQuoteCurrencyFirst() bool
+	GetRESTPollingDelay() time.Duration
 
 	GetWithdrawPermissions() uint32
 	FormatWithdrawPermissions() string
@@ -247,16 +321,29 @@ type IBotExchange interface {
 
 	GetExchangeFundTransferHistory() ([]FundHistory, error)
 	SubmitExchangeOrder(p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) (int64, error)
+	SubmitOrder(req OrderSubmission) (SubmitOrderResponse, error)
 	ModifyExchangeOrder(orderID int64, modify ModifyOrder) (int64, error)
 	CancelExchangeOrder(orderID int64) error
 	CancelAllExchangeOrders() error
 	GetExchangeOrderInfo(orderID int64) (OrderDetail, error)
+	GetExchangeOpenOrders(p pair.CurrencyPair) ([]OrderDetail, error)
+	GetExchangeOrderHistory(p pair.CurrencyPair, start, end time.Time) ([]OrderDetail, error)
 	GetExchangeDepositAddress(cryptocurrency pair.CurrencyItem) (string, error)
 
 	WithdrawCryptoExchangeFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error)
 	WithdrawFiatExchangeFunds(currency pair.CurrencyItem, amount float64) (string, error)
+	WithdrawExchangeFunds(req WithdrawalRequest) (string, error)
 
 	GetWebsocket() (*Websocket, error)
+	Subscribe(p pair.CurrencyPair, channel WebsocketChannel, assetType string) error
+	Unsubscribe(p pair.CurrencyPair, channel WebsocketChannel, assetType string) error
+	GetFeeByType(feeBuilder FeeBuilder) (float64, error)
+	GetHistoricCandles(p pair.CurrencyPair, start, end time.Time, interval time.Duration) (Candles, error)
+	GetExchangeServerTime() (time.Time, error)
+	Capabilities() Capabilities
+	Ping() error
+	AuthenticatedPing() error
+	Stop() error
 }
 
 // SupportsRESTTickerBatchUpdates returns whether or not the
@@ -265,6 +352,47 @@ func (e *Base) SupportsRESTTickerBatchUpdates() bool {
 	return e.SupportsRESTTickerBatching
 }
 
+// IsQuoteCurrencyFirst returns whether the exchange currency pairs put the
+// quote currency first, as for Poloniex where BTC_LTC is LTC priced in BTC
+func (e *Base) IsQuoteCurrencyFirst() bool {
+	return e.QuoteCurrencyFirst
+}
+
+// GetRESTPollingDelay returns the delay between REST polling requests as a
+// duration. RESTPollingDelay is stored as a number of RESTPollingDelayUnit so
+// callers should use this rather than converting it themselves
+func (e *Base) GetRESTPollingDelay() time.Duration {
+	return time.Duration(atomic.LoadInt64((*int64)(&e.RESTPollingDelay))) * RESTPollingDelayUnit
+}
+
+// SetNonceStrategy sets how the exchange nonce is seeded
+func (e *Base) SetNonceStrategy(s nonce.Strategy) {
+	e.Nonce.SetStrategy(s)
+}
+
+// SetServerTimeOffset stores the offset between the exchange server time and
+// the local time. If SyncNonceWithServerTime is set the offset is also applied
+// to the nonce
+func (e *Base) SetServerTimeOffset(serverTime time.Time) {
+	offset := time.Until(serverTime)
+	atomic.StoreInt64(&e.serverTimeOffset, int64(offset))
+	if e.SyncNonceWithServerTime {
+		e.Nonce.SetOffset(offset)
+	}
+}
+
+// GetServerTimeOffset returns the offset between the exchange server time and
+// the local time, a positive offset means the server clock is ahead
+func (e *Base) GetServerTimeOffset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&e.serverTimeOffset))
+}
+
+// GetServerAlignedTime returns the local time adjusted by the server time
+// offset
+func (e *Base) GetServerAlignedTime() time.Time {
+	return time.Now().Add(e.GetServerTimeOffset())
+}
+
 // SetHTTPClientTimeout sets the timeout value for the exchanges
 // HTTP Client
 func (e *Base) SetHTTPClientTimeout(t time.Duration) {
@@ -274,7 +402,7 @@ func (e *Base) SetHTTPClientTimeout(t time.Duration) {
 			request.NewRateLimit(time.Second, 0),
 			new(http.Client))
 	}
-	e.Requester.HTTPClient.Timeout = t
+	e.Requester.SetTimeout(t)
 }
 
 // SetHTTPClient sets exchanges HTTP client
@@ -296,7 +424,7 @@ func (e *Base) GetHTTPClient() *http.Client {
 			request.NewRateLimit(time.Second, 0),
 			new(http.Client))
 	}
-	return e.Requester.HTTPClient
+	return e.Requester.Client()
 }
 
 // SetHTTPClientUserAgent sets the exchanges HTTP user agent
@@ -307,12 +435,87 @@ func (e *Base) SetHTTPClientUserAgent(ua string) {
 			request.NewRateLimit(time.Second, 0),
 			new(http.Client))
 	}
-	e.Requester.UserAgent = ua
+	e.Requester.SetUserAgent(ua)
+	e.urlMtx.Lock()
 	e.HTTPUserAgent = ua
+	e.urlMtx.Unlock()
+}
+
+// SetHTTP2Enabled toggles HTTP/2 support for the exchanges HTTP client. When
+// disabled the client is restricted to HTTP/1.1. The transport is rebuilt, as
+// a transport which has been used keeps the protocols it was first set up with
+func (e *Base) SetHTTP2Enabled(enabled bool) {
+	client := e.GetHTTPClient()
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		if enabled {
+			return
+		}
+		e.SetHTTPClient(common.NewHTTP1ClientWithTimeout(client.Timeout))
+		return
+	}
+
+	rebuilt := transport.Clone()
+	rebuilt.ForceAttemptHTTP2 = enabled
+	if enabled {
+		rebuilt.TLSNextProto = nil
+	} else {
+		rebuilt.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		if rebuilt.TLSClientConfig != nil {
+			var protos []string
+			for _, proto := range rebuilt.TLSClientConfig.NextProtos {
+				if proto != "h2" {
+					protos = append(protos, proto)
+				}
+			}
+			rebuilt.TLSClientConfig.NextProtos = protos
+		}
+	}
+	e.Requester.SetTransport(rebuilt)
+}
+
+// SetHTTPClientPins restricts the exchanges HTTP client to servers presenting a
+// certificate matching one of the pinned SPKI hashes, see
+// common.NewPinnedHTTPClientWithTimeout. No pins falls back to TLSPinsDefault
+// and no default pins leaves the client unchanged. It must be called before
+// SetClientProxyAddress
+func (e *Base) SetHTTPClientPins(pins []string) error {
+	if len(pins) == 0 {
+		pins = e.TLSPinsDefault
+	}
+	if len(pins) == 0 {
+		return nil
+	}
+
+	verify, err := common.VerifyPinnedCertificate(pins)
+	if err != nil {
+		return fmt.Errorf("%s %s", e.Name, err)
+	}
+
+	client := e.GetHTTPClient()
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		pinned, err := common.NewPinnedHTTPClientWithTimeout(client.Timeout, pins)
+		if err != nil {
+			return fmt.Errorf("%s %s", e.Name, err)
+		}
+		e.SetHTTPClient(pinned)
+		return nil
+	}
+
+	tlsConfig := new(tls.Config)
+	if transport.TLSClientConfig != nil {
+		tlsConfig = transport.TLSClientConfig.Clone()
+	}
+	tlsConfig.VerifyPeerCertificate = verify
+	transport.TLSClientConfig = tlsConfig
+	return nil
 }
 
 // GetHTTPClientUserAgent gets the exchanges HTTP user agent
 func (e *Base) GetHTTPClientUserAgent() string {
+	e.urlMtx.RLock()
+	defer e.urlMtx.RUnlock()
 	return e.HTTPUserAgent
 }
 
@@ -337,10 +540,20 @@ func (e *Base) SetClientProxyAddress(addr string) error {
 				return err
 			}
 		}
+		e.urlMtx.Lock()
+		e.ProxyAddress = addr
+		e.urlMtx.Unlock()
 	}
 	return nil
 }
 
+// GetProxyAddress returns the proxy address for REST and websocket requests
+func (e *Base) GetProxyAddress() string {
+	e.urlMtx.RLock()
+	defer e.urlMtx.RUnlock()
+	return e.ProxyAddress
+}
+
 // SetAutoPairDefaults sets the default values for whether or not the exchange
 // supports auto pair updating or not
 func (e *Base) SetAutoPairDefaults() error {
@@ -377,6 +590,13 @@ func (e *Base) SupportsAutoPairUpdates() bool {
 	return e.SupportsAutoPairUpdating
 }
 
+// AutoPairUpdatesEnabled returns whether the exchange currency pairs should be
+// automatically updated, which requires the exchange to support it and auto
+// pair updates to not be disabled
+func (e *Base) AutoPairUpdatesEnabled() bool {
+	return e.SupportsAutoPairUpdating && !e.DisableAutoPairUpdates
+}
+
 // GetLastPairsUpdateTime returns the unix timestamp of when the exchanges
 // currency pairs were last updated
 func (e *Base) GetLastPairsUpdateTime() int64 {
@@ -413,6 +633,15 @@ func (e *Base) GetAssetTypes() []string {
 	return e.AssetTypes
 }
 
+// ErrAssetTypeNotSupported is returned when an exchange does not support the
+// requested asset type
+var ErrAssetTypeNotSupported = errors.New("asset type not supported")
+
+// SupportsAssetType returns whether the exchange supports the asset type
+func (e *Base) SupportsAssetType(assetType string) bool {
+	return common.StringDataCompare(e.AssetTypes, assetType)
+}
+
 // GetExchangeAssetTypes returns the asset types the exchange supports (SPOT,
 // binary, futures)
 func GetExchangeAssetTypes(exchName string) ([]string, error) {
@@ -506,6 +735,8 @@ func (e *Base) SetCurrencyPairFormat() error {
 // GetAuthenticatedAPISupport returns whether the exchange supports
 // authenticated API requests
 func (e *Base) GetAuthenticatedAPISupport() bool {
+	e.apiKeysMtx.RLock()
+	defer e.apiKeysMtx.RUnlock()
 	return e.AuthenticatedAPISupport
 }
 
@@ -517,14 +748,38 @@ func (e *Base) GetName() string {
 // GetEnabledCurrencies is a method that returns the enabled currency pairs of
 // the exchange base
 func (e *Base) GetEnabledCurrencies() []pair.CurrencyPair {
+	e.pairsMtx.RLock()
+	defer e.pairsMtx.RUnlock()
 	return pair.FormatPairs(e.EnabledPairs,
 		e.ConfigCurrencyPairFormat.Delimiter,
 		e.ConfigCurrencyPairFormat.Index)
 }
 
+// GetEnabledCurrenciesByQuote returns the enabled currency pairs of the
+// exchange base quoted in the supplied currency. The quote currency is the
+// second currency of a pair unless QuoteCurrencyFirst is set, as for exchanges
+// such as Poloniex where BTC_LTC is LTC priced in BTC
+func (e *Base) GetEnabledCurrenciesByQuote(currency string) []pair.CurrencyPair {
+	var pairs []pair.CurrencyPair
+	quote := pair.CurrencyItem(currency).Upper()
+	for _, p := range e.GetEnabledCurrencies() {
+		pairQuote := p.SecondCurrency
+		if e.QuoteCurrencyFirst {
+			pairQuote = p.FirstCurrency
+		}
+
+		if pairQuote.Upper() == quote {
+			pairs = append(pairs, p)
+		}
+	}
+	return pairs
+}
+
 // GetAvailableCurrencies is a method that returns the available currency pairs
 // of the exchange base
 func (e *Base) GetAvailableCurrencies() []pair.CurrencyPair {
+	e.pairsMtx.RLock()
+	defer e.pairsMtx.RUnlock()
 	return pair.FormatPairs(e.AvailablePairs,
 		e.ConfigCurrencyPairFormat.Delimiter,
 		e.ConfigCurrencyPairFormat.Index)
@@ -594,33 +849,63 @@ func FormatCurrency(p pair.CurrencyPair) pair.CurrencyItem {
 
 // SetEnabled is a method that sets if the exchange is enabled
 func (e *Base) SetEnabled(enabled bool) {
+	e.enabledMtx.Lock()
 	e.Enabled = enabled
+	e.enabledMtx.Unlock()
 }
 
 // IsEnabled is a method that returns if the current exchange is enabled
 func (e *Base) IsEnabled() bool {
+	e.enabledMtx.RLock()
+	defer e.enabledMtx.RUnlock()
 	return e.Enabled
 }
 
-// SetAPIKeys is a method that sets the current API keys for the exchange
+// SetAPIKeys is a method that sets the current API keys for the exchange. It
+// is safe to call at runtime to rotate credentials without re-running Setup;
+// requests already signed with the previous keys complete with them and any
+// request signed afterwards uses the new keys. Authenticated API support is
+// guarded by the same lock, use GetAuthenticatedAPISupport to read it
 func (e *Base) SetAPIKeys(APIKey, APISecret, ClientID string, b64Decode bool) {
+	e.apiKeysMtx.Lock()
+	defer e.apiKeysMtx.Unlock()
+
 	if !e.AuthenticatedAPISupport {
 		return
 	}
 
-	e.APIKey = APIKey
-	e.ClientID = ClientID
-
+	secret := APISecret
 	if b64Decode {
 		result, err := common.Base64Decode(APISecret)
 		if err != nil {
 			e.AuthenticatedAPISupport = false
 			log.Printf(warningBase64DecryptSecretKeyFailed, e.Name)
 		}
-		e.APISecret = string(result)
-	} else {
-		e.APISecret = APISecret
+		secret = string(result)
 	}
+
+	e.APIKey = APIKey
+	e.APISecret = secret
+	e.ClientID = ClientID
+	e.apiSecretBase64 = b64Decode
+}
+
+// CheckAPICredentials returns an error if the API key or secret are empty
+func (e *Base) CheckAPICredentials() error {
+	apiKey, apiSecret, _ := e.GetAPIKeys()
+	if apiKey == "" || apiSecret == "" {
+		return &classifiedError{class: ErrAuthFailed, err: fmt.Errorf(ErrAPICredentialsNotSet, e.Name)}
+	}
+	return nil
+}
+
+// GetAPIKeys returns the current API key, secret and client ID. Requests should
+// be signed using a single call so they never mix old and new credentials
+// while the keys are being rotated
+func (e *Base) GetAPIKeys() (apiKey, apiSecret, clientID string) {
+	e.apiKeysMtx.RLock()
+	defer e.apiKeysMtx.RUnlock()
+	return e.APIKey, e.APISecret, e.ClientID
 }
 
 // SetCurrencies sets the exchange currency pairs for either enabledPairs or
@@ -642,6 +927,7 @@ func (e *Base) SetCurrencies(pairs []pair.CurrencyPair, enabledPairs bool) error
 			exchCfg.ConfigCurrencyPairFormat.Uppercase).String())
 	}
 
+	e.pairsMtx.Lock()
 	if enabledPairs {
 		exchCfg.EnabledPairs = common.JoinStrings(pairsStr, ",")
 		e.EnabledPairs = pairsStr
@@ -649,10 +935,45 @@ func (e *Base) SetCurrencies(pairs []pair.CurrencyPair, enabledPairs bool) error
 		exchCfg.AvailablePairs = common.JoinStrings(pairsStr, ",")
 		e.AvailablePairs = pairsStr
 	}
+	e.pairsMtx.Unlock()
 
 	return cfg.UpdateExchangeConfig(exchCfg)
 }
 
+// GetRemovedPairs returns the stored available pairs which are no longer
+// present in the exchange products
+func (e *Base) GetRemovedPairs(exchangeProducts []string) []string {
+	e.pairsMtx.RLock()
+	defer e.pairsMtx.RUnlock()
+	_, removedPairs := pair.FindPairDifferences(e.AvailablePairs, exchangeProducts)
+	return removedPairs
+}
+
+// UnavailablePairsError is returned when enabled currency pairs are not
+// offered by the exchange
+type UnavailablePairsError struct {
+	Exchange string
+	Pairs    []string
+}
+
+// Error implements the error interface
+func (u *UnavailablePairsError) Error() string {
+	return fmt.Sprintf("%s enabled pairs not offered by the exchange: %s",
+		u.Exchange, common.JoinStrings(u.Pairs, ","))
+}
+
+// CheckEnabledPairs returns an UnavailablePairsError listing the enabled pairs
+// which are not present in the exchange products
+func (e *Base) CheckEnabledPairs(exchangeProducts []string) error {
+	e.pairsMtx.RLock()
+	unavailable, _ := pair.FindPairDifferences(exchangeProducts, e.EnabledPairs)
+	e.pairsMtx.RUnlock()
+	if len(unavailable) == 0 {
+		return nil
+	}
+	return &UnavailablePairsError{Exchange: e.Name, Pairs: unavailable}
+}
+
 // UpdateCurrencies updates the exchange currency pairs for either enabledPairs or
 // availablePairs
 func (e *Base) UpdateCurrencies(exchangeProducts []string, enabled, force bool) error {
@@ -660,7 +981,7 @@ func (e *Base) UpdateCurrencies(exchangeProducts []string, enabled, force bool)
 		return fmt.Errorf("%s UpdateCurrencies error - exchangeProducts is empty", e.Name)
 	}
 
-	exchangeProducts = common.SplitStrings(common.StringToUpper(common.JoinStrings(exchangeProducts, ",")), ",")
+	exchangeProducts = common.StringSliceToUpper(exchangeProducts)
 	var products []string
 
 	for x := range exchangeProducts {
@@ -673,6 +994,7 @@ func (e *Base) UpdateCurrencies(exchangeProducts []string, enabled, force bool)
 	var newPairs, removedPairs []string
 	var updateType string
 
+	e.pairsMtx.RLock()
 	if enabled {
 		newPairs, removedPairs = pair.FindPairDifferences(e.EnabledPairs, products)
 		updateType = "enabled"
@@ -680,6 +1002,7 @@ func (e *Base) UpdateCurrencies(exchangeProducts []string, enabled, force bool)
 		newPairs, removedPairs = pair.FindPairDifferences(e.AvailablePairs, products)
 		updateType = "available"
 	}
+	e.pairsMtx.RUnlock()
 
 	if force || len(newPairs) > 0 || len(removedPairs) > 0 {
 		cfg := config.GetConfig()
@@ -699,6 +1022,7 @@ func (e *Base) UpdateCurrencies(exchangeProducts []string, enabled, force bool)
 			}
 		}
 
+		e.pairsMtx.Lock()
 		if enabled {
 			exch.EnabledPairs = common.JoinStrings(products, ",")
 			e.EnabledPairs = products
@@ -706,6 +1030,7 @@ func (e *Base) UpdateCurrencies(exchangeProducts []string, enabled, force bool)
 			exch.AvailablePairs = common.JoinStrings(products, ",")
 			e.AvailablePairs = products
 		}
+		e.pairsMtx.Unlock()
 		return cfg.UpdateExchangeConfig(exch)
 	}
 	return nil
@@ -757,6 +1082,11 @@ func (o OrderType) Format(exchangeName string) string {
 	return ""
 }
 
+// String returns the order type as accepted by ParseOrderType
+func (o OrderType) String() string {
+	return string(o)
+}
+
 // OrderTypeLimit returns an OrderType limit order
 func OrderTypeLimit() OrderType {
 	return "Limit"
@@ -767,6 +1097,11 @@ func OrderTypeMarket() OrderType {
 	return "Market"
 }
 
+// OrderTypeStopLimit returns an OrderType stop limit order
+func OrderTypeStopLimit() OrderType {
+	return "StopLimit"
+}
+
 // OrderSide enforces a standard for OrderSides across the code base
 type OrderSide string
 
@@ -780,6 +1115,11 @@ func (o OrderSide) Format(exchangeName string) string {
 	return ""
 }
 
+// String returns the order side as accepted by ParseOrderSide
+func (o OrderSide) String() string {
+	return string(o)
+}
+
 // OrderSideBuy returns an OrderSide buy order
 func OrderSideBuy() OrderSide {
 	return "Buy"
@@ -795,6 +1135,8 @@ func (e *Base) SetAPIURL(ec config.ExchangeConfig) error {
 	if ec.APIURL == "" || ec.APIURLSecondary == "" {
 		return errors.New("SetAPIURL error variable zero value")
 	}
+	e.urlMtx.Lock()
+	defer e.urlMtx.Unlock()
 	if ec.APIURL != config.APIURLNonDefaultMessage {
 		e.APIUrl = ec.APIURL
 	}
@@ -806,11 +1148,15 @@ func (e *Base) SetAPIURL(ec config.ExchangeConfig) error {
 
 // GetAPIURL returns the set API URL
 func (e *Base) GetAPIURL() string {
+	e.urlMtx.RLock()
+	defer e.urlMtx.RUnlock()
 	return e.APIUrl
 }
 
 // GetSecondaryAPIURL returns the set Secondary API URL
 func (e *Base) GetSecondaryAPIURL() string {
+	e.urlMtx.RLock()
+	defer e.urlMtx.RUnlock()
 	return e.APIUrlSecondary
 }
 
@@ -892,3 +1238,70 @@ func (e *Base) FormatWithdrawPermissions() string {
 
 	return NoAPIWithdrawalMethodsText
 }
+
+// GetBalanceChanges compares the account info balances against the balances
+// cached from the previous call and returns the currencies which have changed.
+// Currencies which are newly seen or no longer reported are treated as having
+// a zero balance on the other side of the comparison
+func (e *Base) GetBalanceChanges(info AccountInfo) []BalanceChange {
+	e.balanceMtx.Lock()
+	defer e.balanceMtx.Unlock()
+
+	current := make(map[string]float64)
+	for x := range info.Currencies {
+		current[info.Currencies[x].CurrencyName] += info.Currencies[x].TotalValue
+	}
+
+	var changes []BalanceChange
+	for currency, balance := range current {
+		if previous := e.previousBalances[currency]; previous != balance {
+			changes = append(changes, BalanceChange{
+				CurrencyName: currency,
+				Previous:     previous,
+				Current:      balance,
+				Delta:        balance - previous,
+			})
+		}
+	}
+
+	for currency, previous := range e.previousBalances {
+		if _, ok := current[currency]; !ok && previous != 0 {
+			changes = append(changes, BalanceChange{
+				CurrencyName: currency,
+				Previous:     previous,
+				Delta:        -previous,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].CurrencyName < changes[j].CurrencyName
+	})
+
+	e.previousBalances = current
+	return changes
+}
+
+// Validate checks that the fields required to calculate the fee type are set
+func (f *FeeBuilder) Validate() error {
+	switch f.FeeType {
+	case CryptocurrencyTradeFee:
+		if f.PurchasePrice <= 0 {
+			return errors.New("fee builder purchase price must be greater than zero")
+		}
+		if f.Amount <= 0 {
+			return errors.New("fee builder amount must be greater than zero")
+		}
+	case CryptocurrencyWithdrawalFee, CyptocurrencyDepositFee:
+		if f.FirstCurrency == "" {
+			return errors.New("fee builder first currency must be set")
+		}
+	case BankFee, InternationalBankDepositFee, InternationalBankWithdrawalFee:
+		if f.CurrencyItem == "" {
+			return errors.New("fee builder currency item must be set")
+		}
+	default:
+		return fmt.Errorf("fee builder unsupported fee type %q", f.FeeType)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// ArbitrageOpportunity holds the best cross exchange spread for a currency
+// pair, buying at the ask on one exchange and selling at the bid on the other.
+// Fees and spreads are per unit of the first currency, in the second currency
+type ArbitrageOpportunity struct {
+	Pair         pair.CurrencyPair
+	BuyExchange  string
+	SellExchange string
+	BuyPrice     float64
+	SellPrice    float64
+	Spread       float64
+	Fees         float64
+	NetSpread    float64
+	Profitable   bool
+}
+
+// GetArbitrageOpportunity compares the tickers of two exchanges for the
+// currency pair and returns the direction with the largest spread after
+// deducting each exchanges taker fee. Profitable is set if the spread remains
+// positive after fees. The pair is given with the base currency first, such as
+// ETH-BTC for ETH priced in BTC, and is swapped for exchanges which put the
+// quote currency first
+func GetArbitrageOpportunity(first, second IBotExchange, p pair.CurrencyPair, assetType string) (ArbitrageOpportunity, error) {
+	firstTicker, err := getArbitrageTicker(first, p, assetType)
+	if err != nil {
+		return ArbitrageOpportunity{}, err
+	}
+
+	secondTicker, err := getArbitrageTicker(second, p, assetType)
+	if err != nil {
+		return ArbitrageOpportunity{}, err
+	}
+
+	buyFirst, err := newArbitrageOpportunity(first, second, p, firstTicker.Ask, secondTicker.Bid)
+	if err != nil {
+		return ArbitrageOpportunity{}, err
+	}
+
+	buySecond, err := newArbitrageOpportunity(second, first, p, secondTicker.Ask, firstTicker.Bid)
+	if err != nil {
+		return ArbitrageOpportunity{}, err
+	}
+
+	if buySecond.NetSpread > buyFirst.NetSpread {
+		return buySecond, nil
+	}
+	return buyFirst, nil
+}
+
+// getArbitrageTicker returns the exchange ticker priced in the second currency
+// of the pair, ensuring it has a bid and ask. If the exchange only offers the
+// inverse market its ticker is inverted
+func getArbitrageTicker(exch IBotExchange, p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	market := p
+	if exch.IsQuoteCurrencyFirst() {
+		market = p.Swap()
+	}
+
+	tick, err := exch.GetTickerPrice(market, assetType)
+	if err != nil {
+		inverse, inverseErr := exch.GetTickerPrice(market.Swap(), assetType)
+		if inverseErr != nil || inverse.Bid <= 0 || inverse.Ask <= 0 {
+			return tick, err
+		}
+
+		tick = inverse
+		tick.Pair = market
+		tick.Bid, tick.Ask = 1/inverse.Ask, 1/inverse.Bid
+	}
+
+	if tick.Bid <= 0 || tick.Ask <= 0 {
+		return tick, fmt.Errorf("%s %s ticker has no bid or ask",
+			exch.GetName(), p.Pair())
+	}
+	return tick, nil
+}
+
+// newArbitrageOpportunity calculates the spread after taker fees for buying
+// on one exchange and selling on the other
+func newArbitrageOpportunity(buy, sell IBotExchange, p pair.CurrencyPair, buyPrice, sellPrice float64) (ArbitrageOpportunity, error) {
+	buyFee, err := getTakerFee(buy, p, buyPrice)
+	if err != nil {
+		return ArbitrageOpportunity{}, err
+	}
+
+	sellFee, err := getTakerFee(sell, p, sellPrice)
+	if err != nil {
+		return ArbitrageOpportunity{}, err
+	}
+
+	o := ArbitrageOpportunity{
+		Pair:         p,
+		BuyExchange:  buy.GetName(),
+		SellExchange: sell.GetName(),
+		BuyPrice:     buyPrice,
+		SellPrice:    sellPrice,
+		Spread:       sellPrice - buyPrice,
+		Fees:         buyFee + sellFee,
+	}
+	o.NetSpread = o.Spread - o.Fees
+	o.Profitable = o.NetSpread > 0
+	return o, nil
+}
+
+// getTakerFee returns the exchanges taker fee for trading one unit at price
+func getTakerFee(exch IBotExchange, p pair.CurrencyPair, price float64) (float64, error) {
+	return exch.GetFeeByType(FeeBuilder{
+		FeeType:        CryptocurrencyTradeFee,
+		FirstCurrency:  p.FirstCurrency.String(),
+		SecondCurrency: p.SecondCurrency.String(),
+		Delimiter:      p.Delimiter,
+		PurchasePrice:  price,
+		Amount:         1,
+	})
+}
@@ -0,0 +1,108 @@
+package exchange
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type arbitrageExchange struct {
+	IBotExchange
+	name       string
+	bid        float64
+	ask        float64
+	takerFee   float64
+	market     pair.CurrencyPair
+	quoteFirst bool
+}
+
+func (a *arbitrageExchange) GetName() string {
+	return a.name
+}
+
+func (a *arbitrageExchange) IsQuoteCurrencyFirst() bool {
+	return a.quoteFirst
+}
+
+func (a *arbitrageExchange) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	if !a.market.Empty() && !a.market.Equal(p, true) {
+		return ticker.Price{}, errors.New("no ticker for pair")
+	}
+	return ticker.Price{Pair: p, Bid: a.bid, Ask: a.ask}, nil
+}
+
+func (a *arbitrageExchange) GetFeeByType(feeBuilder FeeBuilder) (float64, error) {
+	if feeBuilder.IsMaker || feeBuilder.FeeType != CryptocurrencyTradeFee {
+		return 0, nil
+	}
+	return a.takerFee * feeBuilder.PurchasePrice * feeBuilder.Amount, nil
+}
+
+func TestGetArbitrageOpportunity(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	cheap := &arbitrageExchange{name: "CHEAP", bid: 99, ask: 100, takerFee: 0.001}
+	dear := &arbitrageExchange{name: "DEAR", bid: 102, ask: 103, takerFee: 0.002}
+
+	o, err := GetArbitrageOpportunity(dear, cheap, p, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test failed. GetArbitrageOpportunity() error", err)
+	}
+
+	if o.BuyExchange != "CHEAP" || o.SellExchange != "DEAR" || o.BuyPrice != 100 ||
+		o.SellPrice != 102 || o.Spread != 2 || !o.Profitable {
+		t.Errorf("Test failed. GetArbitrageOpportunity() unexpected values %+v", o)
+	}
+
+	if math.Abs(o.Fees-0.304) > 1e-9 || math.Abs(o.NetSpread-1.696) > 1e-9 {
+		t.Errorf("Test failed. GetArbitrageOpportunity() unexpected fees %+v", o)
+	}
+
+	// The gap is smaller than the fees
+	dear.bid = 100.2
+	o, err = GetArbitrageOpportunity(cheap, dear, p, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test failed. GetArbitrageOpportunity() error", err)
+	}
+
+	if o.BuyExchange != "CHEAP" || o.Spread <= 0 || o.Profitable {
+		t.Errorf("Test failed. GetArbitrageOpportunity() unexpected values %+v", o)
+	}
+
+	cheap.ask = 0
+	if _, err = GetArbitrageOpportunity(cheap, dear, p, ticker.Spot); err == nil {
+		t.Error("Test failed. GetArbitrageOpportunity() should error without an ask")
+	}
+}
+
+func TestGetArbitrageOpportunityPairOrientation(t *testing.T) {
+	p := pair.NewCurrencyPair("ETH", "BTC")
+	// Poloniex prices ETH in BTC on BTC_ETH
+	quoteFirst := &arbitrageExchange{name: "QUOTEFIRST", bid: 0.0302, ask: 0.0303,
+		market: pair.NewCurrencyPairDelimiter("BTC_ETH", "_"), quoteFirst: true}
+	baseFirst := &arbitrageExchange{name: "BASEFIRST", bid: 0.0299, ask: 0.0300,
+		market: pair.NewCurrencyPairDelimiter("ETH_BTC", "_")}
+
+	o, err := GetArbitrageOpportunity(quoteFirst, baseFirst, p, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test failed. GetArbitrageOpportunity() error", err)
+	}
+
+	if o.BuyExchange != "BASEFIRST" || o.BuyPrice != 0.0300 || o.SellPrice != 0.0302 {
+		t.Errorf("Test failed. GetArbitrageOpportunity() unexpected values %+v", o)
+	}
+
+	// An exchange offering only BTC priced in ETH has its ticker inverted
+	inverse := &arbitrageExchange{name: "INVERSE", bid: 32, ask: 32.5,
+		market: pair.NewCurrencyPairDelimiter("BTC_ETH", "_")}
+	o, err = GetArbitrageOpportunity(inverse, baseFirst, p, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test failed. GetArbitrageOpportunity() error", err)
+	}
+
+	if o.SellExchange != "INVERSE" || o.BuyPrice != 0.0300 || math.Abs(o.SellPrice-1/32.5) > 1e-12 {
+		t.Errorf("Test failed. GetArbitrageOpportunity() unexpected values %+v", o)
+	}
+}
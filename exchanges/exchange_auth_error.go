@@ -0,0 +1,74 @@
+package exchange
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// authErrorMessages maps lower case substrings of known exchange authentication
+// error responses to clearer, actionable messages
+var authErrorMessages = []struct {
+	substring string
+	message   string
+}{
+	{"ip not allowed", ipWhitelistMessage},
+	{"ip is not allowed", ipWhitelistMessage},
+	{"ip address not allowed", ipWhitelistMessage},
+	{"not whitelisted", ipWhitelistMessage},
+	{"invalid ip", ipWhitelistMessage},
+	{"permission denied", "the API key was rejected, check its permissions and that this server's IP address is whitelisted for it on the exchange"},
+	{"invalid sign", "the request signature was rejected, check the API secret and that this server's IP address is whitelisted for the API key on the exchange"},
+}
+
+const ipWhitelistMessage = "the request was rejected because this server's IP address is not whitelisted, add it to the API key's IP whitelist on the exchange"
+
+// authErrorResponse is the error field returned by exchanges in the body of a
+// rejected authenticated request
+type authErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// TranslateAuthError returns a clearer error when err matches a known
+// authentication error, such as a request from an IP address which is not
+// whitelisted. Translated errors match ErrAuthFailed and unknown errors are
+// returned unchanged. err must be an error returned by the exchange, transport
+// errors such as a local permission denied would be mistranslated
+func TranslateAuthError(exchName string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return translateAuthError(exchName, err.Error(), err)
+}
+
+// translateAuthError wraps err with a clearer message if the exchange response
+// matches a known authentication error, otherwise err is returned unchanged
+func translateAuthError(exchName, response string, err error) error {
+	lower := common.StringToLower(response)
+	for x := range authErrorMessages {
+		if common.StringContains(lower, authErrorMessages[x].substring) {
+			return &classifiedError{
+				class: ErrAuthFailed,
+				err:   fmt.Errorf("%s %s (%w)", exchName, authErrorMessages[x].message, err),
+			}
+		}
+	}
+	return err
+}
+
+// CheckAuthErrorResponse returns a translated error if an authenticated
+// response body contains a known authentication error, otherwise nil
+func CheckAuthErrorResponse(exchName string, contents []byte) error {
+	var resp authErrorResponse
+	if json.Unmarshal(contents, &resp) != nil || resp.Error == "" {
+		return nil
+	}
+
+	err := errors.New(resp.Error)
+	if translated := TranslateAuthError(exchName, err); translated != err {
+		return translated
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+func TestTranslateAuthError(t *testing.T) {
+	if TranslateAuthError("TESTNAME", nil) != nil {
+		t.Error("Test failed. TranslateAuthError() returned an error for nil")
+	}
+
+	unknown := errors.New("insufficient funds")
+	if TranslateAuthError("TESTNAME", unknown) != unknown {
+		t.Error("Test failed. TranslateAuthError() changed an unknown error")
+	}
+
+	err := TranslateAuthError("TESTNAME", errors.New("IP not allowed"))
+	if err == nil || !common.StringContains(err.Error(), "whitelist") {
+		t.Errorf("Test failed. TranslateAuthError() unexpected error %v", err)
+	}
+}
+
+func TestCheckAuthErrorResponse(t *testing.T) {
+	if err := CheckAuthErrorResponse("TESTNAME", []byte(`[]`)); err != nil {
+		t.Error("Test failed. CheckAuthErrorResponse() error on non object response", err)
+	}
+
+	if err := CheckAuthErrorResponse("TESTNAME", []byte(`{"error":"Not enough BTC."}`)); err != nil {
+		t.Error("Test failed. CheckAuthErrorResponse() error on unknown error", err)
+	}
+
+	err := CheckAuthErrorResponse("TESTNAME", []byte(`{"success":0,"error":"invalid sign"}`))
+	if err == nil || !common.StringContains(err.Error(), "signature") {
+		t.Errorf("Test failed. CheckAuthErrorResponse() unexpected error %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package exchange
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// Candle holds OHLCV data for a single candlestick period
+type Candle struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// Candles holds a series of candlestick data
+type Candles []Candle
+
+// Resample aggregates the candles into a coarser interval, for example
+// building 1 hour candles from 5 minute candles. Candles are grouped by their
+// start time truncated to the interval
+func (c Candles) Resample(interval time.Duration) (Candles, error) {
+	if interval <= 0 {
+		return nil, errors.New("resample interval must be greater than zero")
+	}
+
+	sorted := make(Candles, len(c))
+	copy(sorted, c)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	var resampled Candles
+	for x := range sorted {
+		bucket := sorted[x].Time.Truncate(interval)
+		last := len(resampled) - 1
+		if last < 0 || !resampled[last].Time.Equal(bucket) {
+			resampled = append(resampled, Candle{
+				Time:   bucket,
+				Open:   sorted[x].Open,
+				High:   sorted[x].High,
+				Low:    sorted[x].Low,
+				Close:  sorted[x].Close,
+				Volume: sorted[x].Volume,
+			})
+			continue
+		}
+
+		if sorted[x].High > resampled[last].High {
+			resampled[last].High = sorted[x].High
+		}
+		if sorted[x].Low < resampled[last].Low {
+			resampled[last].Low = sorted[x].Low
+		}
+		resampled[last].Close = sorted[x].Close
+		resampled[last].Volume += sorted[x].Volume
+	}
+	return resampled, nil
+}
@@ -0,0 +1,53 @@
+package exchange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResample(t *testing.T) {
+	start := time.Unix(1530000000, 0).Truncate(time.Hour)
+	var candles Candles
+	for i := 0; i < 24; i++ {
+		candles = append(candles, Candle{
+			Time:   start.Add(time.Duration(i) * time.Minute * 5),
+			Open:   float64(i),
+			High:   float64(i) + 2,
+			Low:    float64(i) - 1,
+			Close:  float64(i) + 1,
+			Volume: 1,
+		})
+	}
+
+	// reverse the order to ensure the input is sorted before resampling
+	for i, j := 0, len(candles)-1; i < j; i, j = i+1, j-1 {
+		candles[i], candles[j] = candles[j], candles[i]
+	}
+
+	resampled, err := candles.Resample(time.Hour)
+	if err != nil {
+		t.Fatal("Test failed. Resample() error", err)
+	}
+
+	if len(resampled) != 2 {
+		t.Fatalf("Test failed. Expected 2 candles, got %d", len(resampled))
+	}
+
+	first := resampled[0]
+	if !first.Time.Equal(start) || first.Open != 0 || first.High != 13 ||
+		first.Low != -1 || first.Close != 12 || first.Volume != 12 {
+		t.Errorf("Test failed. Unexpected first candle %+v", first)
+	}
+
+	second := resampled[1]
+	if !second.Time.Equal(start.Add(time.Hour)) || second.Open != 12 ||
+		second.High != 25 || second.Low != 11 || second.Close != 24 ||
+		second.Volume != 12 {
+		t.Errorf("Test failed. Unexpected second candle %+v", second)
+	}
+
+	_, err = candles.Resample(0)
+	if err == nil {
+		t.Error("Test failed. Resample() error cannot be nil")
+	}
+}
@@ -0,0 +1,41 @@
+package exchange
+
+import (
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Capabilities describes the features implemented by an exchange wrapper, so
+// callers can check for support before calling methods which would otherwise
+// return a not implemented error. Exchanges which have not declared their
+// capabilities report no support
+type Capabilities struct {
+	SupportsMargin              bool
+	SupportsLending             bool
+	SupportsWebsocket           bool
+	SupportsOHLC                bool
+	SupportsTradeHistory        bool
+	SupportsOrderSubmission     bool
+	SupportsMarketOrders        bool
+	SupportsOrderModification   bool
+	SupportsOrderCancellation   bool
+	SupportsCancelAllOrders     bool
+	SupportsOrderInfo           bool
+	SupportsOpenOrders          bool
+	SupportsOrderHistory        bool
+	SupportsDepositAddress      bool
+	SupportsFundTransferHistory bool
+	SupportsWithdrawals         bool
+	SupportsFiat                bool
+}
+
+// Capabilities returns the features supported by the exchange wrapper. Margin
+// is only reported while the margin asset type is enabled, as it may require
+// authenticated API support
+func (e *Base) Capabilities() Capabilities {
+	c := e.Features
+	if c.SupportsMargin {
+		c.SupportsMargin = common.StringDataCompare(e.GetAssetTypes(), ticker.Margin)
+	}
+	return c
+}
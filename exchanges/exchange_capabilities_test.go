@@ -0,0 +1,28 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+func TestCapabilities(t *testing.T) {
+	b := Base{
+		AssetTypes: []string{ticker.Spot, ticker.Margin},
+		Features:   Capabilities{SupportsMargin: true, SupportsWithdrawals: true},
+	}
+
+	c := b.Capabilities()
+	if !c.SupportsMargin || !c.SupportsWithdrawals || c.SupportsWebsocket {
+		t.Errorf("Test failed. Capabilities() unexpected values %+v", c)
+	}
+
+	b.AssetTypes = []string{ticker.Spot}
+	if b.Capabilities().SupportsMargin {
+		t.Error("Test failed. Capabilities() reported margin without the margin asset type")
+	}
+
+	if !b.Features.SupportsMargin {
+		t.Error("Test failed. Capabilities() modified the declared features")
+	}
+}
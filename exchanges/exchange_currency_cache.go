@@ -0,0 +1,88 @@
+package exchange
+
+import (
+	"log"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// currencyPairsCacheDirName is the directory under the default data directory
+// which holds the cached exchange currency pairs
+const currencyPairsCacheDirName = "currencypairs"
+
+// currencyPairsCache is the on disk format of the cached exchange currency
+// pairs
+type currencyPairsCache struct {
+	Timestamp int64    `json:"timestamp"`
+	Pairs     []string `json:"pairs"`
+}
+
+// GetCachedExchangeCurrencies returns the exchange currency pairs from the on
+// disk cache if it was written within the CurrencyPairsCacheTTL, otherwise the
+// pairs are fetched and the cache refreshed. Setting force or a zero TTL always
+// fetches the pairs
+func (e *Base) GetCachedExchangeCurrencies(fetch func() ([]string, error), force bool) ([]string, error) {
+	if e.CurrencyPairsCacheTTL <= 0 {
+		return fetch()
+	}
+
+	cacheFile := e.getCurrencyPairsCacheFile()
+	if !force {
+		data, err := common.ReadFile(cacheFile)
+		if err == nil {
+			var cache currencyPairsCache
+			err = common.JSONDecode(data, &cache)
+			if err == nil && len(cache.Pairs) > 0 &&
+				time.Since(time.Unix(cache.Timestamp, 0)) < e.CurrencyPairsCacheTTL {
+				return cache.Pairs, nil
+			}
+		}
+	}
+
+	pairs, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.writeCurrencyPairsCache(cacheFile, pairs)
+	if err != nil && e.IsVerbose() {
+		log.Printf("%s failed to write currency pairs cache: %s", e.Name, err)
+	}
+	return pairs, nil
+}
+
+// getCurrencyPairsCacheFile returns the cache file path for the exchange
+func (e *Base) getCurrencyPairsCacheFile() string {
+	dir := e.CurrencyPairsCacheDir
+	if dir == "" {
+		dir = filepath.Join(common.GetDefaultDataDir(runtime.GOOS),
+			currencyPairsCacheDirName)
+	}
+	return filepath.Join(dir, common.StringToLower(e.Name)+".json")
+}
+
+// writeCurrencyPairsCache writes the currency pairs to the cache file
+func (e *Base) writeCurrencyPairsCache(cacheFile string, pairs []string) error {
+	dir := filepath.Dir(cacheFile)
+	err := common.CheckDir(filepath.Dir(dir), true)
+	if err != nil {
+		return err
+	}
+
+	err = common.CheckDir(dir, true)
+	if err != nil {
+		return err
+	}
+
+	data, err := common.JSONEncode(currencyPairsCache{
+		Timestamp: time.Now().Unix(),
+		Pairs:     pairs,
+	})
+	if err != nil {
+		return err
+	}
+	return common.WriteFile(cacheFile, data)
+}
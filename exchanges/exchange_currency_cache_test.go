@@ -0,0 +1,64 @@
+package exchange
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetCachedExchangeCurrencies(t *testing.T) {
+	dir, err := ioutil.TempDir("", "currencypairs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"BTC_LTC", "BTC_ETH"}, nil
+	}
+
+	b := Base{Name: "RAWR", CurrencyPairsCacheDir: dir}
+	_, err = b.GetCachedExchangeCurrencies(fetch, false)
+	if err != nil || calls != 1 {
+		t.Fatal("Test failed. GetCachedExchangeCurrencies() fetch without TTL", err)
+	}
+
+	_, err = b.GetCachedExchangeCurrencies(fetch, false)
+	if err != nil || calls != 2 {
+		t.Fatal("Test failed. GetCachedExchangeCurrencies() cached without TTL", err)
+	}
+
+	b.CurrencyPairsCacheTTL = time.Hour
+	pairs, err := b.GetCachedExchangeCurrencies(fetch, false)
+	if err != nil || calls != 3 || len(pairs) != 2 {
+		t.Fatal("Test failed. GetCachedExchangeCurrencies() fetch with empty cache", err)
+	}
+
+	pairs, err = b.GetCachedExchangeCurrencies(fetch, false)
+	if err != nil || calls != 3 || len(pairs) != 2 || pairs[0] != "BTC_LTC" {
+		t.Fatal("Test failed. GetCachedExchangeCurrencies() cached pairs not used", err)
+	}
+
+	_, err = b.GetCachedExchangeCurrencies(fetch, true)
+	if err != nil || calls != 4 {
+		t.Fatal("Test failed. GetCachedExchangeCurrencies() force update ignored", err)
+	}
+
+	b.CurrencyPairsCacheTTL = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	_, err = b.GetCachedExchangeCurrencies(fetch, false)
+	if err != nil || calls != 5 {
+		t.Fatal("Test failed. GetCachedExchangeCurrencies() expired cache used", err)
+	}
+
+	_, err = b.GetCachedExchangeCurrencies(func() ([]string, error) {
+		return nil, errors.New("fetch failed")
+	}, true)
+	if err == nil {
+		t.Error("Test failed. GetCachedExchangeCurrencies() error cannot be nil")
+	}
+}
@@ -0,0 +1,30 @@
+package exchange
+
+// Enable enables the exchange at runtime and clears any previous Stop so the
+// exchange routines can be started again. The caller is responsible for
+// calling Start once the exchange has been enabled
+func (e *Base) Enable() error {
+	e.enabledMtx.Lock()
+	defer e.enabledMtx.Unlock()
+	if e.Enabled {
+		return nil
+	}
+
+	e.resetShutdown()
+	e.SetError(nil)
+	e.Enabled = true
+	return nil
+}
+
+// Disable disables the exchange at runtime, stopping any REST polling and
+// shutting down the websocket connection
+func (e *Base) Disable() error {
+	e.enabledMtx.Lock()
+	if !e.Enabled {
+		e.enabledMtx.Unlock()
+		return nil
+	}
+	e.Enabled = false
+	e.enabledMtx.Unlock()
+	return e.Stop()
+}
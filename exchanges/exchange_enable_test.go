@@ -0,0 +1,38 @@
+package exchange
+
+import "testing"
+
+func TestEnableDisable(t *testing.T) {
+	var b Base
+	if err := b.Enable(); err != nil {
+		t.Fatal("Test failed. Enable() error", err)
+	}
+
+	if !b.IsEnabled() {
+		t.Error("Test failed. IsEnabled() returned false after Enable()")
+	}
+
+	if err := b.Disable(); err != nil {
+		t.Fatal("Test failed. Disable() error", err)
+	}
+
+	if b.IsEnabled() {
+		t.Error("Test failed. IsEnabled() returned true after Disable()")
+	}
+
+	if !b.IsStopped() {
+		t.Error("Test failed. Disable() did not stop the exchange")
+	}
+
+	if err := b.Disable(); err != nil {
+		t.Error("Test failed. Disable() error on second call", err)
+	}
+
+	if err := b.Enable(); err != nil {
+		t.Fatal("Test failed. Enable() error", err)
+	}
+
+	if !b.IsEnabled() || b.IsStopped() {
+		t.Error("Test failed. Enable() did not reset the stopped exchange")
+	}
+}
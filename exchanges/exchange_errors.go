@@ -0,0 +1,161 @@
+package exchange
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+)
+
+// Errors returned by exchange operations which callers can check for with
+// errors.Is
+var (
+	ErrNotYetImplemented = errors.New("not yet implemented")
+	ErrRateLimited       = errors.New("rate limited")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrAuthFailed        = errors.New("authentication failed")
+)
+
+// errorClasses maps lower case substrings of exchange error responses to the
+// error they are classified as
+var errorClasses = []struct {
+	substring string
+	class     error
+}{
+	{"rate limit", ErrRateLimited},
+	{"too many requests", ErrRateLimited},
+	{"api calls per second", ErrRateLimited},
+	{"not enough", ErrInsufficientFunds},
+	{"insufficient", ErrInsufficientFunds},
+	{"invalid api key", ErrAuthFailed},
+	{"invalid key", ErrAuthFailed},
+	{"invalid sign", ErrAuthFailed},
+	{"permission denied", ErrAuthFailed},
+	{"not whitelisted", ErrAuthFailed},
+	{"ip not allowed", ErrAuthFailed},
+	{"ip is not allowed", ErrAuthFailed},
+	{"ip address not allowed", ErrAuthFailed},
+	{"invalid ip", ErrAuthFailed},
+}
+
+// classifiedError keeps the original exchange error message while matching
+// its class with errors.Is
+type classifiedError struct {
+	class error
+	err   error
+}
+
+func (c *classifiedError) Error() string {
+	return c.err.Error()
+}
+
+func (c *classifiedError) Unwrap() error {
+	return c.err
+}
+
+func (c *classifiedError) Is(target error) bool {
+	return target == c.class
+}
+
+// NewCredentialsError returns the error for an authenticated request made
+// without API credentials set, classified as ErrAuthFailed
+func NewCredentialsError(exchName string) error {
+	return &classifiedError{
+		class: ErrAuthFailed,
+		err:   fmt.Errorf(WarningAuthenticatedRequestWithoutCredentialsSet, exchName),
+	}
+}
+
+// ClassifyError wraps an exchange error so errors.Is matches ErrRateLimited,
+// ErrInsufficientFunds or ErrAuthFailed when its message matches a known
+// exchange error. Already classified and unknown errors are returned unchanged
+func ClassifyError(err error) error {
+	if err == nil || errorClass(err) != nil {
+		return err
+	}
+
+	lower := common.StringToLower(err.Error())
+	for x := range errorClasses {
+		if common.StringContains(lower, errorClasses[x].substring) {
+			return &classifiedError{class: errorClasses[x].class, err: err}
+		}
+	}
+	return err
+}
+
+// CheckErrorResponse returns a classified error if a response body contains an
+// error field matching a known class of exchange error, translating
+// authentication errors as CheckAuthErrorResponse does. Unknown errors return
+// nil and are left to the caller
+func CheckErrorResponse(exchName string, contents []byte) error {
+	if err := CheckAuthErrorResponse(exchName, contents); err != nil {
+		return err
+	}
+
+	var resp authErrorResponse
+	if json.Unmarshal(contents, &resp) != nil || resp.Error == "" {
+		return nil
+	}
+
+	err := errors.New(resp.Error)
+	if classified := ClassifyError(err); classified != err {
+		return classified
+	}
+	return nil
+}
+
+// ClassifyRequestError classifies an error returned when sending a request.
+// HTTP error responses are classified by their status code, 429 as
+// ErrRateLimited and 401 or 403 as ErrAuthFailed, otherwise by the error in
+// their response body as CheckErrorResponse does. Authentication errors in the
+// response body are translated as TranslateAuthError does. Errors without an
+// exchange response, such as transport errors, are returned unchanged. The
+// HTTP status code of the original error remains available with
+// request.StatusCode
+func ClassifyRequestError(exchName string, err error) error {
+	var httpErr *request.HTTPError
+	if !errors.As(err, &httpErr) {
+		return err
+	}
+
+	translated := translateAuthError(exchName, httpErr.Body, err)
+
+	if class := errorClass(CheckErrorResponse(exchName, []byte(httpErr.Body))); class != nil {
+		return &classifiedError{class: class, err: translated}
+	}
+
+	switch httpErr.StatusCode {
+	case http.StatusTooManyRequests:
+		return &classifiedError{class: ErrRateLimited, err: translated}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		if errors.Is(translated, ErrAuthFailed) {
+			return translated
+		}
+		return &classifiedError{class: ErrAuthFailed, err: translated}
+	}
+	return translated
+}
+
+// WrapRequestError prefixes a request error with the lower case exchange name,
+// HTTP method and endpoint, for example "liqui: GET depth: <error>", so the
+// source of an error is clear when many exchanges are running. The original
+// error can still be unwrapped
+func WrapRequestError(exchName, method, endpoint string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %s %s: %w", common.StringToLower(exchName), method, endpoint, err)
+}
+
+// errorClass returns the exchange error class of err or nil if it has none
+func errorClass(err error) error {
+	for _, class := range []error{ErrNotYetImplemented, ErrRateLimited, ErrInsufficientFunds, ErrAuthFailed} {
+		if errors.Is(err, class) {
+			return class
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package exchange
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+)
+
+func TestClassifyError(t *testing.T) {
+	if ClassifyError(nil) != nil {
+		t.Error("Test failed. ClassifyError() returned error for nil")
+	}
+
+	tests := []struct {
+		message string
+		class   error
+	}{
+		{"Please do not make more than 6 API calls per second.", ErrRateLimited},
+		{"Not enough BTC.", ErrInsufficientFunds},
+		{"Invalid API key/secret pair.", ErrAuthFailed},
+	}
+
+	for x := range tests {
+		original := errors.New(tests[x].message)
+		err := ClassifyError(original)
+		if !errors.Is(err, tests[x].class) || !errors.Is(err, original) {
+			t.Errorf("Test failed. ClassifyError(%q) expected %s", tests[x].message, tests[x].class)
+		}
+
+		if err.Error() != tests[x].message {
+			t.Errorf("Test failed. ClassifyError() changed error message to %s", err)
+		}
+	}
+
+	unknown := errors.New("order not found")
+	if ClassifyError(unknown) != unknown {
+		t.Error("Test failed. ClassifyError() changed an unknown error")
+	}
+}
+
+func TestCheckErrorResponse(t *testing.T) {
+	err := CheckErrorResponse("TESTNAME", []byte(`{"error":"Too many requests"}`))
+	if !errors.Is(err, ErrRateLimited) {
+		t.Error("Test failed. CheckErrorResponse() expected ErrRateLimited, received", err)
+	}
+
+	err = CheckErrorResponse("TESTNAME", []byte(`{"error":"IP not allowed"}`))
+	if !errors.Is(err, ErrAuthFailed) {
+		t.Error("Test failed. CheckErrorResponse() expected ErrAuthFailed, received", err)
+	}
+
+	if err = CheckErrorResponse("TESTNAME", []byte(`{"error":"Invalid order number"}`)); err != nil {
+		t.Error("Test failed. CheckErrorResponse() returned error for an unknown error", err)
+	}
+
+	if err = CheckErrorResponse("TESTNAME", []byte(`[{"error":"Not enough BTC."}]`)); err != nil {
+		t.Error("Test failed. CheckErrorResponse() returned error for an array response", err)
+	}
+
+	err = &InsufficientBalanceError{Exchange: "TESTNAME", Currency: "BTC", Required: 1}
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Error("Test failed. InsufficientBalanceError does not match ErrInsufficientFunds")
+	}
+}
+
+func TestWrapRequestError(t *testing.T) {
+	if WrapRequestError("TESTNAME", "GET", "depth", nil) != nil {
+		t.Error("Test failed. WrapRequestError() wrapped a nil error")
+	}
+
+	err := WrapRequestError("TESTNAME", "GET", "depth", ErrRateLimited)
+	if err.Error() != "testname: GET depth: rate limited" {
+		t.Error("Test failed. WrapRequestError() unexpected message", err)
+	}
+
+	if !errors.Is(err, ErrRateLimited) {
+		t.Error("Test failed. WrapRequestError() error does not match ErrRateLimited")
+	}
+}
+
+func TestClassifyRequestError(t *testing.T) {
+	if ClassifyRequestError("TESTNAME", nil) != nil {
+		t.Error("Test failed. ClassifyRequestError() returned an error for nil")
+	}
+
+	err := ClassifyRequestError("TESTNAME",
+		&request.HTTPError{Exchange: "TESTNAME", StatusCode: http.StatusTooManyRequests})
+	if !errors.Is(err, ErrRateLimited) || request.StatusCode(err) != http.StatusTooManyRequests {
+		t.Error("Test failed. ClassifyRequestError() expected ErrRateLimited, received", err)
+	}
+
+	err = ClassifyRequestError("TESTNAME",
+		&request.HTTPError{Exchange: "TESTNAME", StatusCode: http.StatusUnauthorized})
+	if !errors.Is(err, ErrAuthFailed) || request.StatusCode(err) != http.StatusUnauthorized {
+		t.Error("Test failed. ClassifyRequestError() expected ErrAuthFailed, received", err)
+	}
+
+	err = ClassifyRequestError("TESTNAME", &request.HTTPError{
+		Exchange:   "TESTNAME",
+		StatusCode: http.StatusBadRequest,
+		Body:       `{"error":"Not enough BTC."}`,
+	})
+	if !errors.Is(err, ErrInsufficientFunds) || request.StatusCode(err) != http.StatusBadRequest {
+		t.Error("Test failed. ClassifyRequestError() expected ErrInsufficientFunds, received", err)
+	}
+
+	err = ClassifyRequestError("TESTNAME",
+		&request.HTTPError{Exchange: "TESTNAME", StatusCode: http.StatusInternalServerError})
+	if errorClass(err) != nil || request.StatusCode(err) != http.StatusInternalServerError {
+		t.Error("Test failed. ClassifyRequestError() classified a server error", err)
+	}
+
+	err = ClassifyRequestError("TESTNAME", &request.HTTPError{
+		Exchange:   "TESTNAME",
+		StatusCode: http.StatusBadRequest,
+		Body:       `{"error":"Permission denied."}`,
+	})
+	if !errors.Is(err, ErrAuthFailed) || !common.StringContains(err.Error(), "whitelisted") ||
+		request.StatusCode(err) != http.StatusBadRequest {
+		t.Error("Test failed. ClassifyRequestError() expected a translated ErrAuthFailed, received", err)
+	}
+
+	// A local permission denied is not an exchange authentication error
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.EACCES)}
+	err = ClassifyRequestError("TESTNAME", dialErr)
+	if err != dialErr || errors.Is(err, ErrAuthFailed) {
+		t.Error("Test failed. ClassifyRequestError() classified a transport error", err)
+	}
+}
@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// Const declarations for the actions reported to the exchange event handler
+const (
+	ActionSubmitOrder     = "submit_order"
+	ActionCancelOrder     = "cancel_order"
+	ActionCancelAllOrders = "cancel_all_orders"
+	ActionWithdraw        = "withdraw"
+)
+
+// ActionEvent describes an order or withdrawal request sent to an exchange.
+// Result holds the order status or withdrawal ID returned by the exchange and
+// Err is set if the request failed
+type ActionEvent struct {
+	Exchange  string
+	Action    string
+	Pair      pair.CurrencyPair
+	Currency  string
+	Side      string
+	OrderID   int64
+	Amount    float64
+	Price     float64
+	Result    string
+	Err       error
+	Timestamp time.Time
+}
+
+// EventHandler receives exchange action events
+type EventHandler func(event ActionEvent)
+
+// SetEventHandler sets the handler called after each order or withdrawal
+// request sent to the exchange, allowing all trading activity to be audited
+// in one place. The handler is called synchronously from the requesting
+// routine so it should not block. A nil handler disables events
+func (e *Base) SetEventHandler(handler EventHandler) {
+	e.eventMtx.Lock()
+	e.eventHandler = handler
+	e.eventMtx.Unlock()
+}
+
+// SendEvent passes the event to the event handler if one is set, setting the
+// exchange name and timestamp
+func (e *Base) SendEvent(event ActionEvent) {
+	e.eventMtx.RLock()
+	handler := e.eventHandler
+	e.eventMtx.RUnlock()
+
+	if handler == nil {
+		return
+	}
+
+	event.Exchange = e.Name
+	event.Timestamp = time.Now()
+	handler(event)
+}
+
+// NewSubmitOrderEvent returns the event for a submitted order
+func NewSubmitOrderEvent(req OrderSubmission, resp SubmitOrderResponse, err error) ActionEvent {
+	return ActionEvent{
+		Action:  ActionSubmitOrder,
+		Pair:    req.Pair,
+		Side:    req.Side.String(),
+		OrderID: resp.OrderID,
+		Amount:  req.Amount,
+		Price:   req.Price,
+		Result:  resp.Status,
+		Err:     err,
+	}
+}
+
+// NewWithdrawalEvent returns the event for a submitted withdrawal
+func NewWithdrawalEvent(req WithdrawalRequest, withdrawalID string, err error) ActionEvent {
+	return ActionEvent{
+		Action:   ActionWithdraw,
+		Currency: req.Currency.String(),
+		Amount:   req.Amount,
+		Result:   withdrawalID,
+		Err:      err,
+	}
+}
@@ -0,0 +1,54 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+func TestSendEvent(t *testing.T) {
+	b := Base{Name: "TESTNAME"}
+
+	// No handler is set by default
+	b.SendEvent(ActionEvent{Action: ActionCancelOrder})
+
+	var events []ActionEvent
+	b.SetEventHandler(func(event ActionEvent) {
+		events = append(events, event)
+	})
+
+	req := OrderSubmission{
+		Pair:   pair.NewCurrencyPair("BTC", "USD"),
+		Side:   OrderSideBuy(),
+		Type:   OrderTypeLimit(),
+		Amount: 1,
+		Price:  100,
+	}
+	b.SendEvent(NewSubmitOrderEvent(req, SubmitOrderResponse{OrderID: 5, Status: "open"}, nil))
+
+	withdrawErr := errors.New("insufficient funds")
+	b.SendEvent(NewWithdrawalEvent(WithdrawalRequest{Currency: "BTC", Amount: 2}, "", withdrawErr))
+
+	if len(events) != 2 {
+		t.Fatal("Test failed. SendEvent() unexpected event count", len(events))
+	}
+
+	e := events[0]
+	if e.Exchange != "TESTNAME" || e.Action != ActionSubmitOrder || e.Pair.Pair() != "BTCUSD" ||
+		e.Side != "Buy" || e.OrderID != 5 || e.Amount != 1 || e.Price != 100 ||
+		e.Result != "open" || e.Err != nil || e.Timestamp.IsZero() {
+		t.Errorf("Test failed. SendEvent() unexpected order event %+v", e)
+	}
+
+	e = events[1]
+	if e.Action != ActionWithdraw || e.Currency != "BTC" || e.Amount != 2 || e.Err != withdrawErr {
+		t.Errorf("Test failed. SendEvent() unexpected withdrawal event %+v", e)
+	}
+
+	b.SetEventHandler(nil)
+	b.SendEvent(ActionEvent{Action: ActionCancelOrder})
+	if len(events) != 2 {
+		t.Error("Test failed. SendEvent() event sent after the handler was removed")
+	}
+}
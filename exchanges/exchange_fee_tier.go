@@ -0,0 +1,35 @@
+package exchange
+
+import (
+	"sort"
+
+	"github.com/thrasher-/gocryptotrader/config"
+)
+
+// SetFeeTiers sets the exchange trading fee tiers sorted by ascending volume
+func (e *Base) SetFeeTiers(tiers []config.FeeTier) {
+	e.FeeTiers = append([]config.FeeTier(nil), tiers...)
+	sort.Slice(e.FeeTiers, func(i, j int) bool {
+		return e.FeeTiers[i].Volume < e.FeeTiers[j].Volume
+	})
+}
+
+// GetFeeTier returns the highest fee tier reached by the 30 day trading
+// volume. If volume is zero the exchanges cached TradingVolume is used. False
+// is returned if no fee tiers are set or the lowest tier has not been reached
+func (e *Base) GetFeeTier(volume float64) (config.FeeTier, bool) {
+	if volume == 0 {
+		volume = e.TradingVolume
+	}
+
+	var tier config.FeeTier
+	var found bool
+	for x := range e.FeeTiers {
+		if volume < e.FeeTiers[x].Volume {
+			break
+		}
+		tier = e.FeeTiers[x]
+		found = true
+	}
+	return tier, found
+}
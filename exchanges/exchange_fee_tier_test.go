@@ -0,0 +1,39 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/config"
+)
+
+func TestGetFeeTier(t *testing.T) {
+	var b Base
+	if _, ok := b.GetFeeTier(1000); ok {
+		t.Error("Test failed. GetFeeTier() returned a tier with no tiers set")
+	}
+
+	b.SetFeeTiers([]config.FeeTier{
+		{Volume: 100, Maker: 0.0008, Taker: 0.002},
+		{Volume: 10, Maker: 0.001, Taker: 0.0025},
+	})
+
+	if _, ok := b.GetFeeTier(5); ok {
+		t.Error("Test failed. GetFeeTier() returned a tier below the lowest volume")
+	}
+
+	tier, ok := b.GetFeeTier(50)
+	if !ok || tier.Volume != 10 {
+		t.Errorf("Test failed. GetFeeTier() unexpected tier %+v", tier)
+	}
+
+	tier, ok = b.GetFeeTier(100)
+	if !ok || tier.Taker != 0.002 {
+		t.Errorf("Test failed. GetFeeTier() unexpected tier %+v", tier)
+	}
+
+	b.TradingVolume = 150
+	tier, ok = b.GetFeeTier(0)
+	if !ok || tier.Maker != 0.0008 {
+		t.Errorf("Test failed. GetFeeTier() unexpected cached volume tier %+v", tier)
+	}
+}
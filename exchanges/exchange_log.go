@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"fmt"
+	"log"
+)
+
+// LogLevel is the severity of an exchange log message
+type LogLevel int
+
+// Const declarations for exchange log levels
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarn
+	LogError
+)
+
+// String returns the log level name used as the log message prefix
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "DEBUG"
+	case LogInfo:
+		return "INFO"
+	case LogWarn:
+		return "WARN"
+	case LogError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+// SetVerbose sets whether the exchange logs verbosely, it is safe to call while
+// the exchange is running
+func (e *Base) SetVerbose(verbose bool) {
+	e.verboseMtx.Lock()
+	e.Verbose = verbose
+	e.verboseMtx.Unlock()
+}
+
+// IsVerbose returns whether the exchange logs verbosely
+func (e *Base) IsVerbose() bool {
+	e.verboseMtx.RLock()
+	defer e.verboseMtx.RUnlock()
+	return e.Verbose
+}
+
+// GetLogLevel returns the lowest level logged by the exchange. Debug messages
+// are only logged when the exchange is verbose
+func (e *Base) GetLogLevel() LogLevel {
+	if e.IsVerbose() {
+		return LogDebug
+	}
+	return LogInfo
+}
+
+// Logf logs a message prefixed with its level and the exchange name if the
+// level is at or above the exchange log level
+func (e *Base) Logf(level LogLevel, format string, args ...interface{}) {
+	if level < e.GetLogLevel() {
+		return
+	}
+	log.Printf("[%s] %s %s", level, e.Name, fmt.Sprintf(format, args...))
+}
+
+// LogDebugf logs a debug message, only logged when the exchange is verbose
+func (e *Base) LogDebugf(format string, args ...interface{}) {
+	e.Logf(LogDebug, format, args...)
+}
+
+// LogInfof logs an informational message
+func (e *Base) LogInfof(format string, args ...interface{}) {
+	e.Logf(LogInfo, format, args...)
+}
+
+// LogWarnf logs a warning for a problem the exchange can continue past
+func (e *Base) LogWarnf(format string, args ...interface{}) {
+	e.Logf(LogWarn, format, args...)
+}
+
+// LogErrorf logs an error for a failed exchange operation
+func (e *Base) LogErrorf(format string, args ...interface{}) {
+	e.Logf(LogError, format, args...)
+}
@@ -0,0 +1,37 @@
+package exchange
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+func TestLogf(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	b := Base{Name: "TESTNAME"}
+	b.LogDebugf("debug %d", 1)
+	if buf.Len() != 0 {
+		t.Error("Test failed. LogDebugf() logged when not verbose")
+	}
+
+	b.LogWarnf("warn %d", 2)
+	if !common.StringContains(buf.String(), "[WARN] TESTNAME warn 2") {
+		t.Errorf("Test failed. LogWarnf() unexpected output %s", buf.String())
+	}
+
+	b.Verbose = true
+	b.LogDebugf("debug %d", 3)
+	if !common.StringContains(buf.String(), "[DEBUG] TESTNAME debug 3") {
+		t.Errorf("Test failed. LogDebugf() unexpected output %s", buf.String())
+	}
+
+	if LogError.String() != "ERROR" || LogLevel(9).String() != "LEVEL(9)" {
+		t.Error("Test failed. LogLevel String() unexpected name")
+	}
+}
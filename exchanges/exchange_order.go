@@ -0,0 +1,292 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// defaultOrderPollingDelay is used when an exchange has no REST polling delay
+// set
+const defaultOrderPollingDelay = time.Second
+
+// orderTerminalStatuses holds the lower case order statuses after which an
+// order can no longer change
+var orderTerminalStatuses = []string{
+	"filled",
+	"cancelled",
+	"canceled",
+	"closed",
+	"rejected",
+	"expired",
+}
+
+// TimeInForce controls how long an order remains on the orderbook
+type TimeInForce string
+
+// Const declarations for order time in force values
+const (
+	// GoodTillCancelled orders rest on the orderbook until filled or cancelled
+	GoodTillCancelled TimeInForce = "GTC"
+	// ImmediateOrCancel orders fill as much as possible immediately and cancel
+	// the remainder
+	ImmediateOrCancel TimeInForce = "IOC"
+	// FillOrKill orders are cancelled unless they can be filled in full
+	// immediately
+	FillOrKill TimeInForce = "FOK"
+)
+
+// String returns the time in force abbreviation
+func (t TimeInForce) String() string {
+	return string(t)
+}
+
+// ErrOrderTypeNotSupported is returned when an exchange does not support the
+// requested order type
+var ErrOrderTypeNotSupported = errors.New("order type not supported")
+
+// ErrPostOnlyNotSupported is returned when an exchange cannot guarantee that
+// an order is only added to the orderbook as a maker order
+var ErrPostOnlyNotSupported = errors.New("post only orders not supported")
+
+// OrderSubmission holds the details of a new order submitted through
+// SubmitOrder
+type OrderSubmission struct {
+	Pair     pair.CurrencyPair
+	Side     OrderSide
+	Type     OrderType
+	Amount   float64
+	Price    float64
+	ClientID string
+	// PostOnly rejects the order instead of matching it against an existing
+	// order, guaranteeing it is placed as a maker order
+	PostOnly bool
+	// TimeInForce defaults to GoodTillCancelled when not set
+	TimeInForce TimeInForce
+	// StopPrice is the trigger price at which a stop limit order is placed on
+	// the orderbook at Price
+	StopPrice float64
+}
+
+// SubmitOrderResponse holds the result of an order submitted through
+// SubmitOrder. Fills holds any trades matched when the order was placed
+type SubmitOrderResponse struct {
+	OrderID int64
+	Status  string
+	Fills   []TradeHistory
+}
+
+// GetTimeInForce returns the order time in force, defaulting to
+// GoodTillCancelled
+func (o *OrderSubmission) GetTimeInForce() TimeInForce {
+	if o.TimeInForce == "" {
+		return GoodTillCancelled
+	}
+	return o.TimeInForce
+}
+
+// Validate checks that the order request contains the fields required by its
+// type
+func (o *OrderSubmission) Validate() error {
+	if o.Pair.Empty() {
+		return errors.New("order currency pair not set")
+	}
+
+	if o.Side != OrderSideBuy() && o.Side != OrderSideSell() {
+		return fmt.Errorf("unknown order side %q", o.Side)
+	}
+
+	if o.Amount <= 0 {
+		return fmt.Errorf("invalid order amount %f", o.Amount)
+	}
+
+	if o.StopPrice != 0 && o.Type != OrderTypeStopLimit() {
+		return fmt.Errorf("stop price not supported by %s orders", o.Type)
+	}
+
+	switch o.Type {
+	case OrderTypeLimit():
+		if o.Price <= 0 {
+			return fmt.Errorf("invalid limit order price %f", o.Price)
+		}
+	case OrderTypeMarket():
+		if o.PostOnly {
+			return errors.New("market orders cannot be post only")
+		}
+	case OrderTypeStopLimit():
+		if o.Price <= 0 {
+			return fmt.Errorf("invalid stop limit order price %f", o.Price)
+		}
+		if o.StopPrice <= 0 {
+			return fmt.Errorf("invalid stop limit order stop price %f", o.StopPrice)
+		}
+	default:
+		return fmt.Errorf("unknown order type %q", o.Type)
+	}
+
+	switch o.GetTimeInForce() {
+	case GoodTillCancelled:
+	case ImmediateOrCancel, FillOrKill:
+		if o.PostOnly {
+			return fmt.Errorf("%s orders cannot be post only", o.TimeInForce)
+		}
+	default:
+		return fmt.Errorf("unknown order time in force %q", o.TimeInForce)
+	}
+	return nil
+}
+
+// orderSideAliases maps the lower case spellings of an order side accepted by
+// ParseOrderSide to the canonical order side
+var orderSideAliases = map[string]OrderSide{
+	"buy":  OrderSideBuy(),
+	"bid":  OrderSideBuy(),
+	"b":    OrderSideBuy(),
+	"long": OrderSideBuy(),
+
+	"sell":  OrderSideSell(),
+	"ask":   OrderSideSell(),
+	"s":     OrderSideSell(),
+	"short": OrderSideSell(),
+}
+
+// orderTypeAliases maps the lower case spellings of an order type accepted by
+// ParseOrderType to the canonical order type
+var orderTypeAliases = map[string]OrderType{
+	"limit": OrderTypeLimit(),
+	"lmt":   OrderTypeLimit(),
+	"l":     OrderTypeLimit(),
+
+	"market": OrderTypeMarket(),
+	"mkt":    OrderTypeMarket(),
+	"m":      OrderTypeMarket(),
+
+	"stoplimit":  OrderTypeStopLimit(),
+	"stop_limit": OrderTypeStopLimit(),
+	"stop-limit": OrderTypeStopLimit(),
+	"stop limit": OrderTypeStopLimit(),
+}
+
+// ParseOrderSide returns the order side for a case insensitive spelling such
+// as "BUY", "bid" or "s"
+func ParseOrderSide(side string) (OrderSide, error) {
+	o, ok := orderSideAliases[common.StringToLower(common.TrimString(side, " "))]
+	if !ok {
+		return "", fmt.Errorf("unknown order side %q", side)
+	}
+	return o, nil
+}
+
+// ParseOrderType returns the order type for a case insensitive spelling such
+// as "LIMIT", "mkt" or "l"
+func ParseOrderType(orderType string) (OrderType, error) {
+	o, ok := orderTypeAliases[common.StringToLower(common.TrimString(orderType, " "))]
+	if !ok {
+		return "", fmt.Errorf("unknown order type %q", orderType)
+	}
+	return o, nil
+}
+
+// IsOrderTerminal returns whether the order status is final
+func IsOrderTerminal(status string) bool {
+	return common.StringDataCompare(orderTerminalStatuses,
+		common.StringToLower(status))
+}
+
+// WaitForOrder polls GetExchangeOrderInfo at the exchanges REST polling delay
+// until the order reaches a terminal status, the timeout expires or the
+// context is cancelled. The last known order detail is always returned
+func WaitForOrder(ctx context.Context, exch IBotExchange, orderID int64, timeout time.Duration) (OrderDetail, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	delay := exch.GetRESTPollingDelay()
+	if delay <= 0 {
+		delay = defaultOrderPollingDelay
+	}
+
+	poll := time.NewTicker(delay)
+	defer poll.Stop()
+
+	var detail OrderDetail
+	for {
+		var err error
+		detail, err = exch.GetExchangeOrderInfo(orderID)
+		if err != nil {
+			return detail, err
+		}
+
+		if IsOrderTerminal(detail.Status) {
+			return detail, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return detail, errors.New("timed out waiting for order to complete")
+			}
+			return detail, ctx.Err()
+		case <-poll.C:
+		}
+	}
+}
+
+// AggregateFills groups individual fills by exchange and order ID into a single
+// order detail per order. The aggregated amount is the total filled amount,
+// the price is the volume weighted average fill price and the creation time is
+// that of the earliest fill. Orders are returned in order of their first fill
+func AggregateFills(fills []OrderDetail) []OrderDetail {
+	type orderKey struct {
+		exchange string
+		id       int64
+	}
+
+	var orders []OrderDetail
+	var prices, volumes [][]float64
+	index := make(map[orderKey]int)
+	for x := range fills {
+		key := orderKey{fills[x].Exchange, fills[x].ID}
+		i, ok := index[key]
+		if !ok {
+			i = len(orders)
+			index[key] = i
+			orders = append(orders, fills[x])
+			orders[i].Amount = 0
+			prices = append(prices, nil)
+			volumes = append(volumes, nil)
+		}
+
+		orders[i].Amount += fills[x].Amount
+		prices[i] = append(prices[i], fills[x].Price)
+		volumes[i] = append(volumes[i], fills[x].Amount)
+		if fills[x].CreationTime < orders[i].CreationTime {
+			orders[i].CreationTime = fills[x].CreationTime
+		}
+	}
+
+	for x := range orders {
+		orders[x].Price, _ = common.CalculateVWAP(prices[x], volumes[x])
+	}
+	return orders
+}
+
+// GetTradeHistoryVWAP returns the volume weighted average price of the supplied
+// trades, or zero if the total traded amount is zero
+func GetTradeHistoryVWAP(trades []TradeHistory) float64 {
+	prices := make([]float64, len(trades))
+	volumes := make([]float64, len(trades))
+	for x := range trades {
+		prices[x] = trades[x].Price
+		volumes[x] = trades[x].Amount
+	}
+
+	vwap, _ := common.CalculateVWAP(prices, volumes)
+	return vwap
+}
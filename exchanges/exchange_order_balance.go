@@ -0,0 +1,51 @@
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// InsufficientBalanceError is returned by the order balance precheck when the
+// available balance does not cover an order
+type InsufficientBalanceError struct {
+	Exchange  string
+	Currency  string
+	Required  float64
+	Available float64
+}
+
+// Error implements the error interface
+func (i *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("%s insufficient available balance, order requires %f %s but only %f is available",
+		i.Exchange, i.Required, i.Currency, i.Available)
+}
+
+// Is allows errors.Is to match the error with ErrInsufficientFunds
+func (i *InsufficientBalanceError) Is(target error) bool {
+	return target == ErrInsufficientFunds
+}
+
+// CheckAvailableBalance returns an InsufficientBalanceError if the available
+// balance of the currency, which excludes funds held by open orders, is less
+// than the required amount. Currencies missing from the account info have no
+// available balance
+func CheckAvailableBalance(info AccountInfo, currency string, required float64) error {
+	var available float64
+	for x := range info.Currencies {
+		if common.StringToUpper(info.Currencies[x].CurrencyName) == common.StringToUpper(currency) {
+			available = info.Currencies[x].Available
+			break
+		}
+	}
+
+	if required > available {
+		return &InsufficientBalanceError{
+			Exchange:  info.ExchangeName,
+			Currency:  common.StringToUpper(currency),
+			Required:  required,
+			Available: available,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package exchange
+
+import "testing"
+
+func TestCheckAvailableBalance(t *testing.T) {
+	info := AccountInfo{
+		ExchangeName: "TESTNAME",
+		Currencies: []AccountCurrencyInfo{
+			{CurrencyName: "BTC", TotalValue: 2, Hold: 1.5, Available: 0.5},
+		},
+	}
+
+	if err := CheckAvailableBalance(info, "btc", 0.5); err != nil {
+		t.Error("Test failed. CheckAvailableBalance() error with sufficient balance", err)
+	}
+
+	err := CheckAvailableBalance(info, "BTC", 1)
+	if e, ok := err.(*InsufficientBalanceError); !ok || e.Available != 0.5 || e.Required != 1 {
+		t.Errorf("Test failed. CheckAvailableBalance() expected InsufficientBalanceError, received %v", err)
+	}
+
+	err = CheckAvailableBalance(info, "LTC", 1)
+	if _, ok := err.(*InsufficientBalanceError); !ok {
+		t.Errorf("Test failed. CheckAvailableBalance() expected InsufficientBalanceError, received %v", err)
+	}
+}
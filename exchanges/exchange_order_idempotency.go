@@ -0,0 +1,145 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+)
+
+// ErrOrderOutcomeUnknown is returned when an order is resubmitted with the
+// client order ID of an earlier submission which failed without a response,
+// and the order cannot be found. The earlier order may have been placed and
+// filled, so it is not submitted again
+var ErrOrderOutcomeUnknown = errors.New("outcome of previous order submission unknown")
+
+// ErrOrderSubmissionPending is returned when an order is submitted with the
+// client order ID of a submission which has not completed
+var ErrOrderSubmissionPending = errors.New("order submission with client order ID in progress")
+
+const (
+	// clientOrderTTL is how long a client order ID is remembered after its
+	// last submission
+	clientOrderTTL = 24 * time.Hour
+	// maxClientOrders is the maximum number of client order IDs remembered,
+	// the oldest are forgotten first
+	maxClientOrders = 10000
+)
+
+// clientOrder holds the result of the last submission of a client order ID
+type clientOrder struct {
+	orderID   int64
+	ambiguous bool
+	pending   bool
+	submitted time.Time
+}
+
+// ClientOrderTracker records the client order IDs submitted to an exchange so
+// that an order retried after a failed submission returns the existing order
+// instead of placing a duplicate. Client order IDs are remembered for
+// clientOrderTTL, up to maxClientOrders. The zero value is ready to use
+type ClientOrderTracker struct {
+	mtx    sync.Mutex
+	orders map[string]clientOrder
+}
+
+// SubmitOrderOnce submits the order unless its client order ID has already
+// been submitted. Resubmissions look the order up by client order ID and
+// return it instead of placing a new order, and a resubmission while the
+// first is still in progress returns ErrOrderSubmissionPending. Orders without
+// a client order ID are always submitted
+func (c *ClientOrderTracker) SubmitOrderOnce(req OrderSubmission, submit func(OrderSubmission) (SubmitOrderResponse, error), lookup func(clientID string) (OrderDetail, error)) (SubmitOrderResponse, error) {
+	if req.ClientID == "" {
+		return submit(req)
+	}
+
+	c.mtx.Lock()
+	previous, ok := c.orders[req.ClientID]
+	if ok && time.Since(previous.submitted) > clientOrderTTL {
+		ok = false
+	}
+	if ok && previous.pending {
+		c.mtx.Unlock()
+		return SubmitOrderResponse{}, fmt.Errorf("client order ID %s: %w",
+			req.ClientID, ErrOrderSubmissionPending)
+	}
+	submitted := ok && (previous.orderID != 0 || previous.ambiguous)
+	if !submitted {
+		c.record(req.ClientID, clientOrder{pending: true})
+	}
+	c.mtx.Unlock()
+
+	if submitted {
+		detail, err := lookup(req.ClientID)
+		if err == nil {
+			c.mtx.Lock()
+			c.record(req.ClientID, clientOrder{orderID: detail.ID})
+			c.mtx.Unlock()
+			return SubmitOrderResponse{OrderID: detail.ID, Status: detail.Status}, nil
+		}
+
+		// The order was placed but is no longer open
+		if previous.orderID != 0 {
+			return SubmitOrderResponse{OrderID: previous.orderID}, nil
+		}
+		return SubmitOrderResponse{}, fmt.Errorf("client order ID %s: %w (%s)",
+			req.ClientID, ErrOrderOutcomeUnknown, err)
+	}
+
+	resp, err := submit(req)
+
+	c.mtx.Lock()
+	c.record(req.ClientID, clientOrder{
+		orderID:   resp.OrderID,
+		ambiguous: err != nil && isAmbiguousRequestError(err),
+	})
+	c.mtx.Unlock()
+
+	return resp, err
+}
+
+// record stores the submission of a client order ID, forgetting client order
+// IDs older than clientOrderTTL and the oldest beyond maxClientOrders. The
+// caller must hold the lock
+func (c *ClientOrderTracker) record(clientID string, order clientOrder) {
+	now := time.Now()
+	if c.orders == nil {
+		c.orders = make(map[string]clientOrder)
+	}
+
+	if _, ok := c.orders[clientID]; !ok && len(c.orders) >= maxClientOrders {
+		var oldestID string
+		var oldest time.Time
+		for id, o := range c.orders {
+			if now.Sub(o.submitted) > clientOrderTTL {
+				delete(c.orders, id)
+				continue
+			}
+			if oldestID == "" || o.submitted.Before(oldest) {
+				oldestID, oldest = id, o.submitted
+			}
+		}
+		if len(c.orders) >= maxClientOrders {
+			delete(c.orders, oldestID)
+		}
+	}
+
+	order.submitted = now
+	c.orders[clientID] = order
+}
+
+// isAmbiguousRequestError returns whether a request failed in a way which
+// leaves it unknown if the exchange received and processed it, such as a
+// timeout, a dropped connection or a server error from the exchange or a
+// gateway in front of it
+func isAmbiguousRequestError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return request.StatusCode(err) >= http.StatusInternalServerError
+}
@@ -0,0 +1,171 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+)
+
+type orderSubmitter struct {
+	submissions int
+	err         error
+	open        map[string]OrderDetail
+}
+
+func (o *orderSubmitter) submit(req OrderSubmission) (SubmitOrderResponse, error) {
+	o.submissions++
+	if o.err != nil {
+		return SubmitOrderResponse{}, o.err
+	}
+	return SubmitOrderResponse{OrderID: int64(o.submissions), Status: "open"}, nil
+}
+
+func (o *orderSubmitter) lookup(clientID string) (OrderDetail, error) {
+	detail, ok := o.open[clientID]
+	if !ok {
+		return detail, errors.New("order not found")
+	}
+	return detail, nil
+}
+
+func TestSubmitOrderOnce(t *testing.T) {
+	var c ClientOrderTracker
+	s := &orderSubmitter{open: make(map[string]OrderDetail)}
+
+	resp, err := c.SubmitOrderOnce(OrderSubmission{ClientID: "1"}, s.submit, s.lookup)
+	if err != nil || resp.OrderID != 1 {
+		t.Fatal("Test failed. SubmitOrderOnce unexpected result", resp, err)
+	}
+
+	s.open["1"] = OrderDetail{ID: 1, ClientID: "1", Status: "open"}
+	resp, err = c.SubmitOrderOnce(OrderSubmission{ClientID: "1"}, s.submit, s.lookup)
+	if err != nil || resp.OrderID != 1 || s.submissions != 1 {
+		t.Error("Test failed. SubmitOrderOnce resubmitted an open order", resp, err)
+	}
+
+	delete(s.open, "1")
+	resp, err = c.SubmitOrderOnce(OrderSubmission{ClientID: "1"}, s.submit, s.lookup)
+	if err != nil || resp.OrderID != 1 || s.submissions != 1 {
+		t.Error("Test failed. SubmitOrderOnce resubmitted a closed order", resp, err)
+	}
+
+	c.SubmitOrderOnce(OrderSubmission{}, s.submit, s.lookup)
+	c.SubmitOrderOnce(OrderSubmission{}, s.submit, s.lookup)
+	if s.submissions != 3 {
+		t.Error("Test failed. SubmitOrderOnce did not submit orders without a client ID")
+	}
+}
+
+func TestSubmitOrderOnceFailedSubmission(t *testing.T) {
+	var c ClientOrderTracker
+	s := &orderSubmitter{
+		err:  errors.New("insufficient funds"),
+		open: make(map[string]OrderDetail),
+	}
+
+	c.SubmitOrderOnce(OrderSubmission{ClientID: "1"}, s.submit, s.lookup)
+	s.err = nil
+	resp, err := c.SubmitOrderOnce(OrderSubmission{ClientID: "1"}, s.submit, s.lookup)
+	if err != nil || s.submissions != 2 || resp.OrderID != 2 {
+		t.Error("Test failed. SubmitOrderOnce did not resubmit a rejected order", resp, err)
+	}
+}
+
+func TestSubmitOrderOnceAmbiguousSubmission(t *testing.T) {
+	var c ClientOrderTracker
+	s := &orderSubmitter{
+		err: fmt.Errorf("request.go error - failed to retry request %w",
+			&net.DNSError{Err: "i/o timeout", IsTimeout: true}),
+		open: make(map[string]OrderDetail),
+	}
+
+	c.SubmitOrderOnce(OrderSubmission{ClientID: "1"}, s.submit, s.lookup)
+	s.err = nil
+	_, err := c.SubmitOrderOnce(OrderSubmission{ClientID: "1"}, s.submit, s.lookup)
+	if !errors.Is(err, ErrOrderOutcomeUnknown) || s.submissions != 1 {
+		t.Error("Test failed. SubmitOrderOnce resubmitted an order with an unknown outcome", err)
+	}
+
+	s.open["1"] = OrderDetail{ID: 1337, ClientID: "1", Status: "open"}
+	resp, err := c.SubmitOrderOnce(OrderSubmission{ClientID: "1"}, s.submit, s.lookup)
+	if err != nil || resp.OrderID != 1337 || s.submissions != 1 {
+		t.Error("Test failed. SubmitOrderOnce did not return the existing order", resp, err)
+	}
+}
+
+func TestSubmitOrderOncePendingSubmission(t *testing.T) {
+	var c ClientOrderTracker
+	s := &orderSubmitter{open: make(map[string]OrderDetail)}
+
+	submitting := make(chan struct{})
+	release := make(chan struct{})
+	blockingSubmit := func(req OrderSubmission) (SubmitOrderResponse, error) {
+		close(submitting)
+		<-release
+		return s.submit(req)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.SubmitOrderOnce(OrderSubmission{ClientID: "1"}, blockingSubmit, s.lookup)
+		done <- err
+	}()
+
+	<-submitting
+	_, err := c.SubmitOrderOnce(OrderSubmission{ClientID: "1"}, s.submit, s.lookup)
+	if !errors.Is(err, ErrOrderSubmissionPending) {
+		t.Error("Test failed. SubmitOrderOnce unexpected result for a pending submission", err)
+	}
+
+	close(release)
+	if err = <-done; err != nil || s.submissions != 1 {
+		t.Error("Test failed. SubmitOrderOnce unexpected result", err, s.submissions)
+	}
+}
+
+func TestClientOrderTrackerEviction(t *testing.T) {
+	var c ClientOrderTracker
+	c.record("expired", clientOrder{orderID: 1})
+	c.orders["expired"] = clientOrder{orderID: 1, submitted: time.Now().Add(-clientOrderTTL - time.Minute)}
+
+	s := &orderSubmitter{open: make(map[string]OrderDetail)}
+	if _, err := c.SubmitOrderOnce(OrderSubmission{ClientID: "expired"}, s.submit, s.lookup); err != nil || s.submissions != 1 {
+		t.Error("Test failed. SubmitOrderOnce did not submit an expired client order ID", err)
+	}
+
+	for i := 0; i < maxClientOrders+10; i++ {
+		c.record(strconv.Itoa(i), clientOrder{orderID: int64(i)})
+	}
+	if len(c.orders) != maxClientOrders {
+		t.Error("Test failed. ClientOrderTracker unexpected size", len(c.orders))
+	}
+}
+
+func TestIsAmbiguousRequestError(t *testing.T) {
+	retryErr := fmt.Errorf("request.go error - failed to retry request %w",
+		&net.DNSError{Err: "i/o timeout", IsTimeout: true})
+	if !isAmbiguousRequestError(WrapRequestError("Poloniex", "POST", "buy", retryErr)) {
+		t.Error("Test failed. isAmbiguousRequestError expected wrapped retry error to be ambiguous")
+	}
+	gatewayErr := &request.HTTPError{Exchange: "Poloniex", StatusCode: 504}
+	if !isAmbiguousRequestError(WrapRequestError("Poloniex", "POST", "buy", gatewayErr)) {
+		t.Error("Test failed. isAmbiguousRequestError expected gateway timeout to be ambiguous")
+	}
+	badRequestErr := &request.HTTPError{Exchange: "Poloniex", StatusCode: 400}
+	if isAmbiguousRequestError(WrapRequestError("Poloniex", "POST", "buy", badRequestErr)) {
+		t.Error("Test failed. isAmbiguousRequestError unexpected ambiguous client error")
+	}
+	urlErr := &url.Error{Op: "Post", URL: "https://poloniex.com/tradingApi", Err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}}
+	if !isAmbiguousRequestError(WrapRequestError("Poloniex", "POST", "buy", urlErr)) {
+		t.Error("Test failed. isAmbiguousRequestError expected wrapped connection error to be ambiguous")
+	}
+	if isAmbiguousRequestError(errors.New("insufficient funds")) {
+		t.Error("Test failed. isAmbiguousRequestError unexpected ambiguous error")
+	}
+}
@@ -0,0 +1,81 @@
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// orderNotionalDelimiters are removed from currency pairs so per pair limits
+// match regardless of the pair format used
+var orderNotionalDelimiters = []string{"_", "-", "/"}
+
+// OrderSizeError is returned when an order notional exceeds the configured
+// maximum order notional
+type OrderSizeError struct {
+	Exchange string
+	Pair     string
+	Notional float64
+	Limit    float64
+}
+
+// Error implements the error interface
+func (o *OrderSizeError) Error() string {
+	return fmt.Sprintf("%s %s order notional %f exceeds the maximum order notional %f",
+		o.Exchange, o.Pair, o.Notional, o.Limit)
+}
+
+// SetMaxOrderNotional sets the maximum order notional for all currency pairs
+// and per currency pair from the exchange config. Per pair limits take
+// precedence and a zero limit disables the check
+func (e *Base) SetMaxOrderNotional(limit float64, pairLimits map[string]float64) {
+	e.MaxOrderNotional = limit
+	e.MaxPairOrderNotional = make(map[string]float64)
+	for currencyPair, pairLimit := range pairLimits {
+		e.MaxPairOrderNotional[orderNotionalKey(currencyPair)] = pairLimit
+	}
+}
+
+// GetMaxOrderNotional returns the maximum order notional for the currency
+// pair, or zero if orders are not limited
+func (e *Base) GetMaxOrderNotional(currencyPair string) float64 {
+	if limit, ok := e.MaxPairOrderNotional[orderNotionalKey(currencyPair)]; ok {
+		return limit
+	}
+	return e.MaxOrderNotional
+}
+
+// CheckOrderNotional returns an OrderSizeError if the order notional, the
+// amount multiplied by the price in the currency the price is quoted in,
+// exceeds the maximum order notional for the currency pair. Orders without a
+// price are rejected while a limit applies as their notional is unknown
+func (e *Base) CheckOrderNotional(currencyPair string, amount, price float64) error {
+	limit := e.GetMaxOrderNotional(currencyPair)
+	if limit <= 0 {
+		return nil
+	}
+
+	if price <= 0 {
+		return fmt.Errorf("%s %s order requires a price to check the maximum order notional %f",
+			e.Name, currencyPair, limit)
+	}
+
+	if notional := amount * price; notional > limit {
+		return &OrderSizeError{
+			Exchange: e.Name,
+			Pair:     currencyPair,
+			Notional: notional,
+			Limit:    limit,
+		}
+	}
+	return nil
+}
+
+// orderNotionalKey returns the upper case currency pair without delimiters
+func orderNotionalKey(currencyPair string) string {
+	key := common.StringToUpper(currencyPair)
+	for x := range orderNotionalDelimiters {
+		key = common.ReplaceString(key, orderNotionalDelimiters[x], "", -1)
+	}
+	return key
+}
@@ -0,0 +1,35 @@
+package exchange
+
+import "testing"
+
+func TestCheckOrderNotional(t *testing.T) {
+	b := Base{Name: "TESTNAME"}
+	if err := b.CheckOrderNotional("BTC_USD", 1000, 10000); err != nil {
+		t.Error("Test failed. CheckOrderNotional() error with no limit", err)
+	}
+
+	b.SetMaxOrderNotional(1000, map[string]float64{"btc-usd": 5000})
+
+	if err := b.CheckOrderNotional("BTC_USD", 0.5, 10000); err != nil {
+		t.Error("Test failed. CheckOrderNotional() error within the pair limit", err)
+	}
+
+	err := b.CheckOrderNotional("BTCUSD", 1, 10000)
+	if e, ok := err.(*OrderSizeError); !ok || e.Notional != 10000 || e.Limit != 5000 {
+		t.Errorf("Test failed. CheckOrderNotional() expected OrderSizeError, received %v", err)
+	}
+
+	err = b.CheckOrderNotional("LTC_USD", 20, 100)
+	if _, ok := err.(*OrderSizeError); !ok {
+		t.Errorf("Test failed. CheckOrderNotional() expected OrderSizeError, received %v", err)
+	}
+
+	if err = b.CheckOrderNotional("LTC_USD", 1, 0); err == nil {
+		t.Error("Test failed. CheckOrderNotional() should error without a price")
+	}
+
+	b.SetMaxOrderNotional(0, nil)
+	if err = b.CheckOrderNotional("LTC_USD", 20, 100); err != nil {
+		t.Error("Test failed. CheckOrderNotional() error after the limit was removed", err)
+	}
+}
@@ -0,0 +1,216 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+type orderPoller struct {
+	IBotExchange
+	statuses []string
+	calls    int
+}
+
+func (o *orderPoller) GetRESTPollingDelay() time.Duration {
+	return time.Millisecond
+}
+
+func (o *orderPoller) GetExchangeOrderInfo(orderID int64) (OrderDetail, error) {
+	if o.statuses == nil {
+		return OrderDetail{}, errors.New("not yet implemented")
+	}
+	status := o.statuses[len(o.statuses)-1]
+	if o.calls < len(o.statuses) {
+		status = o.statuses[o.calls]
+	}
+	o.calls++
+	return OrderDetail{ID: orderID, Status: status}, nil
+}
+
+func TestOrderSubmissionValidate(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	tests := []struct {
+		name  string
+		req   OrderSubmission
+		valid bool
+	}{
+		{"limit", OrderSubmission{Pair: p, Side: OrderSideBuy(), Type: OrderTypeLimit(), Amount: 1, Price: 1}, true},
+		{"post only limit", OrderSubmission{Pair: p, Side: OrderSideSell(), Type: OrderTypeLimit(), Amount: 1, Price: 1, PostOnly: true}, true},
+		{"market", OrderSubmission{Pair: p, Side: OrderSideSell(), Type: OrderTypeMarket(), Amount: 1}, true},
+		{"post only market", OrderSubmission{Pair: p, Side: OrderSideSell(), Type: OrderTypeMarket(), Amount: 1, PostOnly: true}, false},
+		{"no pair", OrderSubmission{Side: OrderSideBuy(), Type: OrderTypeLimit(), Amount: 1, Price: 1}, false},
+		{"no side", OrderSubmission{Pair: p, Type: OrderTypeLimit(), Amount: 1, Price: 1}, false},
+		{"no amount", OrderSubmission{Pair: p, Side: OrderSideBuy(), Type: OrderTypeLimit(), Price: 1}, false},
+		{"no price", OrderSubmission{Pair: p, Side: OrderSideBuy(), Type: OrderTypeLimit(), Amount: 1}, false},
+		{"unknown type", OrderSubmission{Pair: p, Side: OrderSideBuy(), Amount: 1, Price: 1}, false},
+		{"immediate or cancel", OrderSubmission{Pair: p, Side: OrderSideBuy(), Type: OrderTypeLimit(), Amount: 1, Price: 1, TimeInForce: ImmediateOrCancel}, true},
+		{"post only fill or kill", OrderSubmission{Pair: p, Side: OrderSideBuy(), Type: OrderTypeLimit(), Amount: 1, Price: 1, TimeInForce: FillOrKill, PostOnly: true}, false},
+		{"stop limit", OrderSubmission{Pair: p, Side: OrderSideSell(), Type: OrderTypeStopLimit(), Amount: 1, Price: 1, StopPrice: 1.1}, true},
+		{"stop limit no stop price", OrderSubmission{Pair: p, Side: OrderSideSell(), Type: OrderTypeStopLimit(), Amount: 1, Price: 1}, false},
+		{"stop limit no price", OrderSubmission{Pair: p, Side: OrderSideSell(), Type: OrderTypeStopLimit(), Amount: 1, StopPrice: 1}, false},
+		{"limit stop price", OrderSubmission{Pair: p, Side: OrderSideSell(), Type: OrderTypeLimit(), Amount: 1, Price: 1, StopPrice: 1}, false},
+		{"unknown time in force", OrderSubmission{Pair: p, Side: OrderSideBuy(), Type: OrderTypeLimit(), Amount: 1, Price: 1, TimeInForce: "GTD"}, false},
+	}
+
+	for _, test := range tests {
+		err := test.req.Validate()
+		if test.valid && err != nil {
+			t.Errorf("Test failed. Validate() %s error %s", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Test failed. Validate() %s returned nil error", test.name)
+		}
+	}
+}
+
+func TestOrderSubmissionGetTimeInForce(t *testing.T) {
+	var req OrderSubmission
+	if tif := req.GetTimeInForce(); tif != GoodTillCancelled {
+		t.Error("Test failed. GetTimeInForce() unexpected default", tif)
+	}
+
+	req.TimeInForce = FillOrKill
+	if tif := req.GetTimeInForce(); tif != FillOrKill {
+		t.Error("Test failed. GetTimeInForce() unexpected value", tif)
+	}
+}
+
+func TestParseOrderSide(t *testing.T) {
+	for _, side := range []string{"BUY", "bid", "b", " Long "} {
+		o, err := ParseOrderSide(side)
+		if err != nil || o != OrderSideBuy() {
+			t.Errorf("Test failed. ParseOrderSide %q returned %s %v", side, o, err)
+		}
+	}
+
+	for _, side := range []string{"Sell", "ASK", "s", "short"} {
+		o, err := ParseOrderSide(side)
+		if err != nil || o != OrderSideSell() {
+			t.Errorf("Test failed. ParseOrderSide %q returned %s %v", side, o, err)
+		}
+	}
+
+	if _, err := ParseOrderSide("hold"); err == nil {
+		t.Error("Test failed. ParseOrderSide expected error on unknown side")
+	}
+
+	for _, o := range []OrderSide{OrderSideBuy(), OrderSideSell()} {
+		parsed, err := ParseOrderSide(o.String())
+		if err != nil || parsed != o {
+			t.Errorf("Test failed. ParseOrderSide round trip of %s returned %s %v", o, parsed, err)
+		}
+	}
+}
+
+func TestParseOrderType(t *testing.T) {
+	for _, orderType := range []string{"LIMIT", "lmt", "l"} {
+		o, err := ParseOrderType(orderType)
+		if err != nil || o != OrderTypeLimit() {
+			t.Errorf("Test failed. ParseOrderType %q returned %s %v", orderType, o, err)
+		}
+	}
+
+	for _, orderType := range []string{"Market", "MKT", "m"} {
+		o, err := ParseOrderType(orderType)
+		if err != nil || o != OrderTypeMarket() {
+			t.Errorf("Test failed. ParseOrderType %q returned %s %v", orderType, o, err)
+		}
+	}
+
+	for _, orderType := range []string{"StopLimit", "stop_limit", "Stop-Limit"} {
+		o, err := ParseOrderType(orderType)
+		if err != nil || o != OrderTypeStopLimit() {
+			t.Errorf("Test failed. ParseOrderType %q returned %s %v", orderType, o, err)
+		}
+	}
+
+	if _, err := ParseOrderType("stop"); err == nil {
+		t.Error("Test failed. ParseOrderType expected error on unknown type")
+	}
+
+	for _, o := range []OrderType{OrderTypeLimit(), OrderTypeMarket(), OrderTypeStopLimit()} {
+		parsed, err := ParseOrderType(o.String())
+		if err != nil || parsed != o {
+			t.Errorf("Test failed. ParseOrderType round trip of %s returned %s %v", o, parsed, err)
+		}
+	}
+}
+
+func TestIsOrderTerminal(t *testing.T) {
+	if !IsOrderTerminal("Filled") || !IsOrderTerminal("cancelled") {
+		t.Error("Test failed. IsOrderTerminal expected terminal status")
+	}
+	if IsOrderTerminal("open") {
+		t.Error("Test failed. IsOrderTerminal unexpected terminal status")
+	}
+}
+
+func TestWaitForOrder(t *testing.T) {
+	exch := &orderPoller{statuses: []string{"open", "partially filled", "filled"}}
+	detail, err := WaitForOrder(context.Background(), exch, 1337, time.Second)
+	if err != nil {
+		t.Fatal("Test failed. WaitForOrder() error", err)
+	}
+	if detail.ID != 1337 || detail.Status != "filled" || exch.calls != 3 {
+		t.Errorf("Test failed. WaitForOrder() unexpected result %+v", detail)
+	}
+
+	exch = &orderPoller{statuses: []string{"open"}}
+	detail, err = WaitForOrder(context.Background(), exch, 1337, time.Millisecond*10)
+	if err == nil {
+		t.Error("Test failed. WaitForOrder() expected timeout error")
+	}
+	if detail.Status != "open" {
+		t.Error("Test failed. WaitForOrder() last order detail not returned")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = WaitForOrder(ctx, &orderPoller{statuses: []string{"open"}}, 1337, 0)
+	if err != context.Canceled {
+		t.Error("Test failed. WaitForOrder() expected context cancelled error", err)
+	}
+
+	_, err = WaitForOrder(context.Background(), &orderPoller{}, 1337, time.Second)
+	if err == nil {
+		t.Error("Test failed. WaitForOrder() error cannot be nil")
+	}
+}
+
+func TestAggregateFills(t *testing.T) {
+	fills := []OrderDetail{
+		{Exchange: "Liqui", ID: 1, CreationTime: 20, Price: 10, Amount: 1},
+		{Exchange: "Liqui", ID: 2, CreationTime: 15, Price: 5, Amount: 2},
+		{Exchange: "Liqui", ID: 1, CreationTime: 10, Price: 13, Amount: 2},
+	}
+
+	orders := AggregateFills(fills)
+	if len(orders) != 2 {
+		t.Fatalf("Test failed. AggregateFills() unexpected length %d", len(orders))
+	}
+	if orders[0].ID != 1 || orders[0].Amount != 3 || orders[0].Price != 12 ||
+		orders[0].CreationTime != 10 {
+		t.Errorf("Test failed. AggregateFills() unexpected result %+v", orders[0])
+	}
+	if orders[1].ID != 2 || orders[1].Amount != 2 || orders[1].Price != 5 {
+		t.Errorf("Test failed. AggregateFills() unexpected result %+v", orders[1])
+	}
+}
+
+func TestGetTradeHistoryVWAP(t *testing.T) {
+	trades := []TradeHistory{
+		{Price: 10, Amount: 1},
+		{Price: 13, Amount: 2},
+	}
+	if vwap := GetTradeHistoryVWAP(trades); vwap != 12 {
+		t.Errorf("Test failed. GetTradeHistoryVWAP() unexpected result %v", vwap)
+	}
+
+	if vwap := GetTradeHistoryVWAP(nil); vwap != 0 {
+		t.Errorf("Test failed. GetTradeHistoryVWAP() unexpected result %v", vwap)
+	}
+}
@@ -0,0 +1,100 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// SetEnabledPairs replaces the enabled currency pairs at runtime. Every pair
+// must be present in the available pairs. The change applies to subsequent
+// ticker and orderbook updates and is not saved to the config
+func (e *Base) SetEnabledPairs(pairs []pair.CurrencyPair) error {
+	if len(pairs) == 0 {
+		return fmt.Errorf("%s SetEnabledPairs error - pairs is empty", e.Name)
+	}
+
+	e.pairsMtx.Lock()
+	defer e.pairsMtx.Unlock()
+
+	enabled := make([]string, 0, len(pairs))
+	for x := range pairs {
+		if !e.isAvailablePair(pairs[x]) {
+			return fmt.Errorf("%s pair %s is not available", e.Name, pairs[x].Pair())
+		}
+		enabled = append(enabled, e.formatConfigPair(pairs[x]))
+	}
+	e.EnabledPairs = enabled
+	return nil
+}
+
+// AddEnabledPair enables an available currency pair at runtime. Adding a pair
+// which is already enabled has no effect
+func (e *Base) AddEnabledPair(p pair.CurrencyPair) error {
+	e.pairsMtx.Lock()
+	defer e.pairsMtx.Unlock()
+
+	if !e.isAvailablePair(p) {
+		return fmt.Errorf("%s pair %s is not available", e.Name, p.Pair())
+	}
+
+	if e.enabledPairIndex(p) != -1 {
+		return nil
+	}
+
+	enabled := make([]string, len(e.EnabledPairs), len(e.EnabledPairs)+1)
+	copy(enabled, e.EnabledPairs)
+	e.EnabledPairs = append(enabled, e.formatConfigPair(p))
+	return nil
+}
+
+// RemoveEnabledPair disables an enabled currency pair at runtime. The last
+// enabled pair cannot be removed
+func (e *Base) RemoveEnabledPair(p pair.CurrencyPair) error {
+	e.pairsMtx.Lock()
+	defer e.pairsMtx.Unlock()
+
+	i := e.enabledPairIndex(p)
+	if i == -1 {
+		return fmt.Errorf("%s pair %s is not enabled", e.Name, p.Pair())
+	}
+
+	if len(e.EnabledPairs) == 1 {
+		return errors.New("cannot remove the last enabled pair")
+	}
+
+	enabled := make([]string, 0, len(e.EnabledPairs)-1)
+	enabled = append(enabled, e.EnabledPairs[:i]...)
+	e.EnabledPairs = append(enabled, e.EnabledPairs[i+1:]...)
+	return nil
+}
+
+// isAvailablePair returns whether the pair is an available pair. The pairs
+// mutex must be held
+func (e *Base) isAvailablePair(p pair.CurrencyPair) bool {
+	available := pair.FormatPairs(e.AvailablePairs,
+		e.ConfigCurrencyPairFormat.Delimiter,
+		e.ConfigCurrencyPairFormat.Index)
+	return pair.Contains(available, p, true)
+}
+
+// enabledPairIndex returns the index of the pair in the enabled pairs or -1 if
+// it is not enabled. The pairs mutex must be held
+func (e *Base) enabledPairIndex(p pair.CurrencyPair) int {
+	enabled := pair.FormatPairs(e.EnabledPairs,
+		e.ConfigCurrencyPairFormat.Delimiter,
+		e.ConfigCurrencyPairFormat.Index)
+	for x := range enabled {
+		if enabled[x].Equal(p, true) {
+			return x
+		}
+	}
+	return -1
+}
+
+// formatConfigPair formats the pair using the exchange config pair format
+func (e *Base) formatConfigPair(p pair.CurrencyPair) string {
+	return p.Display(e.ConfigCurrencyPairFormat.Delimiter,
+		e.ConfigCurrencyPairFormat.Uppercase).String()
+}
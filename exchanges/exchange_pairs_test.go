@@ -0,0 +1,100 @@
+package exchange
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+func newPairsBase() *Base {
+	return &Base{
+		Name:           "RAWR",
+		AvailablePairs: []string{"BTC-USD", "LTC-USD", "ETH-USD"},
+		EnabledPairs:   []string{"BTC-USD"},
+		ConfigCurrencyPairFormat: config.CurrencyPairFormatConfig{
+			Delimiter: "-",
+			Uppercase: true,
+		},
+	}
+}
+
+func TestSetEnabledPairs(t *testing.T) {
+	b := newPairsBase()
+	err := b.SetEnabledPairs([]pair.CurrencyPair{
+		pair.NewCurrencyPair("ltc", "usd"),
+		pair.NewCurrencyPair("ETH", "USD"),
+	})
+	if err != nil {
+		t.Fatalf("Test failed. SetEnabledPairs() error %s", err)
+	}
+
+	if len(b.EnabledPairs) != 2 || b.EnabledPairs[0] != "LTC-USD" || b.EnabledPairs[1] != "ETH-USD" {
+		t.Errorf("Test failed. SetEnabledPairs() unexpected enabled pairs %v", b.EnabledPairs)
+	}
+
+	err = b.SetEnabledPairs([]pair.CurrencyPair{pair.NewCurrencyPair("XMR", "USD")})
+	if err == nil || len(b.EnabledPairs) != 2 {
+		t.Error("Test failed. SetEnabledPairs() accepted an unavailable pair")
+	}
+
+	if err = b.SetEnabledPairs(nil); err == nil {
+		t.Error("Test failed. SetEnabledPairs() accepted empty pairs")
+	}
+}
+
+func TestAddRemoveEnabledPair(t *testing.T) {
+	b := newPairsBase()
+	if err := b.AddEnabledPair(pair.NewCurrencyPair("LTC", "USD")); err != nil {
+		t.Fatalf("Test failed. AddEnabledPair() error %s", err)
+	}
+
+	if err := b.AddEnabledPair(pair.NewCurrencyPair("LTC", "USD")); err != nil || len(b.EnabledPairs) != 2 {
+		t.Errorf("Test failed. AddEnabledPair() duplicate pair unexpected result %v %v", b.EnabledPairs, err)
+	}
+
+	if err := b.AddEnabledPair(pair.NewCurrencyPair("XMR", "USD")); err == nil {
+		t.Error("Test failed. AddEnabledPair() accepted an unavailable pair")
+	}
+
+	if !b.SupportsCurrency(pair.NewCurrencyPair("LTC", "USD"), true) {
+		t.Error("Test failed. AddEnabledPair() pair not returned by GetEnabledCurrencies")
+	}
+
+	if err := b.RemoveEnabledPair(pair.NewCurrencyPair("BTC", "USD")); err != nil {
+		t.Fatalf("Test failed. RemoveEnabledPair() error %s", err)
+	}
+
+	if len(b.EnabledPairs) != 1 || b.EnabledPairs[0] != "LTC-USD" {
+		t.Errorf("Test failed. RemoveEnabledPair() unexpected enabled pairs %v", b.EnabledPairs)
+	}
+
+	if err := b.RemoveEnabledPair(pair.NewCurrencyPair("ETH", "USD")); err == nil {
+		t.Error("Test failed. RemoveEnabledPair() removed a pair which is not enabled")
+	}
+
+	if err := b.RemoveEnabledPair(pair.NewCurrencyPair("LTC", "USD")); err == nil {
+		t.Error("Test failed. RemoveEnabledPair() removed the last enabled pair")
+	}
+}
+
+func TestEnabledPairsConcurrentAccess(t *testing.T) {
+	b := newPairsBase()
+	p := pair.NewCurrencyPair("LTC", "USD")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			b.AddEnabledPair(p)
+			b.RemoveEnabledPair(p)
+		}()
+		go func() {
+			defer wg.Done()
+			b.GetEnabledCurrencies()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,60 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+)
+
+// PingErrorType describes why an exchange ping failed
+type PingErrorType string
+
+// Const declarations for ping error types
+const (
+	PingNetworkError  PingErrorType = "network"
+	PingAuthError     PingErrorType = "authentication"
+	PingExchangeError PingErrorType = "exchange"
+)
+
+// PingError is returned by Ping and AuthenticatedPing and separates failures to
+// reach the exchange from requests the exchange rejected
+type PingError struct {
+	Exchange string
+	Type     PingErrorType
+	Err      error
+}
+
+// Error implements the error interface
+func (p *PingError) Error() string {
+	return fmt.Sprintf("%s ping %s error: %s", p.Exchange, p.Type, p.Err)
+}
+
+// NewPingError wraps err in a PingError. Transport failures anywhere in the
+// error chain are classified as network errors, ErrAuthFailed and 401 or 403
+// responses as authentication errors and everything else as exchange errors.
+// A nil err returns nil
+func NewPingError(exchName string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	errType := PingExchangeError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr):
+		errType = PingNetworkError
+	case errors.Is(err, ErrAuthFailed),
+		request.StatusCode(err) == http.StatusUnauthorized,
+		request.StatusCode(err) == http.StatusForbidden:
+		errType = PingAuthError
+	}
+
+	return &PingError{
+		Exchange: exchName,
+		Type:     errType,
+		Err:      err,
+	}
+}
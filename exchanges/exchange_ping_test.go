@@ -0,0 +1,50 @@
+package exchange
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+)
+
+func TestNewPingError(t *testing.T) {
+	if err := NewPingError("Liqui", nil); err != nil {
+		t.Error("Test failed. NewPingError() expected nil error")
+	}
+
+	netErr := &net.DNSError{Err: "no such host", Name: "api.liqui.io"}
+	err := NewPingError("Liqui", netErr)
+	if pingErr, ok := err.(*PingError); !ok || pingErr.Type != PingNetworkError {
+		t.Errorf("Test failed. NewPingError() expected network error, received %v", err)
+	}
+
+	urlErr := &url.Error{Op: "Get", URL: "https://api.liqui.io/api/3/info", Err: netErr}
+	err = NewPingError("Liqui", WrapRequestError("Liqui", "GET", "info", urlErr))
+	if pingErr, ok := err.(*PingError); !ok || pingErr.Type != PingNetworkError {
+		t.Errorf("Test failed. NewPingError() expected wrapped network error, received %v", err)
+	}
+
+	err = NewPingError("Liqui", ClassifyError(errors.New("invalid api key")))
+	if pingErr, ok := err.(*PingError); !ok || pingErr.Type != PingAuthError {
+		t.Errorf("Test failed. NewPingError() expected authentication error, received %v", err)
+	}
+
+	httpErr := &request.HTTPError{Exchange: "Liqui", StatusCode: http.StatusForbidden}
+	err = NewPingError("Liqui", WrapRequestError("Liqui", "POST", "getInfo", httpErr))
+	if pingErr, ok := err.(*PingError); !ok || pingErr.Type != PingAuthError {
+		t.Errorf("Test failed. NewPingError() expected 403 authentication error, received %v", err)
+	}
+
+	err = NewPingError("Liqui", errors.New("nonce is too small"))
+	if pingErr, ok := err.(*PingError); !ok || pingErr.Type != PingExchangeError {
+		t.Errorf("Test failed. NewPingError() expected exchange error, received %v", err)
+	}
+
+	err = NewPingError("Liqui", errors.New("bad response"))
+	if pingErr, ok := err.(*PingError); !ok || pingErr.Type != PingExchangeError {
+		t.Errorf("Test failed. NewPingError() expected exchange error, received %v", err)
+	}
+}
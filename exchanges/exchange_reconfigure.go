@@ -0,0 +1,127 @@
+package exchange
+
+import (
+	"fmt"
+	"net/url"
+	"sync/atomic"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// Reconfigure applies the settings in exch which differ from the running
+// exchange without reconstructing it. The polling delay, verbosity, HTTP
+// timeout and user agent, proxy address and fallback, API keys, API URLs and
+// enabled pairs are updated live. The config is validated and the proxy, the
+// only setting which can fail to apply, is changed before anything else, so an
+// error leaves the other settings unchanged.
+//
+// The exchange name, authenticated API support, websocket support, HTTP/2
+// support, certificate pins, circuit breaker settings, sandbox mode, asset
+// types, currency pair formats and available pairs cannot be changed live and
+// require the exchange to be reloaded, as does removing a proxy. A websocket
+// connection cannot move to a new proxy, so changing the proxy address
+// reconnects the websocket. Use Enable and Disable to change whether the
+// exchange is enabled
+func (e *Base) Reconfigure(exch config.ExchangeConfig) error {
+	if common.StringToUpper(exch.Name) != common.StringToUpper(e.Name) {
+		return fmt.Errorf("%s cannot be reconfigured with %s config",
+			e.Name, exch.Name)
+	}
+
+	enabledPairs, err := e.reconfigureEnabledPairs(exch.EnabledPairs)
+	if err != nil {
+		return err
+	}
+
+	proxyAddress := e.GetProxyAddress()
+	if exch.ProxyAddress != proxyAddress {
+		if exch.ProxyAddress == "" {
+			return fmt.Errorf("%s proxy address cannot be removed live, reload the exchange",
+				e.Name)
+		}
+		if _, err = url.Parse(exch.ProxyAddress); err != nil {
+			return fmt.Errorf("%s invalid proxy address %s", e.Name, err)
+		}
+	}
+
+	if exch.APIURL == "" || exch.APIURLSecondary == "" {
+		return fmt.Errorf("%s API URL cannot be empty", e.Name)
+	}
+
+	// The fallback is applied when the proxy transport is created
+	oldFailures, oldBypass := e.GetProxyFallback()
+	e.SetProxyFallback(exch.ProxyFallback, exch.ProxyBypass)
+	failures, bypass := e.GetProxyFallback()
+	fallbackChanged := failures != oldFailures || bypass != oldBypass
+
+	if exch.ProxyAddress != proxyAddress || fallbackChanged && proxyAddress != "" {
+		err = e.SetClientProxyAddress(exch.ProxyAddress)
+		if err != nil {
+			e.SetProxyFallback(oldFailures, oldBypass)
+			return err
+		}
+	}
+
+	if exch.RESTPollingDelay != e.RESTPollingDelay {
+		atomic.StoreInt64((*int64)(&e.RESTPollingDelay), int64(exch.RESTPollingDelay))
+	}
+
+	e.SetVerbose(exch.Verbose)
+
+	if exch.HTTPTimeout != e.GetHTTPClient().Timeout {
+		e.SetHTTPClientTimeout(exch.HTTPTimeout)
+	}
+
+	if exch.HTTPUserAgent != e.GetHTTPClientUserAgent() {
+		e.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+	}
+
+	if e.GetAuthenticatedAPISupport() {
+		e.apiKeysMtx.RLock()
+		b64Decode := e.apiSecretBase64
+		e.apiKeysMtx.RUnlock()
+		e.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, b64Decode)
+	}
+
+	err = e.SetAPIURL(exch)
+	if err != nil {
+		return err
+	}
+
+	if enabledPairs != nil {
+		return e.SetEnabledPairs(enabledPairs)
+	}
+	return nil
+}
+
+// reconfigureEnabledPairs parses and validates the enabled pairs from an
+// exchange config. It returns nil if they match the current enabled pairs
+func (e *Base) reconfigureEnabledPairs(enabled string) ([]pair.CurrencyPair, error) {
+	e.pairsMtx.RLock()
+	defer e.pairsMtx.RUnlock()
+
+	pairs := pair.FormatPairs(common.SplitStrings(enabled, ","),
+		e.ConfigCurrencyPairFormat.Delimiter,
+		e.ConfigCurrencyPairFormat.Index)
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("%s enabled pairs cannot be empty", e.Name)
+	}
+
+	changed := len(pairs) != len(e.EnabledPairs)
+	for x := range pairs {
+		if !e.isAvailablePair(pairs[x]) {
+			return nil, fmt.Errorf("%s pair %s is not available",
+				e.Name, pairs[x].Pair())
+		}
+		if !changed && e.formatConfigPair(pairs[x]) != e.EnabledPairs[x] {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil, nil
+	}
+	return pairs, nil
+}
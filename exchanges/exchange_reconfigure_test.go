@@ -0,0 +1,168 @@
+package exchange
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+)
+
+func newReconfigureConfig() config.ExchangeConfig {
+	return config.ExchangeConfig{
+		Name:             "RAWR",
+		RESTPollingDelay: 10,
+		HTTPTimeout:      15 * time.Second,
+		HTTPUserAgent:    "rawr",
+		APIKey:           "key",
+		APISecret:        "secret",
+		APIURL:           config.APIURLNonDefaultMessage,
+		APIURLSecondary:  config.APIURLNonDefaultMessage,
+		EnabledPairs:     "BTC-USD",
+	}
+}
+
+func TestReconfigure(t *testing.T) {
+	b := newPairsBase()
+	b.AuthenticatedAPISupport = true
+	b.SetHTTPClientTimeout(15 * time.Second)
+	b.SetHTTPClientUserAgent("rawr")
+	b.APIUrl = "https://api.rawr.com"
+
+	cfg := newReconfigureConfig()
+	cfg.RESTPollingDelay = 20
+	cfg.Verbose = true
+	cfg.HTTPTimeout = 30 * time.Second
+	cfg.HTTPUserAgent = "meow"
+	cfg.APIKey = "newkey"
+	cfg.APIURL = "https://api2.rawr.com"
+	cfg.EnabledPairs = "LTC-USD,ETH-USD"
+	cfg.ProxyAddress = "http://localhost:8080"
+	cfg.ProxyFallback = 3
+	err := b.Reconfigure(cfg)
+	if err != nil {
+		t.Fatalf("Test failed. Reconfigure() error %s", err)
+	}
+
+	if b.GetRESTPollingDelay() != 20*RESTPollingDelayUnit {
+		t.Errorf("Test failed. Reconfigure() unexpected polling delay %s",
+			b.GetRESTPollingDelay())
+	}
+
+	if !b.IsVerbose() {
+		t.Error("Test failed. Reconfigure() did not set verbose")
+	}
+
+	if b.GetHTTPClient().Timeout != 30*time.Second {
+		t.Error("Test failed. Reconfigure() did not update the HTTP client timeout")
+	}
+
+	if b.Requester.UserAgent != "meow" {
+		t.Errorf("Test failed. Reconfigure() unexpected user agent %s",
+			b.Requester.UserAgent)
+	}
+
+	if b.ProxyAddress != "http://localhost:8080" {
+		t.Errorf("Test failed. Reconfigure() unexpected proxy address %s",
+			b.ProxyAddress)
+	}
+
+	if failures, _ := b.GetProxyFallback(); failures != 3 {
+		t.Errorf("Test failed. Reconfigure() unexpected proxy fallback %d", failures)
+	}
+
+	if apiKey, apiSecret, _ := b.GetAPIKeys(); apiKey != "newkey" || apiSecret != "secret" {
+		t.Errorf("Test failed. Reconfigure() unexpected API keys %s %s",
+			apiKey, apiSecret)
+	}
+
+	if b.GetAPIURL() != "https://api2.rawr.com" {
+		t.Errorf("Test failed. Reconfigure() unexpected API URL %s", b.GetAPIURL())
+	}
+
+	if len(b.EnabledPairs) != 2 || b.EnabledPairs[0] != "LTC-USD" || b.EnabledPairs[1] != "ETH-USD" {
+		t.Errorf("Test failed. Reconfigure() unexpected enabled pairs %v",
+			b.EnabledPairs)
+	}
+}
+
+func TestReconfigureConcurrentAccess(t *testing.T) {
+	b := newPairsBase()
+	b.SetHTTPClientTimeout(15 * time.Second)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			b.GetAPIURL()
+			b.GetSecondaryAPIURL()
+			b.GetHTTPClientUserAgent()
+			b.GetProxyAddress()
+			b.GetHTTPClient()
+		}
+	}()
+
+	for i := 0; i < 50; i++ {
+		cfg := newReconfigureConfig()
+		cfg.HTTPTimeout = time.Duration(i+1) * time.Second
+		cfg.HTTPUserAgent = fmt.Sprintf("rawr%d", i)
+		cfg.APIURL = fmt.Sprintf("https://api%d.rawr.com", i)
+		if err := b.Reconfigure(cfg); err != nil {
+			t.Fatalf("Test failed. Reconfigure() error %s", err)
+		}
+	}
+	wg.Wait()
+}
+
+func TestReconfigureInvalid(t *testing.T) {
+	b := newPairsBase()
+	b.SetHTTPClientTimeout(15 * time.Second)
+	b.ProxyAddress = "http://localhost:8080"
+
+	cfg := newReconfigureConfig()
+	cfg.ProxyAddress = b.ProxyAddress
+	cfg.Name = "MEOW"
+	if err := b.Reconfigure(cfg); err == nil {
+		t.Error("Test failed. Reconfigure() accepted another exchange's config")
+	}
+
+	tests := []func(*config.ExchangeConfig){
+		func(c *config.ExchangeConfig) { c.EnabledPairs = "XMR-USD" },
+		func(c *config.ExchangeConfig) { c.EnabledPairs = "" },
+		func(c *config.ExchangeConfig) { c.ProxyAddress = "" },
+		func(c *config.ExchangeConfig) { c.ProxyAddress = "%gh&%ij" },
+		func(c *config.ExchangeConfig) { c.APIURL = "" },
+	}
+	for x := range tests {
+		cfg = newReconfigureConfig()
+		cfg.ProxyAddress = b.ProxyAddress
+		cfg.RESTPollingDelay = 20
+		tests[x](&cfg)
+
+		if err := b.Reconfigure(cfg); err == nil {
+			t.Errorf("Test failed. Reconfigure() test %d accepted an invalid config", x)
+		}
+
+		if b.RESTPollingDelay != 0 || len(b.EnabledPairs) != 1 {
+			t.Errorf("Test failed. Reconfigure() test %d partially applied an invalid config", x)
+		}
+	}
+	// A proxy which fails to apply leaves the exchange unchanged
+	b.WebsocketInit()
+	b.Websocket.proxyAddr = "http://localhost:9090"
+	cfg = newReconfigureConfig()
+	cfg.ProxyAddress = "http://localhost:9090"
+	cfg.ProxyFallback = 3
+	cfg.RESTPollingDelay = 20
+	cfg.Verbose = true
+	if err := b.Reconfigure(cfg); err == nil {
+		t.Error("Test failed. Reconfigure() expected proxy error")
+	}
+
+	if failures, _ := b.GetProxyFallback(); failures != 0 || b.IsVerbose() ||
+		b.RESTPollingDelay != 0 || b.ProxyAddress != "http://localhost:8080" {
+		t.Error("Test failed. Reconfigure() partially applied a config with a failed proxy")
+	}
+}
@@ -0,0 +1,41 @@
+package exchange
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+)
+
+// RunRecovered calls run and recovers from any panic it raises so a single
+// exchange cannot take down the rest of the engine. A recovered panic is
+// logged with the exchange name and stack trace and the exchange is marked as
+// errored
+func (e *Base) RunRecovered(run func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("%s exchange routine panic: %v\n%s", e.Name, r, debug.Stack())
+			e.SetError(fmt.Errorf("%s exchange routine panic: %v", e.Name, r))
+		}
+	}()
+	run()
+}
+
+// SetError marks the exchange as errored
+func (e *Base) SetError(err error) {
+	e.errMtx.Lock()
+	e.err = err
+	e.errMtx.Unlock()
+}
+
+// GetError returns the error the exchange was marked with, or nil if the
+// exchange has not errored
+func (e *Base) GetError() error {
+	e.errMtx.Lock()
+	defer e.errMtx.Unlock()
+	return e.err
+}
+
+// IsErrored returns whether the exchange has been marked as errored
+func (e *Base) IsErrored() bool {
+	return e.GetError() != nil
+}
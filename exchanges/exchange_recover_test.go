@@ -0,0 +1,32 @@
+package exchange
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRunRecovered(t *testing.T) {
+	b := Base{Name: "Panicky"}
+	if b.IsErrored() {
+		t.Error("Test failed. IsErrored() returned true before Run")
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		b.RunRecovered(func() {
+			var m map[string]float64
+			m["BTC"] = 1
+		})
+		wg.Done()
+	}()
+	wg.Wait()
+
+	if !b.IsErrored() {
+		t.Error("Test failed. IsErrored() returned false after a panicking Run")
+	}
+
+	if b.GetError() == nil {
+		t.Error("Test failed. GetError() returned nil after a panicking Run")
+	}
+}
@@ -0,0 +1,106 @@
+package exchange
+
+import "time"
+
+// defaultRESTPollingDelay is used by PollUntilStopped when an exchange has no
+// REST polling delay set
+const defaultRESTPollingDelay = time.Second
+
+// shutdownChannel returns the exchange shutdown channel, creating it if needed
+func (e *Base) shutdownChannel() chan struct{} {
+	e.shutdownMtx.Lock()
+	defer e.shutdownMtx.Unlock()
+	if e.shutdown == nil {
+		e.shutdown = make(chan struct{})
+	}
+	return e.shutdown
+}
+
+// GetShutdownChannel returns a channel which is closed when Stop is called.
+// Routines started by the exchange should return once it is closed
+func (e *Base) GetShutdownChannel() <-chan struct{} {
+	return e.shutdownChannel()
+}
+
+// IsStopped returns whether Stop has been called
+func (e *Base) IsStopped() bool {
+	select {
+	case <-e.shutdownChannel():
+		return true
+	default:
+		return false
+	}
+}
+
+// Stop signals the routines started by the exchange to return and shuts down
+// the websocket connection if connected. Calling Stop more than once is safe
+func (e *Base) Stop() error {
+	shutdown := e.shutdownChannel()
+	e.shutdownMtx.Lock()
+	select {
+	case <-shutdown:
+	default:
+		close(shutdown)
+	}
+	e.shutdownMtx.Unlock()
+
+	if e.Websocket != nil && e.Websocket.IsConnected() {
+		return e.Websocket.Shutdown()
+	}
+	return nil
+}
+
+// resetShutdown replaces a closed shutdown channel so the exchange routines
+// can be started again after Stop
+func (e *Base) resetShutdown() {
+	e.shutdownMtx.Lock()
+	defer e.shutdownMtx.Unlock()
+	select {
+	case <-e.shutdown:
+		e.shutdown = nil
+	default:
+	}
+}
+
+// PollUntilStopped calls poll immediately and then at the REST polling delay
+// until Stop is called. A polling delay changed by Reconfigure takes effect
+// after the next poll
+func (e *Base) PollUntilStopped(poll func()) {
+	delay := e.pollingDelay()
+	t := time.NewTicker(delay)
+	defer func() {
+		t.Stop()
+	}()
+
+	shutdown := e.GetShutdownChannel()
+	for {
+		select {
+		case <-shutdown:
+			return
+		default:
+		}
+
+		poll()
+
+		if d := e.pollingDelay(); d != delay {
+			t.Stop()
+			delay = d
+			t = time.NewTicker(delay)
+		}
+
+		select {
+		case <-shutdown:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// pollingDelay returns the REST polling delay or the default if it is unset
+func (e *Base) pollingDelay() time.Duration {
+	delay := e.GetRESTPollingDelay()
+	if delay <= 0 {
+		return defaultRESTPollingDelay
+	}
+	return delay
+}
@@ -0,0 +1,62 @@
+package exchange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStop(t *testing.T) {
+	var b Base
+	if b.IsStopped() {
+		t.Error("Test failed. IsStopped() returned true before Stop()")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		<-b.GetShutdownChannel()
+		close(done)
+	}()
+
+	if err := b.Stop(); err != nil {
+		t.Fatal("Test failed. Stop() error", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Test failed. Shutdown channel was not closed by Stop()")
+	}
+
+	if !b.IsStopped() {
+		t.Error("Test failed. IsStopped() returned false after Stop()")
+	}
+
+	if err := b.Stop(); err != nil {
+		t.Error("Test failed. Stop() error on second call", err)
+	}
+}
+
+func TestPollUntilStopped(t *testing.T) {
+	var b Base
+	var polls int
+	done := make(chan struct{})
+	go func() {
+		b.PollUntilStopped(func() {
+			polls++
+			if polls == 1 {
+				b.Stop()
+			}
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Test failed. PollUntilStopped() did not return after Stop()")
+	}
+
+	if polls != 1 {
+		t.Errorf("Test failed. PollUntilStopped() unexpected poll count %d", polls)
+	}
+}
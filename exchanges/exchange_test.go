@@ -2,6 +2,7 @@ package exchange
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -23,6 +24,17 @@ func TestSupportsRESTTickerBatchUpdates(t *testing.T) {
 	}
 }
 
+func TestGetRESTPollingDelay(t *testing.T) {
+	b := Base{
+		Name:             "RAWR",
+		RESTPollingDelay: 10,
+	}
+
+	if b.GetRESTPollingDelay() != 10*time.Second {
+		t.Fatalf("Test failed. GetRESTPollingDelay returned %s", b.GetRESTPollingDelay())
+	}
+}
+
 func TestHTTPClient(t *testing.T) {
 	r := Base{Name: "asdf"}
 	r.SetHTTPClientTimeout(time.Duration(time.Second * 5))
@@ -65,6 +77,130 @@ func TestHTTPClient(t *testing.T) {
 	}
 }
 
+func TestSetHTTP2Enabled(t *testing.T) {
+	b := Base{Name: "RAWR"}
+	b.SetHTTPClientTimeout(time.Second * 5)
+
+	b.SetHTTP2Enabled(true)
+	if b.GetHTTPClient().Transport != nil {
+		t.Fatal("Test failed. TestSetHTTP2Enabled unexpected transport")
+	}
+
+	b.SetHTTP2Enabled(false)
+	transport, ok := b.GetHTTPClient().Transport.(*http.Transport)
+	if !ok || transport.TLSNextProto == nil {
+		t.Fatal("Test failed. TestSetHTTP2Enabled HTTP/2 not disabled")
+	}
+	if b.GetHTTPClient().Timeout != time.Second*5 {
+		t.Fatal("Test failed. TestSetHTTP2Enabled timeout not retained")
+	}
+
+	err := b.SetClientProxyAddress("http://www.google.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport, ok = b.GetHTTPClient().Transport.(*http.Transport)
+	if !ok || transport.TLSNextProto == nil {
+		t.Fatal("Test failed. TestSetHTTP2Enabled HTTP/2 setting lost on proxy change")
+	}
+
+	b.SetHTTP2Enabled(true)
+	transport, ok = b.GetHTTPClient().Transport.(*http.Transport)
+	if !ok || transport.TLSNextProto != nil || !transport.ForceAttemptHTTP2 {
+		t.Fatal("Test failed. TestSetHTTP2Enabled HTTP/2 not enabled")
+	}
+}
+
+func TestSetHTTP2EnabledNegotiation(t *testing.T) {
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	s.EnableHTTP2 = true
+	s.StartTLS()
+	defer s.Close()
+
+	b := Base{Name: "RAWR"}
+	b.SetHTTPClient(s.Client())
+
+	for _, enabled := range []bool{false, true, false} {
+		b.SetHTTP2Enabled(enabled)
+		resp, err := b.GetHTTPClient().Get(s.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if (resp.ProtoMajor == 2) != enabled {
+			t.Errorf("Test failed. SetHTTP2Enabled(%v) negotiated %s", enabled, resp.Proto)
+		}
+	}
+}
+
+func TestSetHTTPClientPins(t *testing.T) {
+	b := Base{Name: "RAWR"}
+	b.SetHTTPClientTimeout(time.Second * 5)
+
+	err := b.SetHTTPClientPins(nil)
+	if err != nil || b.GetHTTPClient().Transport != nil {
+		t.Fatal("Test failed. SetHTTPClientPins changed the client without pins")
+	}
+
+	err = b.SetHTTPClientPins([]string{"rawr"})
+	if err == nil {
+		t.Fatal("Test failed. SetHTTPClientPins accepted an invalid pin")
+	}
+
+	pins := []string{"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="}
+	err = b.SetHTTPClientPins(pins)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport, ok := b.GetHTTPClient().Transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig == nil || transport.TLSClientConfig.VerifyPeerCertificate == nil {
+		t.Fatal("Test failed. SetHTTPClientPins pins not set")
+	}
+	if b.GetHTTPClient().Timeout != time.Second*5 {
+		t.Fatal("Test failed. SetHTTPClientPins timeout not retained")
+	}
+
+	b.SetHTTP2Enabled(false)
+	err = b.SetClientProxyAddress("http://www.google.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport, ok = b.GetHTTPClient().Transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig == nil || transport.TLSClientConfig.VerifyPeerCertificate == nil {
+		t.Fatal("Test failed. SetHTTPClientPins pins lost on proxy change")
+	}
+}
+
+func TestServerTimeOffset(t *testing.T) {
+	b := Base{Name: "RAWR"}
+	if b.GetServerTimeOffset() != 0 {
+		t.Fatal("Test failed. TestServerTimeOffset unexpected default offset")
+	}
+
+	b.SetServerTimeOffset(time.Now().Add(time.Minute))
+	offset := b.GetServerTimeOffset()
+	if offset <= time.Second*59 || offset > time.Minute {
+		t.Fatalf("Test failed. TestServerTimeOffset unexpected offset %v", offset)
+	}
+
+	if b.Nonce.Now().Sub(time.Now()) > time.Second {
+		t.Fatal("Test failed. TestServerTimeOffset nonce offset applied without opt in")
+	}
+
+	b.SyncNonceWithServerTime = true
+	b.SetServerTimeOffset(time.Now().Add(time.Minute))
+	if b.Nonce.Now().Sub(time.Now()) <= time.Second*58 {
+		t.Fatal("Test failed. TestServerTimeOffset nonce offset not applied")
+	}
+
+	aligned := b.GetServerAlignedTime()
+	if aligned.Sub(time.Now()) <= time.Second*58 {
+		t.Errorf("Test failed. TestServerTimeOffset unexpected aligned time %v", aligned)
+	}
+}
+
 func TestSetClientProxyAddress(t *testing.T) {
 	requester := request.New("testicles",
 		&request.RateLimit{},
@@ -181,6 +317,22 @@ func TestSupportsAutoPairUpdates(t *testing.T) {
 	}
 }
 
+func TestAutoPairUpdatesEnabled(t *testing.T) {
+	b := Base{
+		Name:                     "TESTNAME",
+		SupportsAutoPairUpdating: true,
+	}
+
+	if !b.AutoPairUpdatesEnabled() {
+		t.Fatal("Test failed. TestAutoPairUpdatesEnabled Incorrect value")
+	}
+
+	b.DisableAutoPairUpdates = true
+	if b.AutoPairUpdatesEnabled() {
+		t.Fatal("Test failed. TestAutoPairUpdatesEnabled Incorrect value when disabled")
+	}
+}
+
 func TestGetLastPairsUpdateTime(t *testing.T) {
 	testTime := time.Now().Unix()
 	b := Base{
@@ -257,6 +409,20 @@ func TestGetAssetTypes(t *testing.T) {
 	}
 }
 
+func TestSupportsAssetType(t *testing.T) {
+	testExchange := Base{
+		AssetTypes: []string{ticker.Spot, ticker.Margin},
+	}
+
+	if !testExchange.SupportsAssetType(ticker.Margin) {
+		t.Error("Test failed. TestSupportsAssetType margin not supported")
+	}
+
+	if testExchange.SupportsAssetType("FUTURES") {
+		t.Error("Test failed. TestSupportsAssetType unexpected asset type supported")
+	}
+}
+
 func TestGetExchangeAssetTypes(t *testing.T) {
 	cfg := config.GetConfig()
 	err := cfg.LoadConfig(config.ConfigTestFile)
@@ -451,6 +617,36 @@ func TestGetEnabledCurrencies(t *testing.T) {
 	}
 }
 
+func TestGetEnabledCurrenciesByQuote(t *testing.T) {
+	b := Base{
+		Name: "TESTNAME",
+	}
+
+	b.EnabledPairs = []string{"ETH-BTC", "BTC-USD", "LTC-btc"}
+	b.ConfigCurrencyPairFormat.Delimiter = "-"
+	c := b.GetEnabledCurrenciesByQuote("btc")
+	if len(c) != 2 || c[0].Pair().String() != "ETH-BTC" || c[1].Pair().String() != "LTC-btc" {
+		t.Errorf("Test failed. GetEnabledCurrenciesByQuote() unexpected pairs %v", c)
+	}
+
+	if c = b.GetEnabledCurrenciesByQuote("XMR"); len(c) != 0 {
+		t.Errorf("Test failed. GetEnabledCurrenciesByQuote() unexpected pairs %v", c)
+	}
+
+	b.EnabledPairs = []string{"BTC_LTC", "BTC_ETH", "USDT_BTC"}
+	b.ConfigCurrencyPairFormat.Delimiter = "_"
+	b.QuoteCurrencyFirst = true
+	c = b.GetEnabledCurrenciesByQuote("BTC")
+	if len(c) != 2 || c[0].Pair().String() != "BTC_LTC" || c[1].Pair().String() != "BTC_ETH" {
+		t.Errorf("Test failed. GetEnabledCurrenciesByQuote() unexpected pairs %v", c)
+	}
+
+	c = b.GetEnabledCurrenciesByQuote("USDT")
+	if len(c) != 1 || c[0].Pair().String() != "USDT_BTC" {
+		t.Errorf("Test failed. GetEnabledCurrenciesByQuote() unexpected pairs %v", c)
+	}
+}
+
 func TestGetAvailableCurrencies(t *testing.T) {
 	b := Base{
 		Name: "TESTNAME",
@@ -680,6 +876,73 @@ func TestSetAPIKeys(t *testing.T) {
 	SetAPIKeys.SetAPIKeys("RocketMan", "Digereedoo", "007", true)
 }
 
+func TestCheckAPICredentials(t *testing.T) {
+	b := Base{Name: "TESTNAME", AuthenticatedAPISupport: true}
+	if err := b.CheckAPICredentials(); err == nil {
+		t.Error("Test failed. CheckAPICredentials() returned nil error with no credentials")
+	}
+
+	b.SetAPIKeys("RocketMan", "", "", false)
+	if err := b.CheckAPICredentials(); err == nil {
+		t.Error("Test failed. CheckAPICredentials() returned nil error with no secret")
+	}
+
+	b.SetAPIKeys("RocketMan", "Digereedoo", "", false)
+	if err := b.CheckAPICredentials(); err != nil {
+		t.Error("Test failed. CheckAPICredentials() error", err)
+	}
+}
+
+func TestGetAPIKeys(t *testing.T) {
+	b := Base{AuthenticatedAPISupport: true}
+	b.SetAPIKeys("RocketMan", "Digereedoo", "007", false)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			b.SetAPIKeys("Rotated", "Secret", "008", false)
+			b.SetAPIKeys("RocketMan", "Digereedoo", "007", false)
+		}
+		close(done)
+	}()
+
+	for i := 0; i < 100; i++ {
+		key, secret, clientID := b.GetAPIKeys()
+		if (key == "RocketMan") != (secret == "Digereedoo") ||
+			(key == "RocketMan") != (clientID == "007") {
+			t.Fatalf("Test failed. GetAPIKeys() returned mixed credentials %s %s %s",
+				key, secret, clientID)
+		}
+	}
+	<-done
+
+	b.SetAPIKeys("Rotated", "Secret", "008", false)
+	key, secret, clientID := b.GetAPIKeys()
+	if key != "Rotated" || secret != "Secret" || clientID != "008" {
+		t.Error("Test failed. GetAPIKeys() did not return rotated credentials")
+	}
+}
+
+func TestSetAPIKeysAuthenticatedSupport(t *testing.T) {
+	b := Base{Name: "TESTNAME", AuthenticatedAPISupport: true}
+
+	done := make(chan struct{})
+	go func() {
+		// An invalid base64 secret disables authenticated API support
+		b.SetAPIKeys("RocketMan", "%%%", "007", true)
+		close(done)
+	}()
+
+	for i := 0; i < 100; i++ {
+		b.GetAuthenticatedAPISupport()
+	}
+	<-done
+
+	if b.GetAuthenticatedAPISupport() {
+		t.Error("Test failed. SetAPIKeys() did not disable authenticated API support")
+	}
+}
+
 func TestSetCurrencies(t *testing.T) {
 	cfg := config.GetConfig()
 	err := cfg.LoadConfig(config.ConfigTestFile)
@@ -720,6 +983,44 @@ func TestSetCurrencies(t *testing.T) {
 	}
 }
 
+func TestGetRemovedPairs(t *testing.T) {
+	b := Base{
+		Name:           "RAWR",
+		AvailablePairs: []string{"BTC_USDT", "BTC_LTC", "BTC_ETH"},
+	}
+
+	removed := b.GetRemovedPairs([]string{"USDT_BTC", "btc_ltc", "BTC_ETH", "BTC_XMR"})
+	if len(removed) != 1 || removed[0] != "BTC_USDT" {
+		t.Errorf("Test failed. GetRemovedPairs() unexpected result %v", removed)
+	}
+
+	removed = b.GetRemovedPairs([]string{"BTC_USDT", "BTC_LTC", "BTC_ETH"})
+	if len(removed) != 0 {
+		t.Errorf("Test failed. GetRemovedPairs() unexpected result %v", removed)
+	}
+}
+
+func TestCheckEnabledPairs(t *testing.T) {
+	b := Base{
+		Name:         "RAWR",
+		EnabledPairs: []string{"BTC_LTC", "BTC_USDT"},
+	}
+
+	if err := b.CheckEnabledPairs([]string{"btc_ltc", "BTC_USDT", "BTC_ETH"}); err != nil {
+		t.Errorf("Test failed. CheckEnabledPairs() error %s", err)
+	}
+
+	err := b.CheckEnabledPairs([]string{"BTC_LTC", "BTC_ETH"})
+	unavailable, ok := err.(*UnavailablePairsError)
+	if !ok {
+		t.Fatalf("Test failed. CheckEnabledPairs() expected unavailable pairs error, received %v", err)
+	}
+
+	if len(unavailable.Pairs) != 1 || unavailable.Pairs[0] != "BTC_USDT" {
+		t.Errorf("Test failed. CheckEnabledPairs() unexpected pairs %v", unavailable.Pairs)
+	}
+}
+
 func TestUpdateCurrencies(t *testing.T) {
 	cfg := config.GetConfig()
 	err := cfg.LoadConfig(config.ConfigTestFile)
@@ -890,3 +1191,88 @@ func TestFormatWithdrawPermissions(t *testing.T) {
 	}
 
 }
+
+func TestGetBalanceChanges(t *testing.T) {
+	b := Base{Name: "RAWR"}
+
+	changes := b.GetBalanceChanges(AccountInfo{
+		Currencies: []AccountCurrencyInfo{
+			{CurrencyName: "BTC", TotalValue: 1},
+			{CurrencyName: "LTC", TotalValue: 0},
+		},
+	})
+	if len(changes) != 1 || changes[0].CurrencyName != "BTC" || changes[0].Delta != 1 {
+		t.Fatalf("Test failed. Unexpected balance changes %+v", changes)
+	}
+
+	changes = b.GetBalanceChanges(AccountInfo{
+		Currencies: []AccountCurrencyInfo{
+			{CurrencyName: "BTC", TotalValue: 1},
+			{CurrencyName: "ETH", TotalValue: 5},
+		},
+	})
+	if len(changes) != 1 || changes[0].CurrencyName != "ETH" || changes[0].Delta != 5 {
+		t.Fatalf("Test failed. Unexpected balance changes %+v", changes)
+	}
+
+	changes = b.GetBalanceChanges(AccountInfo{
+		Currencies: []AccountCurrencyInfo{
+			{CurrencyName: "BTC", TotalValue: 0.5},
+		},
+	})
+	if len(changes) != 2 {
+		t.Fatalf("Test failed. Unexpected balance changes %+v", changes)
+	}
+	if changes[0].CurrencyName != "BTC" || changes[0].Delta != -0.5 ||
+		changes[0].Current != 0.5 {
+		t.Errorf("Test failed. Unexpected BTC balance change %+v", changes[0])
+	}
+	if changes[1].CurrencyName != "ETH" || changes[1].Delta != -5 ||
+		changes[1].Current != 0 {
+		t.Errorf("Test failed. Unexpected ETH balance change %+v", changes[1])
+	}
+
+	changes = b.GetBalanceChanges(AccountInfo{
+		Currencies: []AccountCurrencyInfo{
+			{CurrencyName: "BTC", TotalValue: 0.5},
+		},
+	})
+	if len(changes) != 0 {
+		t.Errorf("Test failed. Unexpected balance changes %+v", changes)
+	}
+}
+
+func TestFeeBuilderValidate(t *testing.T) {
+	tests := []struct {
+		builder FeeBuilder
+		valid   bool
+	}{
+		{FeeBuilder{FeeType: CryptocurrencyTradeFee, PurchasePrice: 1, Amount: 1}, true},
+		{FeeBuilder{FeeType: CryptocurrencyTradeFee, Amount: 1}, false},
+		{FeeBuilder{FeeType: CryptocurrencyTradeFee, PurchasePrice: -1, Amount: 1}, false},
+		{FeeBuilder{FeeType: CryptocurrencyTradeFee, PurchasePrice: 1}, false},
+		{FeeBuilder{FeeType: CryptocurrencyWithdrawalFee, FirstCurrency: "BTC"}, true},
+		{FeeBuilder{FeeType: CryptocurrencyWithdrawalFee}, false},
+		{FeeBuilder{FeeType: CyptocurrencyDepositFee, FirstCurrency: "BTC"}, true},
+		{FeeBuilder{FeeType: CyptocurrencyDepositFee}, false},
+		{FeeBuilder{FeeType: BankFee, CurrencyItem: "USD"}, true},
+		{FeeBuilder{FeeType: BankFee}, false},
+		{FeeBuilder{FeeType: InternationalBankDepositFee, CurrencyItem: "USD"}, true},
+		{FeeBuilder{FeeType: InternationalBankDepositFee}, false},
+		{FeeBuilder{FeeType: InternationalBankWithdrawalFee, CurrencyItem: "USD"}, true},
+		{FeeBuilder{FeeType: InternationalBankWithdrawalFee}, false},
+		{FeeBuilder{}, false},
+	}
+
+	for x := range tests {
+		err := tests[x].builder.Validate()
+		if tests[x].valid && err != nil {
+			t.Errorf("Test failed. Validate() unexpected error for %+v: %s",
+				tests[x].builder, err)
+		}
+		if !tests[x].valid && err == nil {
+			t.Errorf("Test failed. Validate() expected error for %+v",
+				tests[x].builder)
+		}
+	}
+}
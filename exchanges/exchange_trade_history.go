@@ -0,0 +1,34 @@
+package exchange
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteTradeHistoryCSV writes the trades to w as CSV time,price,amount,side,id
+// rows. Timestamps are written in UTC using RFC3339
+func WriteTradeHistoryCSV(w io.Writer, trades []TradeHistory) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"time", "price", "amount", "side", "id"})
+	if err != nil {
+		return err
+	}
+
+	for _, trade := range trades {
+		err = writer.Write([]string{
+			time.Unix(trade.Timestamp, 0).UTC().Format(time.RFC3339),
+			strconv.FormatFloat(trade.Price, 'f', -1, 64),
+			strconv.FormatFloat(trade.Amount, 'f', -1, 64),
+			trade.Type,
+			strconv.FormatInt(trade.TID, 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
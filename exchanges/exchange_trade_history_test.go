@@ -0,0 +1,35 @@
+package exchange
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTradeHistoryCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteTradeHistoryCSV(&buf, nil)
+	if err != nil {
+		t.Fatal("Test failed. WriteTradeHistoryCSV() error", err)
+	}
+
+	if buf.String() != "time,price,amount,side,id\n" {
+		t.Errorf("Test failed. WriteTradeHistoryCSV() unexpected output %q", buf.String())
+	}
+
+	trades := []TradeHistory{
+		{Timestamp: 1522877119, TID: 42706057, Price: 0.05567134, Amount: 0.00181421, Type: "buy"},
+		{Timestamp: 1522877120, TID: 42706058, Price: 0.0556, Amount: 2, Type: "sell"},
+	}
+	buf.Reset()
+	err = WriteTradeHistoryCSV(&buf, trades)
+	if err != nil {
+		t.Fatal("Test failed. WriteTradeHistoryCSV() error", err)
+	}
+
+	expected := "time,price,amount,side,id\n" +
+		"2018-04-04T21:25:19Z,0.05567134,0.00181421,buy,42706057\n" +
+		"2018-04-04T21:25:20Z,0.0556,2,sell,42706058\n"
+	if buf.String() != expected {
+		t.Errorf("Test failed. WriteTradeHistoryCSV() unexpected output %q", buf.String())
+	}
+}
@@ -3,7 +3,9 @@ package exchange
 import (
 	"errors"
 	"fmt"
+	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/config"
@@ -14,14 +16,27 @@ import (
 const (
 	// WebsocketNotEnabled alerts of a disabled websocket
 	WebsocketNotEnabled = "exchange_websocket_not_enabled"
+	// WebsocketNotSupported alerts of an exchange which does not offer a
+	// websocket API
+	WebsocketNotSupported = "exchange_websocket_not_supported"
 	// WebsocketTrafficLimitTime defines a standard time for no traffic from the
 	// websocket connection
 	WebsocketTrafficLimitTime = 5 * time.Second
 	// WebsocketStateTimeout defines a const for when a websocket connection
 	// times out, will be handled by the routine management system
 	WebsocketStateTimeout = "TIMEOUT"
+	// WebsocketStateDisconnected defines a const for when a websocket
+	// connection has dropped and is about to be re-established
+	WebsocketStateDisconnected = "DISCONNECTED"
+	// WebsocketStateReconnecting defines a const for each attempt to
+	// re-establish a dropped websocket connection
+	WebsocketStateReconnecting = "RECONNECTING"
+	// WebsocketStateConnected defines a const for when a dropped websocket
+	// connection has been re-established and subscriptions replayed
+	WebsocketStateConnected = "CONNECTED"
 
 	websocketRestablishConnection = 1 * time.Second
+	websocketMaxReconnectDelay    = time.Minute
 )
 
 // WebsocketInit initialises the websocket struct
@@ -72,10 +87,29 @@ type Websocket struct {
 	exchangeName string
 	enabled      bool
 	init         bool
-	connected    bool
+	connected    int32
 	connector    func() error
+	reconnecting bool
 	m            sync.Mutex
 
+	subscriptions    []WebsocketSubscription
+	subscriptionsMtx sync.Mutex
+
+	// reconnectDelay and maxReconnectDelay bound the reconnection backoff,
+	// zero values use the package defaults
+	reconnectDelay    time.Duration
+	maxReconnectDelay time.Duration
+
+	heartbeatInterval time.Duration
+	heartbeatTimeout  time.Duration
+	ping              func() error
+	interrupt         func() error
+	lastActivity      int64
+
+	tradeFeed     chan TradeData
+	tradeFeedMtx  sync.Mutex
+	droppedTrades uint64
+
 	// Connected denotes a channel switch for diversion of request flow
 	Connected chan struct{}
 
@@ -104,12 +138,12 @@ type Websocket struct {
 }
 
 // trafficMonitor monitors traffic and switches connection modes for websocket
-func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
+func (w *Websocket) trafficMonitor(wg *sync.WaitGroup, shutdown chan struct{}) {
 	w.Wg.Add(1)
 	wg.Done() // Makes sure we are unlocking after we add to waitgroup
 
 	defer func() {
-		if w.connected {
+		if w.isConnected() {
 			w.Disconnected <- struct{}{}
 		}
 		w.Wg.Done()
@@ -121,27 +155,28 @@ func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
 
 	for {
 		select {
-		case <-w.ShutdownC: // Returns on shutdown channel close
+		case <-shutdown: // Returns on shutdown channel close
 			return
 
 		case <-w.TrafficAlert: // Resets timer on traffic
-			if !w.connected {
+			w.updateLastActivity()
+			if !w.isConnected() {
 				w.Connected <- struct{}{}
-				w.connected = true
+				w.setConnected(true)
 			}
 
 			trafficTimer.Reset(WebsocketTrafficLimitTime)
 
 		case <-trafficTimer.C: // Falls through when timer runs out
 			newtimer := time.NewTimer(10 * time.Second) // New secondary timer set
-			if w.connected {
+			if w.isConnected() {
 				// If connected divert traffic to rest
 				w.Disconnected <- struct{}{}
-				w.connected = false
+				w.setConnected(false)
 			}
 
 			select {
-			case <-w.ShutdownC: // Returns on shutdown channel close
+			case <-shutdown: // Returns on shutdown channel close
 				return
 
 			case <-newtimer.C: // If secondary timer runs state timeout is sent to the data handler
@@ -149,11 +184,12 @@ func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
 				return
 
 			case <-w.TrafficAlert: // If in this time response traffic comes through
+				w.updateLastActivity()
 				trafficTimer.Reset(WebsocketTrafficLimitTime)
-				if !w.connected {
+				if !w.isConnected() {
 					// If not connected divert traffic from REST to websocket
 					w.Connected <- struct{}{}
-					w.connected = true
+					w.setConnected(true)
 				}
 			}
 		}
@@ -171,7 +207,7 @@ func (w *Websocket) Connect() error {
 			w.GetName())
 	}
 
-	if w.connected {
+	if w.isConnected() {
 		return errors.New("exchange_websocket.go error - already connected, cannot connect again")
 	}
 
@@ -179,18 +215,29 @@ func (w *Websocket) Connect() error {
 
 	var anotherWG sync.WaitGroup
 	anotherWG.Add(1)
-	go w.trafficMonitor(&anotherWG)
+	go w.trafficMonitor(&anotherWG, w.ShutdownC)
 	anotherWG.Wait()
 
 	err := w.connector()
+	if err == nil {
+		err = w.resubscribe()
+	}
+
 	if err != nil {
+		// Stop the traffic monitor and any connection routines so a later
+		// connect does not leak them or race with them
+		if stopErr := w.stopRoutines(); stopErr != nil {
+			log.Println(stopErr)
+		}
 		return fmt.Errorf("exchange_websocket.go connection error %s",
 			err)
 	}
 
+	w.startHeartbeat()
+
 	// Divert for incoming websocket traffic
 	w.Connected <- struct{}{}
-	w.connected = true
+	w.setConnected(true)
 
 	return nil
 }
@@ -205,22 +252,36 @@ func (w *Websocket) Shutdown() error {
 		w.m.Unlock()
 	}()
 
-	if !w.connected {
+	if !w.isConnected() {
 		return errors.New("exchange_websocket.go error - System not connected to shut down")
 	}
 
+	err := w.stopRoutines()
+	if err != nil {
+		return err
+	}
+
+	w.setConnected(false)
+	return nil
+}
+
+// stopRoutines closes the shutdown channel and waits for the websocket
+// routines to return
+func (w *Websocket) stopRoutines() error {
 	timer := time.NewTimer(5 * time.Second)
 	c := make(chan struct{}, 1)
 
 	go func(c chan struct{}) {
 		close(w.ShutdownC)
+		if w.interrupt != nil {
+			w.interrupt()
+		}
 		w.Wg.Wait()
 		c <- struct{}{}
 	}(c)
 
 	select {
 	case <-c:
-		w.connected = false
 		return nil
 	case <-timer.C:
 		return fmt.Errorf("%s - Websocket routines failed to shutdown",
@@ -256,13 +317,13 @@ func (w *Websocket) SetEnabled(enabled bool) error {
 
 	if !w.init {
 		if enabled {
-			if w.connected {
+			if w.isConnected() {
 				return nil
 			}
 			return w.Connect()
 		}
 
-		if !w.connected {
+		if !w.isConnected() {
 			return nil
 		}
 		return w.Shutdown()
@@ -275,6 +336,28 @@ func (w *Websocket) IsEnabled() bool {
 	return w.enabled
 }
 
+// IsConnected returns whether the websocket is connected
+func (w *Websocket) IsConnected() bool {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.isConnected()
+}
+
+// isConnected returns the connection state, which is shared with the traffic
+// monitor routine
+func (w *Websocket) isConnected() bool {
+	return atomic.LoadInt32(&w.connected) == 1
+}
+
+// setConnected sets the connection state
+func (w *Websocket) setConnected(connected bool) {
+	var state int32
+	if connected {
+		state = 1
+	}
+	atomic.StoreInt32(&w.connected, state)
+}
+
 // SetProxyAddress sets websocket proxy address
 func (w *Websocket) SetProxyAddress(URL string) error {
 	if w.proxyAddr == URL {
@@ -284,7 +367,7 @@ func (w *Websocket) SetProxyAddress(URL string) error {
 	w.proxyAddr = URL
 
 	if !w.init && w.enabled {
-		if w.connected {
+		if w.isConnected() {
 			err := w.Shutdown()
 			if err != nil {
 				return err
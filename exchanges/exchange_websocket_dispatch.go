@@ -0,0 +1,113 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// WebsocketHandler handles a raw websocket message for a subscribed channel
+type WebsocketHandler func(raw []byte) error
+
+// WebsocketReader reads messages from a websocket connection, it is satisfied
+// by *websocket.Conn
+type WebsocketReader interface {
+	ReadMessage() (messageType int, p []byte, err error)
+}
+
+// WebsocketDispatcher routes incoming websocket messages to the handler
+// subscribed to the message channel, so exchange packages only register a
+// handler per subscription instead of switching on every message
+type WebsocketDispatcher struct {
+	channel  func(raw []byte) (string, error)
+	handlers map[string]WebsocketHandler
+	m        sync.RWMutex
+}
+
+// NewWebsocketDispatcher returns a dispatcher which uses the exchange defined
+// channel function to extract the channel ID or topic from a raw message
+func NewWebsocketDispatcher(channel func(raw []byte) (string, error)) *WebsocketDispatcher {
+	return &WebsocketDispatcher{
+		channel:  channel,
+		handlers: make(map[string]WebsocketHandler),
+	}
+}
+
+// Subscribe registers the handler for messages on the channel, replacing any
+// handler already registered for it
+func (d *WebsocketDispatcher) Subscribe(channel string, handler WebsocketHandler) error {
+	if channel == "" {
+		return errors.New("exchange_websocket_dispatch.go error - channel cannot be empty")
+	}
+
+	if handler == nil {
+		return fmt.Errorf("exchange_websocket_dispatch.go error - nil handler for channel %s",
+			channel)
+	}
+
+	d.m.Lock()
+	d.handlers[channel] = handler
+	d.m.Unlock()
+	return nil
+}
+
+// Unsubscribe removes the handler for the channel
+func (d *WebsocketDispatcher) Unsubscribe(channel string) {
+	d.m.Lock()
+	delete(d.handlers, channel)
+	d.m.Unlock()
+}
+
+// Dispatch passes a raw message to the handler subscribed to its channel
+func (d *WebsocketDispatcher) Dispatch(raw []byte) error {
+	channel, err := d.channel(raw)
+	if err != nil {
+		return err
+	}
+
+	d.m.RLock()
+	handler, ok := d.handlers[channel]
+	d.m.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("exchange_websocket_dispatch.go error - no handler subscribed to channel %s",
+			channel)
+	}
+	return handler(raw)
+}
+
+// Run reads messages from the connection and dispatches them until the
+// websocket is shut down or a read fails. Handler errors are sent to the
+// websocket data handler and a failed read reconnects the websocket
+func (d *WebsocketDispatcher) Run(w *Websocket, conn WebsocketReader) {
+	w.Wg.Add(1)
+	defer w.Wg.Done()
+
+	for {
+		select {
+		case <-w.ShutdownC:
+			return
+
+		default:
+			_, resp, err := conn.ReadMessage()
+			if err != nil {
+				select {
+				case <-w.ShutdownC:
+				default:
+					log.Printf("%s websocket read error, reconnecting. Error: %s",
+						w.GetName(), err)
+					w.reconnectInBackground()
+				}
+				return
+			}
+
+			w.TrafficAlert <- struct{}{}
+
+			err = d.Dispatch(resp)
+			if err != nil {
+				w.DataHandler <- err
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package exchange
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testWebsocketReader struct {
+	messages []string
+}
+
+func (r *testWebsocketReader) ReadMessage() (int, []byte, error) {
+	if len(r.messages) == 0 {
+		return 0, nil, errors.New("connection closed")
+	}
+	msg := r.messages[0]
+	r.messages = r.messages[1:]
+	return 1, []byte(msg), nil
+}
+
+func testWebsocketChannel(raw []byte) (string, error) {
+	s := strings.SplitN(string(raw), ":", 2)
+	if len(s) != 2 {
+		return "", errors.New("invalid message")
+	}
+	return s[0], nil
+}
+
+func TestWebsocketDispatcher(t *testing.T) {
+	d := NewWebsocketDispatcher(testWebsocketChannel)
+
+	if err := d.Subscribe("", func([]byte) error { return nil }); err == nil {
+		t.Error("test failed - Subscribe() accepted an empty channel")
+	}
+
+	if err := d.Subscribe("ticker", nil); err == nil {
+		t.Error("test failed - Subscribe() accepted a nil handler")
+	}
+
+	var tickers []string
+	err := d.Subscribe("ticker", func(raw []byte) error {
+		tickers = append(tickers, string(raw))
+		return nil
+	})
+	if err != nil {
+		t.Fatal("test failed - Subscribe()", err)
+	}
+
+	if err = d.Dispatch([]byte("ticker:BTC_ETH")); err != nil {
+		t.Error("test failed - Dispatch()", err)
+	}
+
+	if len(tickers) != 1 || tickers[0] != "ticker:BTC_ETH" {
+		t.Errorf("test failed - Dispatch() unexpected values %v", tickers)
+	}
+
+	if err = d.Dispatch([]byte("trades:BTC_ETH")); err == nil {
+		t.Error("test failed - Dispatch() unsubscribed channel should error")
+	}
+
+	if err = d.Dispatch([]byte("ticker")); err == nil {
+		t.Error("test failed - Dispatch() invalid message should error")
+	}
+
+	d.Unsubscribe("ticker")
+	if err = d.Dispatch([]byte("ticker:BTC_ETH")); err == nil || len(tickers) != 1 {
+		t.Error("test failed - Unsubscribe() handler still subscribed")
+	}
+}
+
+func TestWebsocketDispatcherRun(t *testing.T) {
+	w := &Websocket{
+		ShutdownC:    make(chan struct{}),
+		DataHandler:  make(chan interface{}, 3),
+		TrafficAlert: make(chan struct{}, 3),
+	}
+
+	d := NewWebsocketDispatcher(testWebsocketChannel)
+	handlerErr := errors.New("handler error")
+	var trades int
+	d.Subscribe("trades", func(raw []byte) error {
+		trades++
+		if trades == 2 {
+			return handlerErr
+		}
+		return nil
+	})
+
+	d.Run(w, &testWebsocketReader{
+		messages: []string{"trades:1", "trades:2"},
+	})
+
+	if trades != 2 || len(w.TrafficAlert) != 2 {
+		t.Errorf("test failed - Run() unexpected values %d %d",
+			trades, len(w.TrafficAlert))
+	}
+
+	if <-w.DataHandler != handlerErr {
+		t.Error("test failed - Run() handler error not sent to data handler")
+	}
+
+	select {
+	case data := <-w.DataHandler:
+		state, ok := data.(WebsocketConnectionState)
+		if !ok || state.State != WebsocketStateDisconnected {
+			t.Errorf("test failed - Run() unexpected values %v", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("test failed - Run() read error did not reconnect")
+	}
+
+	close(w.ShutdownC)
+	d.Run(w, &testWebsocketReader{messages: []string{"trades:3"}})
+	if trades != 2 {
+		t.Error("test failed - Run() read after shutdown")
+	}
+}
@@ -0,0 +1,92 @@
+package exchange
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// SetHeartbeat pings the connection every interval and reconnects the
+// websocket once no traffic has been received for the timeout, catching
+// connections which are open but no longer deliver data. Traffic is counted
+// from TrafficAlert, so pong handlers should send to it as well. ping may be
+// nil for exchanges which send their own heartbeats. interrupt is called on
+// shutdown to unblock routines reading a dead connection. A zero interval
+// disables the heartbeat and a zero timeout defaults to twice the interval.
+// Changes apply from the next connection
+func (w *Websocket) SetHeartbeat(interval, timeout time.Duration, ping, interrupt func() error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if timeout <= 0 {
+		timeout = 2 * interval
+	}
+
+	w.heartbeatInterval = interval
+	w.heartbeatTimeout = timeout
+	w.ping = ping
+	w.interrupt = interrupt
+}
+
+// GetHeartbeat returns the heartbeat interval and timeout
+func (w *Websocket) GetHeartbeat() (interval, timeout time.Duration) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.heartbeatInterval, w.heartbeatTimeout
+}
+
+// updateLastActivity records that traffic has been received
+func (w *Websocket) updateLastActivity() {
+	atomic.StoreInt64(&w.lastActivity, time.Now().UnixNano())
+}
+
+// getLastActivity returns when traffic was last received
+func (w *Websocket) getLastActivity() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&w.lastActivity))
+}
+
+// startHeartbeat starts the heartbeat routine if enabled, it must be called
+// with the websocket lock held
+func (w *Websocket) startHeartbeat() {
+	if w.heartbeatInterval <= 0 {
+		return
+	}
+
+	w.updateLastActivity()
+	w.Wg.Add(1)
+	go w.heartbeat(w.heartbeatInterval, w.heartbeatTimeout, w.ping, w.ShutdownC)
+}
+
+// heartbeat pings the connection and reconnects once traffic stops
+func (w *Websocket) heartbeat(interval, timeout time.Duration, ping func() error, shutdown chan struct{}) {
+	defer w.Wg.Done()
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-shutdown:
+			return
+
+		case <-t.C:
+			if idle := time.Since(w.getLastActivity()); idle > timeout {
+				log.Printf("%s websocket received no traffic for %s, reconnecting",
+					w.GetName(), idle.Truncate(time.Millisecond))
+				w.reconnectInBackground()
+				return
+			}
+
+			if ping == nil {
+				continue
+			}
+
+			if err := ping(); err != nil {
+				log.Printf("%s websocket ping failed, reconnecting. Error: %s",
+					w.GetName(), err)
+				w.reconnectInBackground()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetHeartbeat(t *testing.T) {
+	var b Base
+	b.WebsocketInit()
+
+	b.Websocket.SetHeartbeat(time.Second, 0, nil, nil)
+	if interval, timeout := b.Websocket.GetHeartbeat(); interval != time.Second || timeout != 2*time.Second {
+		t.Errorf("test failed - SetHeartbeat() unexpected values %s %s", interval, timeout)
+	}
+
+	b.Websocket.SetHeartbeat(time.Second, 5*time.Second, nil, nil)
+	if _, timeout := b.Websocket.GetHeartbeat(); timeout != 5*time.Second {
+		t.Errorf("test failed - SetHeartbeat() unexpected timeout %s", timeout)
+	}
+}
+
+func TestHeartbeatTimeout(t *testing.T) {
+	w := &Websocket{
+		exchangeName: "testName",
+		DataHandler:  make(chan interface{}, 1),
+	}
+
+	shutdown := make(chan struct{})
+	done := make(chan struct{})
+	w.updateLastActivity()
+	w.Wg.Add(1)
+	go func() {
+		w.heartbeat(5*time.Millisecond, 50*time.Millisecond, nil, shutdown)
+		close(done)
+	}()
+
+	for x := 0; x < 10; x++ {
+		time.Sleep(10 * time.Millisecond)
+		w.updateLastActivity()
+	}
+
+	select {
+	case <-done:
+		t.Fatal("test failed - heartbeat() timed out with traffic")
+	default:
+	}
+
+	select {
+	case data := <-w.DataHandler:
+		state, ok := data.(WebsocketConnectionState)
+		if !ok || state.State != WebsocketStateDisconnected {
+			t.Errorf("test failed - heartbeat() unexpected values %v", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("test failed - heartbeat() did not reconnect without traffic")
+	}
+	<-done
+}
+
+func TestHeartbeatPing(t *testing.T) {
+	var b Base
+	b.WebsocketInit()
+
+	var connects, pings, interrupts int
+	b.WebsocketSetup(func() error {
+		connects++
+		return nil
+	}, "testName", true, "testDefaultURL", "testRunningURL")
+	b.Websocket.reconnectDelay = 10 * time.Millisecond
+	b.Websocket.SetHeartbeat(10*time.Millisecond, time.Minute, func() error {
+		pings++
+		if pings == 1 {
+			return errors.New("broken pipe")
+		}
+		return nil
+	}, func() error {
+		interrupts++
+		return nil
+	})
+
+	done := make(chan struct{})
+	defer close(done)
+	connected := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-b.Websocket.Connected:
+			case <-b.Websocket.Disconnected:
+			case data := <-b.Websocket.DataHandler:
+				if state, ok := data.(WebsocketConnectionState); ok &&
+					state.State == WebsocketStateConnected {
+					connected <- struct{}{}
+				}
+			}
+		}
+	}()
+
+	err := b.Websocket.Connect()
+	if err != nil {
+		t.Fatal("test failed - Connect()", err)
+	}
+
+	select {
+	case <-connected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("test failed - heartbeat() failed ping did not reconnect")
+	}
+
+	err = b.Websocket.SetEnabled(false)
+	if err != nil {
+		t.Fatal("test failed - SetEnabled()", err)
+	}
+
+	if connects != 2 || interrupts != 2 || pings < 1 {
+		t.Errorf("test failed - heartbeat() unexpected values %d %d %d",
+			connects, interrupts, pings)
+	}
+}
@@ -0,0 +1,192 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// WebsocketSubscription defines a channel subscription which is replayed each
+// time the websocket connects
+type WebsocketSubscription struct {
+	Channel   string
+	Subscribe func() error
+}
+
+// WebsocketConnectionState is sent to the data handler when a dropped
+// websocket connection is being re-established, so strategies can pause
+// until the state is WebsocketStateConnected
+type WebsocketConnectionState struct {
+	Exchange  string
+	State     string
+	Attempt   int
+	Timestamp time.Time
+}
+
+// AddSubscription subscribes to the channel if the websocket is connected and
+// tracks the subscription so it is replayed on every connect. An existing
+// subscription to the channel is replaced. Subscriptions tracked here should
+// not also be sent by the connector function
+func (w *Websocket) AddSubscription(channel string, subscribe func() error) error {
+	if subscribe == nil {
+		return fmt.Errorf("exchange_websocket.go error - nil subscribe function for channel %s",
+			channel)
+	}
+
+	if w.IsConnected() {
+		err := subscribe()
+		if err != nil {
+			return err
+		}
+	}
+
+	w.subscriptionsMtx.Lock()
+	defer w.subscriptionsMtx.Unlock()
+
+	for i := range w.subscriptions {
+		if w.subscriptions[i].Channel == channel {
+			w.subscriptions[i].Subscribe = subscribe
+			return nil
+		}
+	}
+
+	w.subscriptions = append(w.subscriptions, WebsocketSubscription{
+		Channel:   channel,
+		Subscribe: subscribe,
+	})
+	return nil
+}
+
+// RemoveSubscription stops tracking the channel subscription
+func (w *Websocket) RemoveSubscription(channel string) {
+	w.subscriptionsMtx.Lock()
+	defer w.subscriptionsMtx.Unlock()
+
+	for i := range w.subscriptions {
+		if w.subscriptions[i].Channel == channel {
+			w.subscriptions = append(w.subscriptions[:i], w.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetSubscriptions returns the tracked subscription channels
+func (w *Websocket) GetSubscriptions() []string {
+	w.subscriptionsMtx.Lock()
+	defer w.subscriptionsMtx.Unlock()
+
+	var channels []string
+	for i := range w.subscriptions {
+		channels = append(channels, w.subscriptions[i].Channel)
+	}
+	return channels
+}
+
+// resubscribe replays all tracked subscriptions, subscribe functions are
+// called with the websocket lock held during Connect
+func (w *Websocket) resubscribe() error {
+	w.subscriptionsMtx.Lock()
+	subs := make([]WebsocketSubscription, len(w.subscriptions))
+	copy(subs, w.subscriptions)
+	w.subscriptionsMtx.Unlock()
+
+	for i := range subs {
+		err := subs[i].Subscribe()
+		if err != nil {
+			return fmt.Errorf("%s unable to resubscribe to channel %s: %s",
+				w.GetName(), subs[i].Channel, err)
+		}
+	}
+	return nil
+}
+
+// sendConnectionState sends a connection state event to the data handler
+func (w *Websocket) sendConnectionState(state string, attempt int) {
+	w.DataHandler <- WebsocketConnectionState{
+		Exchange:  w.GetName(),
+		State:     state,
+		Attempt:   attempt,
+		Timestamp: time.Now(),
+	}
+}
+
+// Reconnect shuts down a dropped websocket connection and reconnects with
+// exponential backoff, Connect replays all tracked subscriptions.
+// Connection state events are sent to the data handler throughout. It returns
+// once reconnected, or with an error if the websocket is disabled, the stop
+// channel is closed or a reconnection is already in progress
+func (w *Websocket) Reconnect(stop <-chan struct{}) error {
+	w.m.Lock()
+	if w.reconnecting {
+		w.m.Unlock()
+		return errors.New("exchange_websocket.go error - reconnection already in progress")
+	}
+	w.reconnecting = true
+	delay, maxDelay := w.reconnectDelay, w.maxReconnectDelay
+	w.m.Unlock()
+
+	defer func() {
+		w.m.Lock()
+		w.reconnecting = false
+		w.m.Unlock()
+	}()
+
+	if delay <= 0 {
+		delay = websocketRestablishConnection
+	}
+
+	if maxDelay <= 0 {
+		maxDelay = websocketMaxReconnectDelay
+	}
+
+	w.sendConnectionState(WebsocketStateDisconnected, 0)
+
+	if w.IsConnected() {
+		err := w.Shutdown()
+		if err != nil {
+			return err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		if !w.IsEnabled() {
+			return fmt.Errorf("exchange_websocket.go %s error - websocket disabled, reconnection stopped",
+				w.GetName())
+		}
+
+		w.sendConnectionState(WebsocketStateReconnecting, attempt)
+
+		err := w.Connect()
+		if err == nil {
+			w.sendConnectionState(WebsocketStateConnected, attempt)
+			return nil
+		}
+
+		log.Printf("%s websocket reconnection attempt %d failed, retrying in %s. Error: %s",
+			w.GetName(), attempt, delay, err)
+
+		select {
+		case <-stop:
+			return fmt.Errorf("exchange_websocket.go %s error - reconnection stopped",
+				w.GetName())
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+// reconnectInBackground reconnects the websocket without blocking the calling
+// routine, which must return so the connection can be shut down
+func (w *Websocket) reconnectInBackground() {
+	go func() {
+		if err := w.Reconnect(nil); err != nil {
+			log.Printf("%s websocket reconnection failed. Error: %s",
+				w.GetName(), err)
+		}
+	}()
+}
@@ -0,0 +1,130 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWebsocketSubscriptions(t *testing.T) {
+	var b Base
+	b.WebsocketInit()
+
+	if err := b.Websocket.AddSubscription("ticker", nil); err == nil {
+		t.Error("test failed - AddSubscription() accepted a nil subscribe function")
+	}
+
+	var calls int
+	subscribe := func() error {
+		calls++
+		return nil
+	}
+
+	b.Websocket.AddSubscription("ticker", subscribe)
+	b.Websocket.AddSubscription("trades", subscribe)
+	b.Websocket.AddSubscription("ticker", subscribe)
+	if calls != 0 {
+		t.Error("test failed - AddSubscription() subscribed while not connected")
+	}
+
+	subs := b.Websocket.GetSubscriptions()
+	if len(subs) != 2 || subs[0] != "ticker" || subs[1] != "trades" {
+		t.Errorf("test failed - GetSubscriptions() unexpected values %v", subs)
+	}
+
+	b.Websocket.RemoveSubscription("ticker")
+	subs = b.Websocket.GetSubscriptions()
+	if len(subs) != 1 || subs[0] != "trades" {
+		t.Errorf("test failed - RemoveSubscription() unexpected values %v", subs)
+	}
+
+	if err := b.Websocket.resubscribe(); err != nil || calls != 1 {
+		t.Error("test failed - resubscribe()", err)
+	}
+}
+
+func TestWebsocketReconnect(t *testing.T) {
+	var b Base
+	b.WebsocketInit()
+
+	var connects int
+	b.WebsocketSetup(func() error {
+		connects++
+		if connects == 2 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}, "testName", true, "testDefaultURL", "testRunningURL")
+	b.Websocket.reconnectDelay = 10 * time.Millisecond
+
+	done := make(chan struct{})
+	defer close(done)
+	states := make(chan WebsocketConnectionState, 10)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-b.Websocket.Connected:
+			case <-b.Websocket.Disconnected:
+			case data := <-b.Websocket.DataHandler:
+				if state, ok := data.(WebsocketConnectionState); ok {
+					states <- state
+				}
+			}
+		}
+	}()
+
+	err := b.Websocket.Connect()
+	if err != nil {
+		t.Fatal("test failed - Connect()", err)
+	}
+
+	var subscribes int
+	err = b.Websocket.AddSubscription("ticker", func() error {
+		subscribes++
+		return nil
+	})
+	if err != nil || subscribes != 1 {
+		t.Fatal("test failed - AddSubscription()", err)
+	}
+
+	b.Websocket.reconnecting = true
+	if err = b.Websocket.Reconnect(nil); err == nil {
+		t.Error("test failed - Reconnect() should not run twice")
+	}
+	b.Websocket.reconnecting = false
+
+	err = b.Websocket.Reconnect(nil)
+	if err != nil {
+		t.Fatal("test failed - Reconnect()", err)
+	}
+
+	if !b.Websocket.IsConnected() || connects != 3 || subscribes != 2 {
+		t.Errorf("test failed - Reconnect() unexpected values %v %d %d",
+			b.Websocket.IsConnected(), connects, subscribes)
+	}
+
+	expected := []WebsocketConnectionState{
+		{State: WebsocketStateDisconnected},
+		{State: WebsocketStateReconnecting, Attempt: 1},
+		{State: WebsocketStateReconnecting, Attempt: 2},
+		{State: WebsocketStateConnected, Attempt: 2},
+	}
+	for i := range expected {
+		state := <-states
+		if state.State != expected[i].State || state.Attempt != expected[i].Attempt ||
+			state.Exchange != "testName" {
+			t.Errorf("test failed - Reconnect() unexpected state %v", state)
+		}
+	}
+
+	err = b.Websocket.SetEnabled(false)
+	if err != nil {
+		t.Fatal("test failed - SetEnabled()", err)
+	}
+
+	if err = b.Websocket.Reconnect(nil); err == nil {
+		t.Error("test failed - Reconnect() should stop when disabled")
+	}
+}
@@ -0,0 +1,41 @@
+package exchange
+
+import (
+	"errors"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// WebsocketChannel defines a websocket stream which can be subscribed to per
+// currency pair
+type WebsocketChannel string
+
+// Websocket channels supported by Subscribe and Unsubscribe
+const (
+	WebsocketTickerChannel    WebsocketChannel = "ticker"
+	WebsocketOrderbookChannel WebsocketChannel = "orderbook"
+	WebsocketTradesChannel    WebsocketChannel = "trades"
+)
+
+// ErrWebsocketChannelNotSupported is returned when subscribing to a websocket
+// channel the exchange does not offer
+var ErrWebsocketChannelNotSupported = errors.New("websocket channel not supported")
+
+// String returns the websocket channel name
+func (c WebsocketChannel) String() string {
+	return string(c)
+}
+
+// Subscribe subscribes to the websocket channel for the currency pair.
+// Exchanges with websocket support override this, by default no channels are
+// supported
+func (e *Base) Subscribe(p pair.CurrencyPair, channel WebsocketChannel, assetType string) error {
+	return ErrWebsocketChannelNotSupported
+}
+
+// Unsubscribe unsubscribes from the websocket channel for the currency pair.
+// Exchanges with websocket support override this, by default no channels are
+// supported
+func (e *Base) Unsubscribe(p pair.CurrencyPair, channel WebsocketChannel, assetType string) error {
+	return ErrWebsocketChannelNotSupported
+}
@@ -0,0 +1,20 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+func TestBaseSubscribe(t *testing.T) {
+	var b Base
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	if err := b.Subscribe(p, WebsocketTickerChannel, "SPOT"); err != ErrWebsocketChannelNotSupported {
+		t.Error("Test failed. Subscribe() unexpected error", err)
+	}
+
+	if err := b.Unsubscribe(p, WebsocketTickerChannel, "SPOT"); err != ErrWebsocketChannelNotSupported {
+		t.Error("Test failed. Unsubscribe() unexpected error", err)
+	}
+}
@@ -0,0 +1,47 @@
+package exchange
+
+import "sync/atomic"
+
+// WebsocketTradeFeedBuffer is the number of trades buffered for a consumer of
+// the trade feed before further trades are dropped
+const WebsocketTradeFeedBuffer = 1000
+
+// TradeFeed returns a channel of normalised trades received from the
+// websocket stream. Trades are only delivered once the feed has been
+// requested. Publishing never blocks the websocket routines, so when a slow
+// consumer lets the buffer fill the newest trades are dropped and counted by
+// DroppedTrades until there is room again
+func (w *Websocket) TradeFeed() <-chan TradeData {
+	w.tradeFeedMtx.Lock()
+	defer w.tradeFeedMtx.Unlock()
+
+	if w.tradeFeed == nil {
+		w.tradeFeed = make(chan TradeData, WebsocketTradeFeedBuffer)
+	}
+	return w.tradeFeed
+}
+
+// PublishTrade delivers a trade to the trade feed without blocking, it
+// returns false if the trade was dropped because the buffer is full
+func (w *Websocket) PublishTrade(trade TradeData) bool {
+	w.tradeFeedMtx.Lock()
+	feed := w.tradeFeed
+	w.tradeFeedMtx.Unlock()
+
+	if feed == nil {
+		return true
+	}
+
+	select {
+	case feed <- trade:
+		return true
+	default:
+		atomic.AddUint64(&w.droppedTrades, 1)
+		return false
+	}
+}
+
+// DroppedTrades returns the number of trades dropped from the trade feed
+func (w *Websocket) DroppedTrades() uint64 {
+	return atomic.LoadUint64(&w.droppedTrades)
+}
@@ -0,0 +1,47 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+func TestTradeFeed(t *testing.T) {
+	var b Base
+	b.WebsocketInit()
+
+	trade := TradeData{
+		CurrencyPair: pair.NewCurrencyPair("BTC", "ETH"),
+		Price:        0.03,
+		Amount:       1,
+		Side:         "BUY",
+	}
+
+	if !b.Websocket.PublishTrade(trade) || b.Websocket.DroppedTrades() != 0 {
+		t.Error("test failed - PublishTrade() without a feed should not drop trades")
+	}
+
+	feed := b.Websocket.TradeFeed()
+	if feed != b.Websocket.TradeFeed() {
+		t.Error("test failed - TradeFeed() should return the same channel")
+	}
+
+	for x := 0; x < WebsocketTradeFeedBuffer; x++ {
+		trade.Amount = float64(x)
+		if !b.Websocket.PublishTrade(trade) {
+			t.Fatalf("test failed - PublishTrade() dropped trade %d", x)
+		}
+	}
+
+	if b.Websocket.PublishTrade(trade) || b.Websocket.DroppedTrades() != 1 {
+		t.Error("test failed - PublishTrade() should drop trades once the buffer is full")
+	}
+
+	if first := <-feed; first.Amount != 0 || first.Side != "BUY" {
+		t.Errorf("test failed - TradeFeed() unexpected trade %v", first)
+	}
+
+	if !b.Websocket.PublishTrade(trade) {
+		t.Error("test failed - PublishTrade() should deliver once there is room")
+	}
+}
@@ -0,0 +1,145 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// WithdrawType is the kind of withdrawal carried by a WithdrawalRequest
+type WithdrawType string
+
+// Const declarations for withdrawal types
+const (
+	CryptoWithdrawal            WithdrawType = "crypto"
+	FiatWithdrawal              WithdrawType = "fiat"
+	InternationalBankWithdrawal WithdrawType = "internationalBank"
+)
+
+// ErrWithdrawTypeNotSupported is returned when an exchange does not support
+// the requested withdrawal type
+var ErrWithdrawTypeNotSupported = errors.New("withdrawal type not supported")
+
+// addressTagCurrencies are currencies whose deposit addresses are shared
+// between accounts and require a memo, tag or payment ID to credit the
+// recipient. Withdrawals of these currencies without one can lose funds. XMR
+// is not included, integrated addresses and subaddresses identify the
+// recipient without a payment ID
+var addressTagCurrencies = map[pair.CurrencyItem]bool{
+	"ATOM":  true,
+	"BNB":   true,
+	"BTS":   true,
+	"EOS":   true,
+	"STEEM": true,
+	"STR":   true,
+	"XEM":   true,
+	"XLM":   true,
+	"XRP":   true,
+}
+
+// RequiresAddressTag returns whether withdrawals of the currency require a
+// memo, tag or payment ID
+func RequiresAddressTag(currency pair.CurrencyItem) bool {
+	return addressTagCurrencies[currency.Upper()]
+}
+
+// CheckAddressTag returns an error if the currency requires a memo, tag or
+// payment ID and none was supplied
+func CheckAddressTag(currency pair.CurrencyItem, addressTag string) error {
+	if addressTag == "" && RequiresAddressTag(currency) {
+		return fmt.Errorf("%s withdrawals require a memo, tag or payment ID, withdrawing without one may lose funds",
+			currency.Upper())
+	}
+	return nil
+}
+
+// WithdrawalRequest holds the details of a withdrawal submitted through
+// WithdrawExchangeFunds. Address and AddressTag, the memo, tag or payment ID,
+// are used by crypto withdrawals, BankAccount by fiat withdrawals
+type WithdrawalRequest struct {
+	Type        WithdrawType
+	Currency    pair.CurrencyItem
+	Amount      float64
+	Address     string
+	AddressTag  string
+	Description string
+	BankAccount config.BankAccount
+}
+
+// Validate checks that the withdrawal request contains the fields required by
+// its type
+func (w *WithdrawalRequest) Validate() error {
+	if w.Currency == "" {
+		return errors.New("withdrawal currency not set")
+	}
+
+	if w.Amount <= 0 {
+		return fmt.Errorf("invalid withdrawal amount %f", w.Amount)
+	}
+
+	switch w.Type {
+	case CryptoWithdrawal:
+		if w.Address == "" {
+			return errors.New("crypto withdrawal address not set")
+		}
+		return CheckAddressTag(w.Currency, w.AddressTag)
+	case FiatWithdrawal, InternationalBankWithdrawal:
+		if w.BankAccount.AccountNumber == "" && w.BankAccount.IBAN == "" {
+			return errors.New("fiat withdrawal bank account not set")
+		}
+	default:
+		return fmt.Errorf("unknown withdrawal type %q", w.Type)
+	}
+	return nil
+}
+
+// BlockedAddressError is returned when a withdrawal address is not on the
+// exchanges withdrawal address allowlist for the currency
+type BlockedAddressError struct {
+	Exchange string
+	Currency pair.CurrencyItem
+	Address  string
+}
+
+// Error implements the error interface
+func (b *BlockedAddressError) Error() string {
+	return fmt.Sprintf("%s withdrawal address %s is not on the %s allowlist",
+		b.Exchange, b.Address, b.Currency)
+}
+
+// SetWithdrawalAddressAllowlist sets the permitted withdrawal addresses per
+// currency from the exchange config
+func (e *Base) SetWithdrawalAddressAllowlist(allowlist map[string][]string) {
+	e.WithdrawalAddressAllowlist = make(map[pair.CurrencyItem][]string)
+	for currency, addresses := range allowlist {
+		if len(addresses) == 0 {
+			continue
+		}
+		c := pair.CurrencyItem(currency).Upper()
+		e.WithdrawalAddressAllowlist[c] = append(e.WithdrawalAddressAllowlist[c], addresses...)
+	}
+}
+
+// CheckWithdrawalAddress returns a BlockedAddressError if the currency has a
+// withdrawal address allowlist which does not contain the address. Currencies
+// without an allowlist permit any address
+func (e *Base) CheckWithdrawalAddress(currency pair.CurrencyItem, address string) error {
+	allowed, ok := e.WithdrawalAddressAllowlist[currency.Upper()]
+	if !ok {
+		return nil
+	}
+
+	for x := range allowed {
+		if allowed[x] == address {
+			return nil
+		}
+	}
+
+	return &BlockedAddressError{
+		Exchange: e.Name,
+		Currency: currency.Upper(),
+		Address:  address,
+	}
+}
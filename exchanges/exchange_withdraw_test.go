@@ -0,0 +1,79 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/config"
+)
+
+func TestWithdrawalRequestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   WithdrawalRequest
+		valid bool
+	}{
+		{"crypto", WithdrawalRequest{Type: CryptoWithdrawal, Currency: "BTC", Amount: 1, Address: "addr"}, true},
+		{"no currency", WithdrawalRequest{Type: CryptoWithdrawal, Amount: 1, Address: "addr"}, false},
+		{"no amount", WithdrawalRequest{Type: CryptoWithdrawal, Currency: "BTC", Address: "addr"}, false},
+		{"no address", WithdrawalRequest{Type: CryptoWithdrawal, Currency: "BTC", Amount: 1}, false},
+		{"no memo", WithdrawalRequest{Type: CryptoWithdrawal, Currency: "XRP", Amount: 1, Address: "addr"}, false},
+		{"memo", WithdrawalRequest{Type: CryptoWithdrawal, Currency: "xrp", Amount: 1, Address: "addr", AddressTag: "1337"}, true},
+		{"fiat", WithdrawalRequest{Type: FiatWithdrawal, Currency: "USD", Amount: 1,
+			BankAccount: config.BankAccount{AccountNumber: "1234"}}, true},
+		{"no bank account", WithdrawalRequest{Type: InternationalBankWithdrawal, Currency: "USD", Amount: 1}, false},
+		{"unknown type", WithdrawalRequest{Currency: "BTC", Amount: 1, Address: "addr"}, false},
+	}
+
+	for _, test := range tests {
+		err := test.req.Validate()
+		if test.valid && err != nil {
+			t.Errorf("Test failed. Validate() %s error %s", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Test failed. Validate() %s returned nil error", test.name)
+		}
+	}
+}
+
+func TestCheckAddressTag(t *testing.T) {
+	if err := CheckAddressTag("BTC", ""); err != nil {
+		t.Error("Test failed. CheckAddressTag() error for BTC", err)
+	}
+
+	if err := CheckAddressTag("XLM", ""); err == nil {
+		t.Error("Test failed. CheckAddressTag() returned nil error for XLM without a memo")
+	}
+
+	if err := CheckAddressTag("XLM", "memo"); err != nil {
+		t.Error("Test failed. CheckAddressTag() error for XLM with a memo", err)
+	}
+
+	if err := CheckAddressTag("XMR", ""); err != nil {
+		t.Error("Test failed. CheckAddressTag() error for XMR integrated address", err)
+	}
+}
+
+func TestCheckWithdrawalAddress(t *testing.T) {
+	b := Base{Name: "TESTNAME"}
+	if err := b.CheckWithdrawalAddress("BTC", "anywhere"); err != nil {
+		t.Error("Test failed. CheckWithdrawalAddress() error with no allowlist", err)
+	}
+
+	b.SetWithdrawalAddressAllowlist(map[string][]string{
+		"btc": {"addr1", "addr2"},
+		"LTC": {},
+	})
+
+	if err := b.CheckWithdrawalAddress("BTC", "addr2"); err != nil {
+		t.Error("Test failed. CheckWithdrawalAddress() error for allowed address", err)
+	}
+
+	err := b.CheckWithdrawalAddress("btc", "anywhere")
+	if _, ok := err.(*BlockedAddressError); !ok {
+		t.Errorf("Test failed. CheckWithdrawalAddress() expected BlockedAddressError, received %v", err)
+	}
+
+	if err = b.CheckWithdrawalAddress("LTC", "anywhere"); err != nil {
+		t.Error("Test failed. CheckWithdrawalAddress() error with an empty allowlist", err)
+	}
+}
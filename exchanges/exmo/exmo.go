@@ -76,7 +76,7 @@ func (e *EXMO) SetDefaults() {
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
-func (e *EXMO) Setup(exch config.ExchangeConfig) {
+func (e *EXMO) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		e.SetEnabled(false)
 	} else {
@@ -92,25 +92,26 @@ func (e *EXMO) Setup(exch config.ExchangeConfig) {
 		e.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := e.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = e.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = e.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = e.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = e.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetTrades returns the trades for a symbol or symbols
@@ -333,7 +334,7 @@ func (e *EXMO) GetWalletHistory(date int64) (WalletHistory, error) {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (e *EXMO) SendHTTPRequest(path string, result interface{}) error {
-	return e.SendPayload("GET", path, nil, nil, result, false, e.Verbose)
+	return e.SendPayload("GET", path, nil, nil, result, false, e.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request
@@ -352,7 +353,7 @@ func (e *EXMO) SendAuthenticatedHTTPRequest(method, endpoint string, vals url.Va
 	payload := vals.Encode()
 	hash := common.GetHMAC(common.HashSHA512, []byte(payload), []byte(e.APISecret))
 
-	if e.Verbose {
+	if e.IsVerbose() {
 		log.Printf("Sending %s request to %s with params %s\n", method, endpoint, payload)
 	}
 
@@ -363,7 +364,7 @@ func (e *EXMO) SendAuthenticatedHTTPRequest(method, endpoint string, vals url.Va
 
 	path := fmt.Sprintf("%s/v%s/%s", e.APIUrl, exmoAPIVersion, endpoint)
 
-	return e.SendPayload(method, path, headers, strings.NewReader(payload), result, true, e.Verbose)
+	return e.SendPayload(method, path, headers, strings.NewReader(payload), result, true, e.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
@@ -0,0 +1,67 @@
+package fixture
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// route holds a compiled URL pattern and the fixture data it serves
+type route struct {
+	pattern *regexp.Regexp
+	data    []byte
+}
+
+// Server replays JSON fixture files for requests matching a URL pattern, so
+// exchange parsers can be tested against known responses. Patterns are
+// matched against the request path and query in the order they were added
+type Server struct {
+	*httptest.Server
+	routes []route
+}
+
+// NewServer returns a started fixture server
+func NewServer() *Server {
+	s := &Server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// AddFixture loads the JSON fixture file and serves it for requests whose path
+// and query match the pattern
+func (s *Server) AddFixture(pattern, file string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+
+	data, err := common.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var check interface{}
+	err = common.JSONDecode(data, &check)
+	if err != nil {
+		return fmt.Errorf("fixture %s is not valid JSON: %s", file, err)
+	}
+
+	s.routes = append(s.routes, route{pattern: re, data: data})
+	return nil
+}
+
+// serve writes the first fixture matching the request, or a 404 if no fixture
+// matches
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	for x := range s.routes {
+		if s.routes[x].pattern.MatchString(r.URL.RequestURI()) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(s.routes[x].data)
+			return
+		}
+	}
+	http.Error(w, "no fixture for "+r.URL.RequestURI(), http.StatusNotFound)
+}
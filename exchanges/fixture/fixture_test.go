@@ -0,0 +1,49 @@
+package fixture
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	err := s.AddFixture("command=hello", "testdata/fixture.json")
+	if err != nil {
+		t.Fatal("Test failed. AddFixture() error", err)
+	}
+
+	err = s.AddFixture("(", "testdata/fixture.json")
+	if err == nil {
+		t.Error("Test failed. AddFixture() invalid pattern error cannot be nil")
+	}
+
+	err = s.AddFixture("command=missing", "testdata/missing.json")
+	if err == nil {
+		t.Error("Test failed. AddFixture() missing file error cannot be nil")
+	}
+
+	resp, err := http.Get(s.URL + "/public?command=hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || string(body) != "{\"hello\": \"world\"}\n" {
+		t.Errorf("Test failed. Unexpected fixture response %d %s", resp.StatusCode, body)
+	}
+
+	resp, err = http.Get(s.URL + "/public?command=goodbye")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Test failed. Expected 404 for unmatched request, got %d", resp.StatusCode)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 	"time"
@@ -66,7 +65,7 @@ func (g *Gateio) SetDefaults() {
 }
 
 // Setup sets user configuration
-func (g *Gateio) Setup(exch config.ExchangeConfig) {
+func (g *Gateio) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		g.SetEnabled(false)
 	} else {
@@ -83,25 +82,26 @@ func (g *Gateio) Setup(exch config.ExchangeConfig) {
 		g.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := g.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = g.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = g.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = g.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = g.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetSymbols returns all supported symbols
@@ -383,7 +383,7 @@ func (g *Gateio) CancelOrder(orderID int64, symbol string) (bool, error) {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (g *Gateio) SendHTTPRequest(path string, result interface{}) error {
-	return g.SendPayload("GET", path, nil, nil, result, false, g.Verbose)
+	return g.SendPayload("GET", path, nil, nil, result, false, g.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends authenticated requests to the Gateio API
@@ -402,7 +402,7 @@ func (g *Gateio) SendAuthenticatedHTTPRequest(method, endpoint, param string, re
 
 	url := fmt.Sprintf("%s/%s/%s", g.APIUrl, gateioAPIVersion, endpoint)
 
-	return g.SendPayload(method, url, headers, strings.NewReader(param), result, true, g.Verbose)
+	return g.SendPayload(method, url, headers, strings.NewReader(param), result, true, g.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
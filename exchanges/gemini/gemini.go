@@ -120,7 +120,7 @@ func (g *Gemini) SetDefaults() {
 }
 
 // Setup sets exchange configuration parameters
-func (g *Gemini) Setup(exch config.ExchangeConfig) {
+func (g *Gemini) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		g.SetEnabled(false)
 	} else {
@@ -137,28 +137,29 @@ func (g *Gemini) Setup(exch config.ExchangeConfig) {
 
 		err := g.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = g.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = g.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = g.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		if exch.UseSandbox {
 			g.APIUrl = geminiSandboxAPIURL
 		}
 		err = g.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetSymbols returns all available symbols for trading
@@ -467,7 +468,7 @@ func (g *Gemini) PostHeartbeat() (string, error) {
 
 // SendHTTPRequest sends an unauthenticated request
 func (g *Gemini) SendHTTPRequest(path string, result interface{}) error {
-	return g.SendPayload("GET", path, nil, nil, result, false, g.Verbose)
+	return g.SendPayload("GET", path, nil, nil, result, false, g.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to the
@@ -493,7 +494,7 @@ func (g *Gemini) SendAuthenticatedHTTPRequest(method, path string, params map[st
 		return errors.New("SendAuthenticatedHTTPRequest: Unable to JSON request")
 	}
 
-	if g.Verbose {
+	if g.IsVerbose() {
 		log.Printf("Request JSON: %s\n", PayloadJSON)
 	}
 
@@ -504,7 +505,7 @@ func (g *Gemini) SendAuthenticatedHTTPRequest(method, path string, params map[st
 	headers["X-GEMINI-PAYLOAD"] = PayloadBase64
 	headers["X-GEMINI-SIGNATURE"] = common.HexEncodeToString(hmac)
 
-	return g.SendPayload(method, g.APIUrl+"/v1/"+path, headers, strings.NewReader(""), result, true, g.Verbose)
+	return g.SendPayload(method, g.APIUrl+"/v1/"+path, headers, strings.NewReader(""), result, true, g.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
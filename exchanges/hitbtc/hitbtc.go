@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"log"
 	"net/url"
 	"strconv"
 	"time"
@@ -79,7 +78,7 @@ func (h *HitBTC) SetDefaults() {
 }
 
 // Setup sets user exchange configuration settings
-func (h *HitBTC) Setup(exch config.ExchangeConfig) {
+func (h *HitBTC) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		h.SetEnabled(false)
 	} else {
@@ -96,23 +95,23 @@ func (h *HitBTC) Setup(exch config.ExchangeConfig) {
 		h.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := h.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.WebsocketSetup(h.WsConnect,
 			exch.Name,
@@ -120,9 +119,10 @@ func (h *HitBTC) Setup(exch config.ExchangeConfig) {
 			hitbtcWebsocketAddress,
 			exch.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // Public Market Data
@@ -568,7 +568,7 @@ func (h *HitBTC) TransferBalance(currency, from, to string, amount float64) (boo
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (h *HitBTC) SendHTTPRequest(path string, result interface{}) error {
-	return h.SendPayload("GET", path, nil, nil, result, false, h.Verbose)
+	return h.SendPayload("GET", path, nil, nil, result, false, h.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated http request
@@ -581,7 +581,7 @@ func (h *HitBTC) SendAuthenticatedHTTPRequest(method, endpoint string, values ur
 
 	path := fmt.Sprintf("%s/%s", h.APIUrl, endpoint)
 
-	return h.SendPayload(method, path, headers, bytes.NewBufferString(values.Encode()), result, true, h.Verbose)
+	return h.SendPayload(method, path, headers, bytes.NewBufferString(values.Encode()), result, true, h.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
@@ -10,7 +10,6 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -91,7 +90,7 @@ func (h *HUOBI) SetDefaults() {
 }
 
 // Setup sets user configuration
-func (h *HUOBI) Setup(exch config.ExchangeConfig) {
+func (h *HUOBI) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		h.SetEnabled(false)
 	} else {
@@ -110,23 +109,23 @@ func (h *HUOBI) Setup(exch config.ExchangeConfig) {
 		h.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := h.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.WebsocketSetup(h.WsConnect,
 			exch.Name,
@@ -134,9 +133,10 @@ func (h *HUOBI) Setup(exch config.ExchangeConfig) {
 			huobiSocketIOAddress,
 			exch.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetSpotKline returns kline data
@@ -771,7 +771,7 @@ func (h *HUOBI) CancelWithdraw(withdrawID int64) (int64, error) {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (h *HUOBI) SendHTTPRequest(path string, result interface{}) error {
-	return h.SendPayload("GET", path, nil, nil, result, false, h.Verbose)
+	return h.SendPayload("GET", path, nil, nil, result, false, h.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends authenticated requests to the HUOBI API
@@ -847,7 +847,7 @@ func (h *HUOBI) SendAuthenticatedHTTPRequest(method, endpoint string, values url
 		body = encoded
 	}
 
-	return h.SendPayload(method, url, headers, bytes.NewReader(body), result, true, h.Verbose)
+	return h.SendPayload(method, url, headers, bytes.NewReader(body), result, true, h.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
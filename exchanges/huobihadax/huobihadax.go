@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/url"
 	"strconv"
 	"time"
@@ -83,7 +82,7 @@ func (h *HUOBIHADAX) SetDefaults() {
 }
 
 // Setup sets user configuration
-func (h *HUOBIHADAX) Setup(exch config.ExchangeConfig) {
+func (h *HUOBIHADAX) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		h.SetEnabled(false)
 	} else {
@@ -101,25 +100,26 @@ func (h *HUOBIHADAX) Setup(exch config.ExchangeConfig) {
 		h.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := h.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = h.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetSpotKline returns kline data
@@ -385,7 +385,7 @@ func (h *HUOBIHADAX) SpotNewOrder(arg SpotNewOrderRequestParams) (int64, error)
 	// So re-encode the Post parameter
 	bytesParams, _ := json.Marshal(vals)
 	postBodyParams := string(bytesParams)
-	if h.Verbose {
+	if h.IsVerbose() {
 		fmt.Println("Post params:", postBodyParams)
 	}
 
@@ -747,7 +747,7 @@ func (h *HUOBIHADAX) CancelWithdraw(withdrawID int64) (int64, error) {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (h *HUOBIHADAX) SendHTTPRequest(path string, result interface{}) error {
-	return h.SendPayload("GET", path, nil, nil, result, false, h.Verbose)
+	return h.SendPayload("GET", path, nil, nil, result, false, h.IsVerbose())
 }
 
 // SendAuthenticatedHTTPPostRequest sends authenticated requests to the HUOBI API
@@ -776,7 +776,7 @@ func (h *HUOBIHADAX) SendAuthenticatedHTTPPostRequest(method, endpoint, postBody
 	url := fmt.Sprintf("%s%s", h.APIUrl, endpoint)
 	url = common.EncodeURLValues(url, signatureParams)
 
-	return h.SendPayload(method, url, headers, bytes.NewBufferString(postBodyValues), result, true, h.Verbose)
+	return h.SendPayload(method, url, headers, bytes.NewBufferString(postBodyValues), result, true, h.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends authenticated requests to the HUOBI API
@@ -803,7 +803,7 @@ func (h *HUOBIHADAX) SendAuthenticatedHTTPRequest(method, endpoint string, value
 	url := fmt.Sprintf("%s%s", h.APIUrl, endpoint)
 	url = common.EncodeURLValues(url, values)
 
-	return h.SendPayload(method, url, headers, bytes.NewBufferString(""), result, true, h.Verbose)
+	return h.SendPayload(method, url, headers, bytes.NewBufferString(""), result, true, h.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
@@ -66,7 +66,7 @@ func (i *ItBit) SetDefaults() {
 }
 
 // Setup sets the exchange parameters from exchange config
-func (i *ItBit) Setup(exch config.ExchangeConfig) {
+func (i *ItBit) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		i.SetEnabled(false)
 	} else {
@@ -82,25 +82,26 @@ func (i *ItBit) Setup(exch config.ExchangeConfig) {
 		i.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := i.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = i.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = i.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = i.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = i.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetTicker returns ticker info for a specified market.
@@ -317,7 +318,7 @@ func (i *ItBit) WalletTransfer(walletID, sourceWallet, destWallet string, amount
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (i *ItBit) SendHTTPRequest(path string, result interface{}) error {
-	return i.SendPayload("GET", path, nil, nil, result, false, i.Verbose)
+	return i.SendPayload("GET", path, nil, nil, result, false, i.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated request to itBit
@@ -345,7 +346,7 @@ func (i *ItBit) SendAuthenticatedHTTPRequest(method string, path string, params
 			return err
 		}
 
-		if i.Verbose {
+		if i.IsVerbose() {
 			log.Printf("Request JSON: %s\n", PayloadJSON)
 		}
 	}
@@ -368,7 +369,7 @@ func (i *ItBit) SendAuthenticatedHTTPRequest(method string, path string, params
 	headers["X-Auth-Nonce"] = nonce
 	headers["Content-Type"] = "application/json"
 
-	return i.SendPayload(method, url, headers, bytes.NewBuffer([]byte(PayloadJSON)), result, true, i.Verbose)
+	return i.SendPayload(method, url, headers, bytes.NewBuffer([]byte(PayloadJSON)), result, true, i.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
@@ -79,7 +79,7 @@ func (k *Kraken) SetDefaults() {
 }
 
 // Setup sets current exchange configuration
-func (k *Kraken) Setup(exch config.ExchangeConfig) {
+func (k *Kraken) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		k.SetEnabled(false)
 	} else {
@@ -95,25 +95,26 @@ func (k *Kraken) Setup(exch config.ExchangeConfig) {
 		k.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := k.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = k.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = k.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = k.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = k.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetServerTime returns current server time
@@ -884,7 +885,7 @@ func GetError(errors []string) error {
 
 // SendHTTPRequest sends an unauthenticated HTTP requests
 func (k *Kraken) SendHTTPRequest(path string, result interface{}) error {
-	return k.SendPayload("GET", path, nil, nil, result, false, k.Verbose)
+	return k.SendPayload("GET", path, nil, nil, result, false, k.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request
@@ -911,7 +912,7 @@ func (k *Kraken) SendAuthenticatedHTTPRequest(method string, params url.Values,
 	shasum := common.GetSHA256([]byte(params.Get("nonce") + encoded))
 	signature := common.Base64Encode(common.GetHMAC(common.HashSHA512, append([]byte(path), shasum...), secret))
 
-	if k.Verbose {
+	if k.IsVerbose() {
 		log.Printf("Sending POST request to %s, path: %s, params: %s", k.APIUrl, path, encoded)
 	}
 
@@ -919,7 +920,7 @@ func (k *Kraken) SendAuthenticatedHTTPRequest(method string, params url.Values,
 	headers["API-Key"] = k.APIKey
 	headers["API-Sign"] = signature
 
-	return k.SendPayload("POST", k.APIUrl+path, headers, strings.NewReader(encoded), result, true, k.Verbose)
+	return k.SendPayload("POST", k.APIUrl+path, headers, strings.NewReader(encoded), result, true, k.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
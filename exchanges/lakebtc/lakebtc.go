@@ -67,7 +67,7 @@ func (l *LakeBTC) SetDefaults() {
 }
 
 // Setup sets exchange configuration profile
-func (l *LakeBTC) Setup(exch config.ExchangeConfig) {
+func (l *LakeBTC) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		l.SetEnabled(false)
 	} else {
@@ -83,25 +83,26 @@ func (l *LakeBTC) Setup(exch config.ExchangeConfig) {
 		l.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := l.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = l.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = l.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = l.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = l.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetTradablePairs returns a list of available pairs from the exchange
@@ -307,7 +308,7 @@ func (l *LakeBTC) CreateWithdraw(amount float64, accountID int64) (Withdraw, err
 
 // SendHTTPRequest sends an unauthenticated http request
 func (l *LakeBTC) SendHTTPRequest(path string, result interface{}) error {
-	return l.SendPayload("GET", path, nil, nil, result, false, l.Verbose)
+	return l.SendPayload("GET", path, nil, nil, result, false, l.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an autheticated HTTP request to a LakeBTC
@@ -325,7 +326,7 @@ func (l *LakeBTC) SendAuthenticatedHTTPRequest(method, params string, result int
 	req := fmt.Sprintf("tonce=%s&accesskey=%s&requestmethod=post&id=1&method=%s&params=%s", l.Nonce.String(), l.APIKey, method, params)
 	hmac := common.GetHMAC(common.HashSHA1, []byte(req), []byte(l.APISecret))
 
-	if l.Verbose {
+	if l.IsVerbose() {
 		log.Printf("Sending POST request to %s calling method %s with params %s\n", l.APIUrl, method, req)
 	}
 
@@ -344,7 +345,7 @@ func (l *LakeBTC) SendAuthenticatedHTTPRequest(method, params string, result int
 	headers["Authorization"] = "Basic " + common.Base64Encode([]byte(l.APIKey+":"+common.HexEncodeToString(hmac)))
 	headers["Content-Type"] = "application/json-rpc"
 
-	return l.SendPayload("POST", l.APIUrl, headers, strings.NewReader(string(data)), result, true, l.Verbose)
+	return l.SendPayload("POST", l.APIUrl, headers, strings.NewReader(string(data)), result, true, l.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
@@ -1,16 +1,21 @@
 package liqui
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/nonce"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -34,13 +39,31 @@ const (
 
 	liquiAuthRate   = 0
 	liquiUnauthRate = 1
+
+	// Request weights against the rate limit budget, requests not listed
+	// weigh 1
+	liquiDepthWeight = 2
+
+	liquiAmountDecimalPlaces = 8
+	liquiTradeHistoryLimit   = 1000
+
+	// liquiNoOrdersError is returned by ActiveOrders when there are no open
+	// orders
+	liquiNoOrdersError = "no orders"
 )
 
 // Liqui is the overarching type across the liqui package
 type Liqui struct {
 	exchange.Base
-	Ticker map[string]Ticker
-	Info   Info
+	Permissions             Permissions
+	AllowWithdrawPermission bool
+	TradingEnabled          bool
+	InfoRefreshInterval     time.Duration
+
+	info       Info
+	infoMtx    sync.RWMutex
+	tickers    map[string]Ticker
+	tickersMtx sync.RWMutex
 }
 
 // SetDefaults sets current default values for liqui
@@ -50,7 +73,10 @@ func (l *Liqui) SetDefaults() {
 	l.Fee = 0.25
 	l.Verbose = false
 	l.RESTPollingDelay = 10
-	l.Ticker = make(map[string]Ticker)
+	l.SetNonceStrategy(nonce.UnixSeconds)
+	l.tickersMtx.Lock()
+	l.tickers = make(map[string]Ticker)
+	l.tickersMtx.Unlock()
 	l.APIWithdrawPermissions = exchange.NoAPIWithdrawalMethods
 	l.RequestCurrencyPairFormat.Delimiter = "_"
 	l.RequestCurrencyPairFormat.Uppercase = false
@@ -60,6 +86,14 @@ func (l *Liqui) SetDefaults() {
 	l.AssetTypes = []string{ticker.Spot}
 	l.SupportsAutoPairUpdating = true
 	l.SupportsRESTTickerBatching = true
+	l.TradingEnabled = true
+	l.Features = exchange.Capabilities{
+		SupportsOrderSubmission: true,
+		SupportsCancelAllOrders: true,
+		SupportsOpenOrders:      true,
+		SupportsOrderHistory:    true,
+		SupportsWithdrawals:     true,
+	}
 	l.Requester = request.New(l.Name,
 		request.NewRateLimit(time.Second, liquiAuthRate),
 		request.NewRateLimit(time.Second, liquiUnauthRate),
@@ -68,11 +102,13 @@ func (l *Liqui) SetDefaults() {
 	l.APIUrl = l.APIUrlDefault
 	l.APIUrlSecondaryDefault = liquiAPIPrivateURL
 	l.APIUrlSecondary = l.APIUrlSecondaryDefault
+	l.TLSPinsDefault = exchange.RootCATLSPins
 	l.WebsocketInit()
 }
 
-// Setup sets exchange configuration parameters for liqui
-func (l *Liqui) Setup(exch config.ExchangeConfig) {
+// Setup sets exchange configuration parameters for liqui, returning the first
+// configuration error encountered
+func (l *Liqui) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		l.SetEnabled(false)
 	} else {
@@ -81,38 +117,63 @@ func (l *Liqui) Setup(exch config.ExchangeConfig) {
 		l.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		l.SetHTTPClientTimeout(exch.HTTPTimeout)
 		l.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		l.SetHTTP2Enabled(!exch.DisableHTTP2)
+		l.SetCircuitBreaker(exch.BreakerThreshold, exch.BreakerWindow, exch.BreakerCooldown)
+		l.SetMaxResponseSize(exch.MaxResponseSize)
 		l.RESTPollingDelay = exch.RESTPollingDelay
+		l.SyncNonceWithServerTime = exch.SyncNonceWithServerTime
+		l.SetFeeTiers(exch.FeeTiers)
+		l.DisableAutoPairUpdates = exch.DisableAutoPairUpdates
+		l.CurrencyPairsCacheTTL = exch.CurrencyPairsCacheTTL
+		l.AllowWithdrawPermission = exch.AllowWithdrawPermission
+		// Trading is assumed to be enabled unless the config disables it
+		if exch.TradingEnabled != nil {
+			l.TradingEnabled = *exch.TradingEnabled
+		}
+		l.InfoRefreshInterval = exch.InfoRefreshInterval
+		l.SetWithdrawalAddressAllowlist(exch.WithdrawalAllowlist)
+		l.SetMaxOrderNotional(exch.MaxOrderNotional, exch.MaxPairOrderNotional)
+		l.CheckOrderBalance = exch.CheckOrderBalance
 		l.Verbose = exch.Verbose
 		l.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		l.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		l.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := l.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = l.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = l.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = l.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
+		}
+		err = l.SetHTTPClientPins(exch.TLSPins)
+		if err != nil {
+			return err
 		}
+		l.SetProxyFallback(exch.ProxyFallback, exch.ProxyBypass)
 		err = l.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetAvailablePairs returns all available pairs
 func (l *Liqui) GetAvailablePairs(nonHidden bool) []string {
+	l.infoMtx.RLock()
+	defer l.infoMtx.RUnlock()
+
 	var pairs []string
-	for x, y := range l.Info.Pairs {
+	for x, y := range l.info.Pairs {
 		if nonHidden && y.Hidden == 1 || x == "" {
 			continue
 		}
@@ -121,17 +182,111 @@ func (l *Liqui) GetAvailablePairs(nonHidden bool) []string {
 	return pairs
 }
 
+// GetExchangeCurrencies returns the non hidden pairs from the stored pair
+// information as sorted uppercase underscore delimited strings for updating the
+// available currencies. RefreshInfo must have been called first
+func (l *Liqui) GetExchangeCurrencies() ([]string, error) {
+	pairs := l.GetAvailablePairs(true)
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("%s pair information not loaded", l.Name)
+	}
+
+	sort.Strings(pairs)
+	return pairs, nil
+}
+
+// ValidateEnabledPairs returns an UnavailablePairsError if any enabled pairs
+// are not present in the stored pair information
+func (l *Liqui) ValidateEnabledPairs() error {
+	exchangeCurrencies, err := l.GetExchangeCurrencies()
+	if err != nil {
+		return err
+	}
+	return l.CheckEnabledPairs(exchangeCurrencies)
+}
+
+// GetHiddenPairs returns all hidden pairs
+func (l *Liqui) GetHiddenPairs() []string {
+	l.infoMtx.RLock()
+	defer l.infoMtx.RUnlock()
+
+	var pairs []string
+	for x, y := range l.info.Pairs {
+		if y.Hidden != 1 || x == "" {
+			continue
+		}
+		pairs = append(pairs, common.StringToUpper(x))
+	}
+	return pairs
+}
+
 // GetInfo provides all the information about currently active pairs, such as
 // the maximum number of digits after the decimal point, the minimum price, the
 // maximum price, the minimum transaction size, whether the pair is hidden, the
 // commission for each pair.
 func (l *Liqui) GetInfo() (Info, error) {
 	resp := Info{}
-	req := fmt.Sprintf("%s/%s/%s/", l.APIUrl, liquiAPIPublicVersion, liquiInfo)
+	req := fmt.Sprintf("%s/%s/%s/", l.GetAPIURL(), liquiAPIPublicVersion, liquiInfo)
 
 	return resp, l.SendHTTPRequest(req, &resp)
 }
 
+// RefreshInfo fetches the latest pair information and replaces the stored
+// copy used for fee and order validation
+func (l *Liqui) RefreshInfo() error {
+	info, err := l.GetInfo()
+	if err != nil {
+		return err
+	}
+
+	l.SetInfo(info)
+	return nil
+}
+
+// refreshInfoUntilStopped refreshes the stored pair information at the supplied
+// interval until Stop is called. Refreshes are skipped while the exchange is
+// disabled. Run starts it in its own goroutine so Run returns
+func (l *Liqui) refreshInfoUntilStopped(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	shutdown := l.GetShutdownChannel()
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-t.C:
+		}
+
+		if !l.IsEnabled() {
+			continue
+		}
+
+		err := l.RefreshInfo()
+		if err != nil {
+			l.LogWarnf("Unable to refresh info %s.", err)
+		}
+	}
+}
+
+// SetInfo replaces the stored pair information
+func (l *Liqui) SetInfo(info Info) {
+	l.infoMtx.Lock()
+	l.info = info
+	l.infoMtx.Unlock()
+}
+
+// GetPairInfo returns the stored information for a currency pair and whether
+// it was found
+//
+// currencyPair - example "eth_btc"
+func (l *Liqui) GetPairInfo(currencyPair string) (PairData, bool) {
+	l.infoMtx.RLock()
+	defer l.infoMtx.RUnlock()
+	pairInfo, ok := l.info.Pairs[currencyPair]
+	return pairInfo, ok
+}
+
 // GetTicker returns information about currently active pairs, such as: the
 // maximum price, the minimum price, average price, trade volume, trade volume
 // in currency, the last trade, Buy and Sell price. All information is provided
@@ -146,9 +301,47 @@ func (l *Liqui) GetTicker(currencyPair string) (map[string]Ticker, error) {
 	}
 
 	response := Response{Data: make(map[string]Ticker)}
-	req := fmt.Sprintf("%s/%s/%s/%s", l.APIUrl, liquiAPIPublicVersion, liquiTicker, currencyPair)
+	req := fmt.Sprintf("%s/%s/%s/%s", l.GetAPIURL(), liquiAPIPublicVersion, liquiTicker, currencyPair)
 
-	return response.Data, l.SendHTTPRequest(req, &response.Data)
+	err := l.SendHTTPRequest(req, &response.Data)
+	if err != nil {
+		return response.Data, err
+	}
+
+	l.updateTickers(response.Data)
+	return response.Data, nil
+}
+
+// GetCachedTicker returns the last ticker fetched by GetTicker for a currency
+// pair and whether it was found
+func (l *Liqui) GetCachedTicker(currencyPair string) (Ticker, bool) {
+	l.tickersMtx.RLock()
+	defer l.tickersMtx.RUnlock()
+	t, ok := l.tickers[currencyPair]
+	return t, ok
+}
+
+// GetCachedTickers returns a copy of all tickers fetched by GetTicker
+func (l *Liqui) GetCachedTickers() map[string]Ticker {
+	l.tickersMtx.RLock()
+	defer l.tickersMtx.RUnlock()
+	tickers := make(map[string]Ticker, len(l.tickers))
+	for k, v := range l.tickers {
+		tickers[k] = v
+	}
+	return tickers
+}
+
+// updateTickers stores fetched tickers in the ticker cache
+func (l *Liqui) updateTickers(tickers map[string]Ticker) {
+	l.tickersMtx.Lock()
+	defer l.tickersMtx.Unlock()
+	if l.tickers == nil {
+		l.tickers = make(map[string]Ticker)
+	}
+	for k, v := range tickers {
+		l.tickers[k] = v
+	}
 }
 
 // GetDepth information about active orders on the pair. Additionally it accepts
@@ -162,9 +355,9 @@ func (l *Liqui) GetDepth(currencyPair string) (Orderbook, error) {
 	}
 
 	response := Response{Data: make(map[string]Orderbook)}
-	req := fmt.Sprintf("%s/%s/%s/%s", l.APIUrl, liquiAPIPublicVersion, liquiDepth, currencyPair)
+	req := fmt.Sprintf("%s/%s/%s/%s", l.GetAPIURL(), liquiAPIPublicVersion, liquiDepth, currencyPair)
 
-	return response.Data[currencyPair], l.SendHTTPRequest(req, &response.Data)
+	return response.Data[currencyPair], l.SendWeightedHTTPRequest(liquiDepthWeight, req, &response.Data)
 }
 
 // GetTrades returns information about the last trades. Additionally it accepts
@@ -178,7 +371,7 @@ func (l *Liqui) GetTrades(currencyPair string) ([]Trades, error) {
 	}
 
 	response := Response{Data: make(map[string][]Trades)}
-	req := fmt.Sprintf("%s/%s/%s/%s", l.APIUrl, liquiAPIPublicVersion, liquiTrades, currencyPair)
+	req := fmt.Sprintf("%s/%s/%s/%s", l.GetAPIURL(), liquiAPIPublicVersion, liquiTrades, currencyPair)
 
 	return response.Data[currencyPair], l.SendHTTPRequest(req, &response.Data)
 }
@@ -193,9 +386,61 @@ func (l *Liqui) GetAccountInfo() (AccountInfo, error) {
 		l.SendAuthenticatedHTTPRequest(liquiAccountInfo, url.Values{}, &result)
 }
 
-// Trade creates orders on the exchange.
-// to-do: convert orderid to int64
-func (l *Liqui) Trade(pair, orderType string, amount, price float64) (float64, error) {
+// ValidatePermissions fetches the API key privileges, stores them in
+// Permissions and returns a warning for each privilege which is missing or
+// should not be granted
+func (l *Liqui) ValidatePermissions() ([]string, error) {
+	info, err := l.GetAccountInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Error != "" {
+		return nil, errors.New(info.Error)
+	}
+
+	l.Permissions = info.Rights
+	return l.permissionWarnings(), nil
+}
+
+// permissionWarnings returns a warning for each missing or unwanted API key
+// privilege in Permissions. A missing trade permission is only reported when
+// trading is enabled
+func (l *Liqui) permissionWarnings() []string {
+	var warnings []string
+	if !l.Permissions.Info {
+		warnings = append(warnings, "API key is missing info permission, account data will be unavailable")
+	}
+	if !l.Permissions.Trade && l.TradingEnabled {
+		warnings = append(warnings, "API key is missing trade permission, orders will be rejected")
+	}
+	if l.Permissions.Withdraw && !l.AllowWithdrawPermission {
+		warnings = append(warnings, "API key has withdraw permission, disable it unless withdrawals are required")
+	}
+	return warnings
+}
+
+// Trade creates orders on the exchange and returns the order ID. The pair info
+// is fetched if it has not been loaded, so the amount and price are always
+// rounded to the pair's precision
+func (l *Liqui) Trade(pair, orderType string, amount, price float64) (int64, error) {
+	// Pair info is needed to round to the pair's permitted precision
+	if _, ok := l.GetPairInfo(pair); !ok {
+		if err := l.RefreshInfo(); err != nil {
+			return 0, err
+		}
+	}
+
+	amount, price, err := l.RoundOrderPrecision(pair, amount, price)
+	if err != nil {
+		return 0, err
+	}
+
+	err = l.CheckOrderNotional(pair, amount, price)
+	if err != nil {
+		return 0, err
+	}
+
 	req := url.Values{}
 	req.Add("pair", pair)
 	req.Add("type", orderType)
@@ -204,17 +449,155 @@ func (l *Liqui) Trade(pair, orderType string, amount, price float64) (float64, e
 
 	var result Trade
 
-	return result.OrderID, l.SendAuthenticatedHTTPRequest(liquiTrade, req, &result)
+	err = l.SendAuthenticatedHTTPRequest(liquiTrade, req, &result)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.OrderID, nil
+}
+
+// MarketBuy spends a quote currency budget on the supplied pair by reading the
+// current asks via GetDepth and submitting a buy order priced at the deepest
+// ask level required to fill the budget, so that the order fills immediately.
+// An error is returned if the orderbook does not hold enough depth to fill the
+// full budget.
+//
+// currencyPair - example "eth_btc"
+func (l *Liqui) MarketBuy(currencyPair string, quoteAmount float64) (int64, error) {
+	book, err := l.GetDepth(currencyPair)
+	if err != nil {
+		return 0, err
+	}
+
+	amount, price, err := calculateMarketOrder(book.Asks, quoteAmount, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return l.Trade(currencyPair, "buy", amount, price)
+}
+
+// MarketSell sells a base currency amount on the supplied pair by reading the
+// current bids via GetDepth and submitting a sell order priced at the deepest
+// bid level required to fill the amount, so that the order fills immediately.
+// An error is returned if the orderbook does not hold enough depth to fill the
+// full amount.
+//
+// currencyPair - example "eth_btc"
+func (l *Liqui) MarketSell(currencyPair string, baseAmount float64) (int64, error) {
+	book, err := l.GetDepth(currencyPair)
+	if err != nil {
+		return 0, err
+	}
+
+	amount, price, err := calculateMarketOrder(book.Bids, baseAmount, false)
+	if err != nil {
+		return 0, err
+	}
+
+	return l.Trade(currencyPair, "sell", amount, price)
+}
+
+// calculateMarketOrder walks orderbook levels of [price, amount] and returns
+// the base amount to trade and the worst price touched. When quote is true the
+// budget is denominated in the quote currency, otherwise in the base currency.
+func calculateMarketOrder(levels [][]float64, budget float64, quote bool) (amount, price float64, err error) {
+	if budget <= 0 {
+		return 0, 0, errors.New("liqui market order error - amount must be greater than zero")
+	}
+
+	remaining := budget
+	for x := range levels {
+		if len(levels[x]) < 2 {
+			continue
+		}
+
+		levelPrice, levelAmount := levels[x][0], levels[x][1]
+		if levelPrice <= 0 || levelAmount <= 0 {
+			continue
+		}
+
+		available := levelAmount
+		if quote {
+			available = levelAmount * levelPrice
+		}
+
+		price = levelPrice
+		if remaining <= available {
+			if quote {
+				amount += remaining / levelPrice
+			} else {
+				amount += remaining
+			}
+			return amount, price, nil
+		}
+
+		amount += levelAmount
+		remaining -= available
+	}
+
+	return 0, 0, fmt.Errorf("liqui market order error - insufficient orderbook depth, %f of %f unfilled",
+		remaining, budget)
+}
+
+// RoundOrderPrecision rounds an order amount and price to the precision
+// permitted by Liqui for the supplied pair. Price precision is taken from the
+// pair's decimal_places as returned by GetInfo.
+//
+// currencyPair - example "eth_btc"
+func (l *Liqui) RoundOrderPrecision(currencyPair string, amount, price float64) (float64, float64, error) {
+	pairInfo, ok := l.GetPairInfo(currencyPair)
+	if !ok {
+		return amount, price, fmt.Errorf("liqui pair %s precision information not found",
+			currencyPair)
+	}
+
+	return common.RoundToDecimals(amount, liquiAmountDecimalPlaces),
+		common.RoundToDecimals(price, pairInfo.DecimalPlaces),
+		nil
 }
 
 // GetActiveOrders returns the list of your active orders.
 func (l *Liqui) GetActiveOrders(pair string) (map[string]ActiveOrders, error) {
-	result := make(map[string]ActiveOrders)
-
 	req := url.Values{}
 	req.Add("pair", pair)
 
-	return result, l.SendAuthenticatedHTTPRequest(liquiActiveOrders, req, &result)
+	return l.getActiveOrders(req)
+}
+
+// GetAllActiveOrders returns the list of your active orders across all pairs.
+// The returned map is keyed by order ID as a string.
+func (l *Liqui) GetAllActiveOrders() (map[string]ActiveOrders, error) {
+	return l.getActiveOrders(url.Values{})
+}
+
+// getActiveOrders requests active orders, Liqui's no orders error response is
+// returned as an empty result
+func (l *Liqui) getActiveOrders(req url.Values) (map[string]ActiveOrders, error) {
+	result := make(map[string]ActiveOrders)
+
+	var raw json.RawMessage
+	err := l.SendAuthenticatedHTTPRequest(liquiActiveOrders, req, &raw)
+	if err != nil {
+		return result, err
+	}
+
+	return result, decodeActiveOrders(raw, result)
+}
+
+// decodeActiveOrders decodes an ActiveOrders response into result, treating
+// the no orders error response as no active orders
+func decodeActiveOrders(raw []byte, result map[string]ActiveOrders) error {
+	var resp Response
+	if json.Unmarshal(raw, &resp) == nil && resp.Error != "" {
+		if resp.Error == liquiNoOrdersError {
+			return nil
+		}
+		return errors.New(resp.Error)
+	}
+
+	return common.JSONDecode(raw, &result)
 }
 
 // GetOrderInfo returns the information on particular order.
@@ -239,9 +622,53 @@ func (l *Liqui) CancelOrder(OrderID int64) (bool, error) {
 		return false, err
 	}
 
+	if result.Error != "" {
+		return false, errors.New(result.Error)
+	}
+
 	return true, nil
 }
 
+// CancelAllOrders cancels every active order for a pair, or across all pairs
+// if the pair is empty. Liqui has no cancel all endpoint so each order is
+// cancelled individually through the requester, which paces the requests
+// within the authenticated rate limit. Orders which fail to cancel are
+// returned in the response rather than stopping the remaining cancellations
+func (l *Liqui) CancelAllOrders(pair string) (CancelAllOrdersResponse, error) {
+	resp := CancelAllOrdersResponse{Failed: make(map[int64]error)}
+
+	var orders map[string]ActiveOrders
+	var err error
+	if pair == "" {
+		orders, err = l.GetAllActiveOrders()
+	} else {
+		orders, err = l.GetActiveOrders(pair)
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	ids := make([]int64, 0, len(orders))
+	for id := range orders {
+		orderID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return resp, fmt.Errorf("%s invalid order ID %s", l.Name, id)
+		}
+		ids = append(ids, orderID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		_, err = l.CancelOrder(id)
+		if err != nil {
+			resp.Failed[id] = err
+			continue
+		}
+		resp.Cancelled = append(resp.Cancelled, id)
+	}
+	return resp, nil
+}
+
 // GetTradeHistory returns trade history
 func (l *Liqui) GetTradeHistory(vals url.Values, pair string) (map[string]TradeHistory, error) {
 	result := make(map[string]TradeHistory)
@@ -253,65 +680,179 @@ func (l *Liqui) GetTradeHistory(vals url.Values, pair string) (map[string]TradeH
 	return result, l.SendAuthenticatedHTTPRequest(liquiTradeHistory, vals, &result)
 }
 
+// GetTradeHistoryRange returns all trades between the start and end times keyed
+// by trade ID. Liqui caps the number of trades per response, so requests are
+// repeated from the last received trade ID until the range is exhausted
+func (l *Liqui) GetTradeHistoryRange(pair string, start, end time.Time) (map[string]TradeHistory, error) {
+	result := make(map[string]TradeHistory)
+	var fromID int64
+
+	for {
+		vals := url.Values{}
+		vals.Set("count", strconv.Itoa(liquiTradeHistoryLimit))
+		vals.Set("order", "ASC")
+		vals.Set("since", strconv.FormatInt(start.Unix(), 10))
+		vals.Set("end", strconv.FormatInt(end.Unix(), 10))
+		if fromID > 0 {
+			vals.Set("from_id", strconv.FormatInt(fromID, 10))
+		}
+
+		trades, err := l.GetTradeHistory(vals, pair)
+		if err != nil {
+			return nil, err
+		}
+
+		lastID := fromID
+		for id, trade := range trades {
+			result[id] = trade
+			tradeID, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if tradeID >= lastID {
+				lastID = tradeID + 1
+			}
+		}
+
+		if len(trades) < liquiTradeHistoryLimit || lastID == fromID {
+			return result, nil
+		}
+		fromID = lastID
+	}
+}
+
 // WithdrawCoins is designed for cryptocurrency withdrawals.
 // API mentions that this isn't active now, but will be soon - you must provide the first 8 characters of the key
-// in your ticket to support.
-func (l *Liqui) WithdrawCoins(coin string, amount float64, address string) (WithdrawCoins, error) {
+// in your ticket to support. The memo is only sent when set and is required for
+// currencies which need a memo or payment ID
+func (l *Liqui) WithdrawCoins(coin string, amount float64, address, memo string) (WithdrawCoins, error) {
+	var result WithdrawCoins
+	err := exchange.CheckAddressTag(pair.CurrencyItem(coin), memo)
+	if err != nil {
+		return result, err
+	}
+
+	err = l.CheckWithdrawalAddress(pair.CurrencyItem(coin), address)
+	if err != nil {
+		return result, err
+	}
+
 	req := url.Values{}
 	req.Add("coinName", coin)
 	req.Add("amount", strconv.FormatFloat(amount, 'f', -1, 64))
 	req.Add("address", address)
+	if memo != "" {
+		req.Add("memo", memo)
+	}
 
-	var result WithdrawCoins
 	return result, l.SendAuthenticatedHTTPRequest(liquiWithdrawCoin, req, &result)
 }
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (l *Liqui) SendHTTPRequest(path string, result interface{}) error {
-	return l.SendPayload("GET", path, nil, nil, result, false, l.Verbose)
+	return l.SendWeightedHTTPRequest(1, path, result)
 }
 
-// SendAuthenticatedHTTPRequest sends an authenticated http request to liqui
-func (l *Liqui) SendAuthenticatedHTTPRequest(method string, values url.Values, result interface{}) (err error) {
-	if !l.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, l.Name)
-	}
-
-	if l.Nonce.Get() == 0 {
-		l.Nonce.Set(time.Now().Unix())
+// SendWeightedHTTPRequest sends an unauthenticated HTTP request which consumes
+// the supplied weight from the rate limit budget
+func (l *Liqui) SendWeightedHTTPRequest(weight int, path string, result interface{}) error {
+	var raw json.RawMessage
+	err := l.SendWeightedPayload(weight, "GET", path, nil, nil, &raw, false, l.IsVerbose())
+	if err != nil {
+		err = exchange.ClassifyRequestError(l.Name, err)
 	} else {
-		l.Nonce.Inc()
+		err = exchange.CheckErrorResponse(l.Name, raw)
 	}
-	values.Set("nonce", l.Nonce.String())
-	values.Set("method", method)
 
-	encoded := values.Encode()
-	hmac := common.GetHMAC(common.HashSHA512, []byte(encoded), []byte(l.APISecret))
+	if err == nil && result != nil {
+		err = common.JSONDecode(raw, result)
+	}
+	return exchange.WrapRequestError(l.Name, "GET", l.requestEndpoint(path), err)
+}
 
-	if l.Verbose {
-		log.Printf("Sending POST request to %s calling method %s with params %s\n",
-			l.APIUrlSecondary, method, encoded)
+// requestEndpoint returns the public API method of a request path
+func (l *Liqui) requestEndpoint(path string) string {
+	endpoint := strings.TrimPrefix(path, fmt.Sprintf("%s/%s/", l.GetAPIURL(), liquiAPIPublicVersion))
+	if i := strings.IndexAny(endpoint, "/?"); i >= 0 {
+		endpoint = endpoint[:i]
 	}
+	return endpoint
+}
+
+// SignRequest sets the nonce and method on the request values and returns the
+// encoded request body along with the Key and Sign headers. The signature is a
+// hex encoded HMAC-SHA512 of the encoded body using the API secret, Liqui
+// expects the body keys to be sorted alphabetically
+func (l *Liqui) SignRequest(method, nonce string, values url.Values) (string, map[string]string) {
+	values.Set("nonce", nonce)
+	values.Set("method", method)
+
+	apiKey, apiSecret, _ := l.GetAPIKeys()
+	encoded := common.EncodeURLValuesOrdered(values, nil)
+	hmac := common.GetHMAC(common.HashSHA512, []byte(encoded), []byte(apiSecret))
 
 	headers := make(map[string]string)
-	headers["Key"] = l.APIKey
+	headers["Key"] = apiKey
 	headers["Sign"] = common.HexEncodeToString(hmac)
 	headers["Content-Type"] = "application/x-www-form-urlencoded"
+	return encoded, headers
+}
+
+// SendAuthenticatedHTTPRequest sends an authenticated http request to liqui
+func (l *Liqui) SendAuthenticatedHTTPRequest(method string, values url.Values, result interface{}) (err error) {
+	if !l.GetAuthenticatedAPISupport() {
+		return exchange.NewCredentialsError(l.Name)
+	}
+
+	if err := l.CheckAPICredentials(); err != nil {
+		return err
+	}
+
+	if l.Nonce.Get() == 0 && l.SyncNonceWithServerTime {
+		if _, err := l.GetExchangeServerTime(); err != nil {
+			return err
+		}
+	}
+	nonce := strconv.FormatInt(l.Nonce.Next(), 10)
+	encoded, headers := l.SignRequest(method, nonce, values)
 
-	return l.SendPayload("POST",
-		l.APIUrlSecondary, headers,
+	path := l.GetSecondaryAPIURL()
+	l.LogDebugf("Sending POST request to %s calling method %s with params %s",
+		path, method, request.RedactBody(encoded))
+
+	var raw json.RawMessage
+	err = l.SendPayload("POST",
+		path, headers,
 		strings.NewReader(encoded),
-		result,
+		&raw,
 		true,
-		l.Verbose)
+		l.IsVerbose())
+	if err != nil {
+		err = exchange.ClassifyRequestError(l.Name, err)
+	} else {
+		err = exchange.CheckErrorResponse(l.Name, raw)
+	}
+
+	if err == nil && result != nil {
+		err = common.JSONDecode(raw, result)
+	}
+	return exchange.WrapRequestError(l.Name, "POST", method, err)
 }
 
 // GetFee returns an estimate of fee based on type of transaction
 func (l *Liqui) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
+	if err := feeBuilder.Validate(); err != nil {
+		return 0, err
+	}
+
 	var fee float64
 	switch feeBuilder.FeeType {
 	case exchange.CryptocurrencyTradeFee:
-		fee = calculateTradingFee(feeBuilder.PurchasePrice, feeBuilder.Amount, feeBuilder.IsMaker)
+		if tier, ok := l.GetFeeTier(feeBuilder.TradingVolume); ok {
+			fee = calculateTieredTradingFee(tier, feeBuilder.PurchasePrice, feeBuilder.Amount, feeBuilder.IsMaker)
+		} else {
+			fee = calculateTradingFee(feeBuilder.PurchasePrice, feeBuilder.Amount, feeBuilder.IsMaker)
+		}
 	case exchange.CryptocurrencyWithdrawalFee:
 		fee = getCryptocurrencyWithdrawalFee(feeBuilder.FirstCurrency)
 	}
@@ -335,3 +876,10 @@ func calculateTradingFee(purchasePrice, amount float64, isMaker bool) (fee float
 	}
 	return fee * purchasePrice * amount
 }
+
+func calculateTieredTradingFee(tier config.FeeTier, purchasePrice, amount float64, isMaker bool) float64 {
+	if isMaker {
+		return tier.Maker * purchasePrice * amount
+	}
+	return tier.Taker * purchasePrice * amount
+}
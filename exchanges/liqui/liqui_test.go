@@ -1,9 +1,16 @@
 package liqui
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
@@ -33,7 +40,57 @@ func TestSetup(t *testing.T) {
 	liquiConfig.APIKey = apiKey
 	liquiConfig.APISecret = apiSecret
 
-	l.Setup(liquiConfig)
+	err = l.Setup(liquiConfig)
+	if err != nil {
+		t.Error("Test Failed - liqui Setup() error", err)
+	}
+}
+
+func TestFormatExchangeCurrency(t *testing.T) {
+	cfg := config.GetConfig()
+	if _, err := cfg.GetExchangeConfig("Liqui"); err != nil {
+		cfg.LoadConfig("../../testdata/configtest.json")
+	}
+
+	var liqui Liqui
+	liqui.SetDefaults()
+
+	tests := []struct {
+		first, second string
+		expected      pair.CurrencyItem
+	}{
+		{symbol.ETH, symbol.BTC, "eth_btc"},
+		{"eth", "btc", "eth_btc"},
+		{symbol.DASH, symbol.USDT, "dash_usdt"},
+	}
+
+	var pairs []pair.CurrencyPair
+	for _, test := range tests {
+		currencyPair := pair.NewCurrencyPair(test.first, test.second)
+		pairs = append(pairs, currencyPair)
+
+		if result := exchange.FormatExchangeCurrency(liqui.Name, currencyPair); result != test.expected {
+			t.Errorf("Test Failed - liqui FormatExchangeCurrency() %s_%s expected %s got %s",
+				test.first, test.second, test.expected, result)
+		}
+
+		// The config format must match the format set by SetDefaults
+		result := currencyPair.Display(liqui.RequestCurrencyPairFormat.Delimiter,
+			liqui.RequestCurrencyPairFormat.Uppercase)
+		if result != test.expected {
+			t.Errorf("Test Failed - liqui SetDefaults() request format %s_%s expected %s got %s",
+				test.first, test.second, test.expected, result)
+		}
+	}
+
+	// Batched ticker requests join the pairs with the separator
+	joined, err := exchange.GetAndFormatExchangeCurrencies(liqui.Name, pairs)
+	if err != nil {
+		t.Fatal("Test Failed - liqui GetAndFormatExchangeCurrencies() error", err)
+	}
+	if joined != "eth_btc-eth_btc-dash_usdt" {
+		t.Errorf("Test Failed - liqui GetAndFormatExchangeCurrencies() unexpected result %s", joined)
+	}
 }
 
 func TestGetAvailablePairs(t *testing.T) {
@@ -44,6 +101,47 @@ func TestGetAvailablePairs(t *testing.T) {
 	}
 }
 
+func TestGetHiddenPairs(t *testing.T) {
+	var liqui Liqui
+	liqui.SetInfo(Info{Pairs: map[string]PairData{
+		"eth_btc": {},
+		"ltc_btc": {Hidden: 1},
+	}})
+
+	hidden := liqui.GetHiddenPairs()
+	if len(hidden) != 1 || hidden[0] != "LTC_BTC" {
+		t.Error("Test Failed - liqui GetHiddenPairs() unexpected pairs", hidden)
+	}
+
+	available := liqui.GetAvailablePairs(true)
+	if len(available) != 1 || available[0] != "ETH_BTC" {
+		t.Error("Test Failed - liqui GetAvailablePairs() unexpected pairs", available)
+	}
+}
+
+func TestGetExchangeCurrencies(t *testing.T) {
+	var liqui Liqui
+	liqui.Name = "Liqui"
+	if _, err := liqui.GetExchangeCurrencies(); err == nil {
+		t.Error("Test Failed - liqui GetExchangeCurrencies() returned nil error without pair information")
+	}
+
+	liqui.SetInfo(Info{Pairs: map[string]PairData{
+		"ltc_btc": {},
+		"eth_btc": {},
+		"xem_btc": {Hidden: 1},
+	}})
+
+	currencies, err := liqui.GetExchangeCurrencies()
+	if err != nil {
+		t.Fatal("Test Failed - liqui GetExchangeCurrencies() error", err)
+	}
+
+	if len(currencies) != 2 || currencies[0] != "ETH_BTC" || currencies[1] != "LTC_BTC" {
+		t.Error("Test Failed - liqui GetExchangeCurrencies() unexpected currencies", currencies)
+	}
+}
+
 func TestGetInfo(t *testing.T) {
 	t.Parallel()
 	_, err := l.GetInfo()
@@ -68,6 +166,22 @@ func TestGetDepth(t *testing.T) {
 	}
 }
 
+func TestRequestErrorContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var liqui Liqui
+	liqui.SetDefaults()
+	liqui.APIUrl = server.URL
+
+	_, err := liqui.GetDepth("eth_btc")
+	if err == nil || !common.StringContains(err.Error(), "liqui: GET depth: ") {
+		t.Error("Test Failed - liqui GetDepth() expected endpoint context, received", err)
+	}
+}
+
 func TestGetTrades(t *testing.T) {
 	t.Parallel()
 	_, err := l.GetTrades("eth_btc")
@@ -93,6 +207,11 @@ func TestAuthRequests(t *testing.T) {
 			t.Error("Test Failed - liqui GetActiveOrders() error", err)
 		}
 
+		_, err = l.GetAllActiveOrders()
+		if err == nil {
+			t.Error("Test Failed - liqui GetAllActiveOrders() error", err)
+		}
+
 		_, err = l.GetOrderInfo(1337)
 		if err == nil {
 			t.Error("Test Failed - liqui GetOrderInfo() error", err)
@@ -108,13 +227,83 @@ func TestAuthRequests(t *testing.T) {
 			t.Error("Test Failed - liqui GetTradeHistory() error", err)
 		}
 
-		_, err = l.WithdrawCoins("btc", 1337, "someaddr")
+		_, err = l.WithdrawCoins("btc", 1337, "someaddr", "")
 		if err == nil {
 			t.Error("Test Failed - liqui WithdrawCoins() error", err)
 		}
 	}
 }
 
+func TestTradeOrderIDPrecision(t *testing.T) {
+	t.Parallel()
+	// 2^53 + 1 cannot be represented exactly as a float64
+	var orderID int64 = 9007199254740993
+	data := []byte(`{"received":0.1,"remains":0,"order_id":9007199254740993,"funds":{"btc":1}}`)
+
+	var result Trade
+	err := common.JSONDecode(data, &result)
+	if err != nil {
+		t.Fatal("Test Failed - liqui Trade unmarshal error", err)
+	}
+
+	if result.OrderID != orderID {
+		t.Errorf("Test Failed - liqui Trade OrderID expected %d, received %d",
+			orderID, result.OrderID)
+	}
+}
+
+func TestCalculateMarketOrder(t *testing.T) {
+	t.Parallel()
+	asks := [][]float64{{0.1, 2}, {0.2, 5}}
+
+	amount, price, err := calculateMarketOrder(asks, 0.5, true)
+	if err != nil {
+		t.Fatal("Test Failed - liqui calculateMarketOrder() error", err)
+	}
+	if amount != 3.5 || price != 0.2 {
+		t.Errorf("Test Failed - liqui calculateMarketOrder() expected 3.5 @ 0.2, received %f @ %f",
+			amount, price)
+	}
+
+	amount, price, err = calculateMarketOrder(asks, 1, false)
+	if err != nil {
+		t.Fatal("Test Failed - liqui calculateMarketOrder() error", err)
+	}
+	if amount != 1 || price != 0.1 {
+		t.Errorf("Test Failed - liqui calculateMarketOrder() expected 1 @ 0.1, received %f @ %f",
+			amount, price)
+	}
+
+	_, _, err = calculateMarketOrder(asks, 10, true)
+	if err == nil {
+		t.Error("Test Failed - liqui calculateMarketOrder() expected insufficient depth error")
+	}
+
+	_, _, err = calculateMarketOrder(asks, 0, true)
+	if err == nil {
+		t.Error("Test Failed - liqui calculateMarketOrder() expected zero amount error")
+	}
+}
+
+func TestRoundOrderPrecision(t *testing.T) {
+	var lq Liqui
+	lq.SetInfo(Info{Pairs: map[string]PairData{"eth_btc": {DecimalPlaces: 5}}})
+
+	amount, price, err := lq.RoundOrderPrecision("eth_btc", 1.123456789, 0.0123456)
+	if err != nil {
+		t.Fatal("Test Failed - liqui RoundOrderPrecision() error", err)
+	}
+	if amount != 1.12345679 || price != 0.01235 {
+		t.Errorf("Test Failed - liqui RoundOrderPrecision() received %f @ %f",
+			amount, price)
+	}
+
+	_, _, err = lq.RoundOrderPrecision("ltc_btc", 1, 1)
+	if err == nil {
+		t.Error("Test Failed - liqui RoundOrderPrecision() expected error on unknown pair")
+	}
+}
+
 func TestUpdateTicker(t *testing.T) {
 	p := pair.NewCurrencyPairDelimiter("ETH_BTC", "_")
 	_, err := l.UpdateTicker(p, "SPOT")
@@ -144,6 +333,80 @@ func setFeeBuilder() exchange.FeeBuilder {
 		BankTransactionType: exchange.WireTransfer,
 	}
 }
+
+func TestSignRequest(t *testing.T) {
+	t.Parallel()
+	signer := Liqui{}
+	signer.APIKey = "key"
+	signer.APISecret = "secret"
+
+	values := url.Values{}
+	values.Set("pair", "eth_btc")
+	encoded, headers := signer.SignRequest("getInfo", "1530000000", values)
+
+	if encoded != "method=getInfo&nonce=1530000000&pair=eth_btc" {
+		t.Errorf("Test Failed - liqui SignRequest() unexpected body %s", encoded)
+	}
+
+	if headers["Key"] != "key" {
+		t.Errorf("Test Failed - liqui SignRequest() unexpected key %s", headers["Key"])
+	}
+
+	expected := "a85e78d78ba836c8a9964bf25228a2cd61139c9abafd64fba792b6fd29fb33a8f3f89a3338d009f4e423cf8ef1a536373665590ea512921d211a2252791ccf69"
+	if headers["Sign"] != expected {
+		t.Errorf("Test Failed - liqui SignRequest() expected %s received %s",
+			expected, headers["Sign"])
+	}
+}
+
+func TestConvertActiveOrders(t *testing.T) {
+	t.Parallel()
+	var resp map[string]ActiveOrders
+	err := common.JSONDecode([]byte(`{"343153":{"pair":"eth_btc","type":"buy","amount":2,"rate":0.05,"timestamp_created":1530000001,"status":0},"343152":{"pair":"ltc_btc","type":"sell","amount":12.1,"rate":0.0185,"timestamp_created":1530000000,"status":0}}`), &resp)
+	if err != nil {
+		t.Fatal("Test Failed - liqui ActiveOrders decode error", err)
+	}
+
+	orders, err := l.convertActiveOrders(resp)
+	if err != nil {
+		t.Fatal("Test Failed - liqui convertActiveOrders() error", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("Test Failed - liqui convertActiveOrders() unexpected length %d", len(orders))
+	}
+
+	o := orders[0]
+	if o.ID != 343152 || o.OrderSide != string(exchange.OrderSideSell()) ||
+		o.BaseCurrency != "LTC" || o.QuoteCurrency != "BTC" ||
+		o.Price != 0.0185 || o.OpenVolume != 12.1 {
+		t.Errorf("Test Failed - liqui convertActiveOrders() unexpected result %+v", o)
+	}
+
+	if orders[1].OrderSide != string(exchange.OrderSideBuy()) {
+		t.Errorf("Test Failed - liqui convertActiveOrders() unexpected side %s", orders[1].OrderSide)
+	}
+}
+
+func TestConvertTradeHistory(t *testing.T) {
+	t.Parallel()
+	var resp map[string]TradeHistory
+	err := common.JSONDecode([]byte(`{"166830":{"pair":"eth_btc","type":"sell","amount":1,"rate":0.065,"order_id":343148,"is_your_order":1,"timestamp":1530000100},"166829":{"pair":"eth_btc","type":"sell","amount":0.5,"rate":0.064,"order_id":343148,"is_your_order":1,"timestamp":1530000000}}`), &resp)
+	if err != nil {
+		t.Fatal("Test Failed - liqui TradeHistory decode error", err)
+	}
+
+	orders := l.convertTradeHistory(resp)
+	if len(orders) != 2 {
+		t.Fatalf("Test Failed - liqui convertTradeHistory() unexpected length %d", len(orders))
+	}
+
+	o := orders[0]
+	if o.ID != 343148 || o.CreationTime != 1530000000 || o.Amount != 0.5 ||
+		o.Price != 0.064 || o.Status != "filled" || o.BaseCurrency != "ETH" {
+		t.Errorf("Test Failed - liqui convertTradeHistory() unexpected result %+v", o)
+	}
+}
+
 func TestGetFee(t *testing.T) {
 	l.SetDefaults()
 	var feeBuilder = setFeeBuilder()
@@ -173,10 +436,17 @@ func TestGetFee(t *testing.T) {
 	// CryptocurrencyTradeFee Negative purchase price
 	feeBuilder = setFeeBuilder()
 	feeBuilder.PurchasePrice = -1000
-	if resp, err := l.GetFee(feeBuilder); resp != float64(0) || err != nil {
-		t.Errorf("Test Failed - GetFee() error. Expected: %f, Recieved: %f", float64(0), resp)
-		t.Error(err)
+	if _, err := l.GetFee(feeBuilder); err == nil {
+		t.Error("Test Failed - GetFee() error cannot be nil for negative purchase price")
 	}
+
+	// CryptocurrencyTradeFee Zero purchase price
+	feeBuilder = setFeeBuilder()
+	feeBuilder.PurchasePrice = 0
+	if _, err := l.GetFee(feeBuilder); err == nil {
+		t.Error("Test Failed - GetFee() error cannot be nil for zero purchase price")
+	}
+
 	// CryptocurrencyWithdrawalFee Basic
 	feeBuilder = setFeeBuilder()
 	feeBuilder.FeeType = exchange.CryptocurrencyWithdrawalFee
@@ -220,6 +490,44 @@ func TestGetFee(t *testing.T) {
 	}
 }
 
+func TestGetFeeTiered(t *testing.T) {
+	var tiered Liqui
+	tiered.SetDefaults()
+	tiered.SetFeeTiers([]config.FeeTier{
+		{Volume: 0, Maker: 0.001, Taker: 0.0025},
+		{Volume: 100, Maker: 0.0005, Taker: 0.0015},
+	})
+
+	feeBuilder := setFeeBuilder()
+	feeBuilder.Amount = 1000
+	if resp, err := tiered.GetFee(feeBuilder); resp != float64(2.5) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Recieved: %f", float64(2.5), resp)
+		t.Error(err)
+	}
+
+	feeBuilder.TradingVolume = 150
+	if resp, err := tiered.GetFee(feeBuilder); resp != float64(1.5) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Recieved: %f", float64(1.5), resp)
+		t.Error(err)
+	}
+
+	feeBuilder.TradingVolume = 0
+	feeBuilder.IsMaker = true
+	tiered.TradingVolume = 150
+	if resp, err := tiered.GetFee(feeBuilder); resp != float64(0.5) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Recieved: %f", float64(0.5), resp)
+		t.Error(err)
+	}
+}
+
+func TestGetWebsocket(t *testing.T) {
+	t.Parallel()
+	ws, err := l.GetWebsocket()
+	if ws != nil || err == nil || err.Error() != exchange.WebsocketNotSupported {
+		t.Error("Test Failed - liqui GetWebsocket() expected not supported error")
+	}
+}
+
 func TestFormatWithdrawPermissions(t *testing.T) {
 	// Arrange
 	l.SetDefaults()
@@ -231,3 +539,442 @@ func TestFormatWithdrawPermissions(t *testing.T) {
 		t.Errorf("Expected: %s, Recieved: %s", expectedResult, withdrawPermissions)
 	}
 }
+
+func TestSendAuthenticatedHTTPRequestNoCredentials(t *testing.T) {
+	var liqui Liqui
+	liqui.Name = "Liqui"
+	liqui.AuthenticatedAPISupport = true
+	liqui.SetAPIKeys("key", "", "", false)
+
+	err := liqui.SendAuthenticatedHTTPRequest(liquiAccountInfo, url.Values{}, nil)
+	if err == nil || err.Error() != "API credentials not set for Liqui" {
+		t.Error("Test Failed - liqui SendAuthenticatedHTTPRequest() unexpected error", err)
+	}
+}
+
+func TestPermissionWarnings(t *testing.T) {
+	var liqui Liqui
+	liqui.Name = "Liqui"
+	liqui.Permissions = Permissions{Info: true, Trade: true}
+	if warnings := liqui.permissionWarnings(); len(warnings) != 0 {
+		t.Error("Test Failed - liqui permissionWarnings() unexpected warnings", warnings)
+	}
+
+	liqui.Permissions = Permissions{Info: true, Withdraw: true}
+	if warnings := liqui.permissionWarnings(); len(warnings) != 1 {
+		t.Error("Test Failed - liqui permissionWarnings() unexpected warnings", warnings)
+	}
+
+	liqui.TradingEnabled = true
+	if warnings := liqui.permissionWarnings(); len(warnings) != 2 {
+		t.Error("Test Failed - liqui permissionWarnings() unexpected warnings", warnings)
+	}
+
+	liqui.AllowWithdrawPermission = true
+	if warnings := liqui.permissionWarnings(); len(warnings) != 1 {
+		t.Error("Test Failed - liqui permissionWarnings() unexpected warnings", warnings)
+	}
+}
+
+func TestSetupInvalidProxy(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	liquiConfig, err := cfg.GetExchangeConfig("Liqui")
+	if err != nil {
+		t.Fatal("Test Failed - liqui Setup() init error", err)
+	}
+
+	var liqui Liqui
+	liqui.SetDefaults()
+	liquiConfig.Enabled = true
+	liquiConfig.ProxyAddress = ":invalid"
+	if err = liqui.Setup(liquiConfig); err == nil {
+		t.Error("Test Failed - liqui Setup() returned nil error on invalid proxy address")
+	}
+}
+
+func TestSetupTradingEnabled(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	liquiConfig, err := cfg.GetExchangeConfig("Liqui")
+	if err != nil {
+		t.Fatal("Test Failed - liqui Setup() init error", err)
+	}
+
+	var liqui Liqui
+	liqui.SetDefaults()
+	liquiConfig.Enabled = true
+	liquiConfig.TradingEnabled = nil
+	if err = liqui.Setup(liquiConfig); err != nil {
+		t.Fatal("Test Failed - liqui Setup() error", err)
+	}
+	if !liqui.TradingEnabled {
+		t.Error("Test Failed - liqui Setup() disabled trading when unset")
+	}
+
+	disabled := false
+	liqui.SetDefaults()
+	liquiConfig.TradingEnabled = &disabled
+	if err = liqui.Setup(liquiConfig); err != nil {
+		t.Fatal("Test Failed - liqui Setup() error", err)
+	}
+	if liqui.TradingEnabled {
+		t.Error("Test Failed - liqui Setup() enabled trading when disabled")
+	}
+}
+
+func TestWithdrawExchangeFunds(t *testing.T) {
+	_, err := l.WithdrawExchangeFunds(exchange.WithdrawalRequest{Type: exchange.FiatWithdrawal})
+	if err != exchange.ErrWithdrawTypeNotSupported {
+		t.Error("Test Failed - liqui WithdrawExchangeFunds() expected not supported error, received", err)
+	}
+
+	_, err = l.WithdrawExchangeFunds(exchange.WithdrawalRequest{
+		Type:     exchange.CryptoWithdrawal,
+		Currency: "BTC",
+		Amount:   1,
+	})
+	if err == nil {
+		t.Error("Test Failed - liqui WithdrawExchangeFunds() returned nil error without an address")
+	}
+}
+
+func TestWithdrawCoinsMemoRequired(t *testing.T) {
+	_, err := l.WithdrawCoins("xrp", 1337, "someaddr", "")
+	if err == nil {
+		t.Error("Test Failed - liqui WithdrawCoins() returned nil error without a memo")
+	}
+}
+
+func TestWithdrawCoinsBlockedAddress(t *testing.T) {
+	var liqui Liqui
+	liqui.SetWithdrawalAddressAllowlist(map[string][]string{"BTC": {"allowed"}})
+
+	_, err := liqui.WithdrawCryptoExchangeFunds("someaddr", "btc", 1)
+	if _, ok := err.(*exchange.BlockedAddressError); !ok {
+		t.Error("Test Failed - liqui WithdrawCryptoExchangeFunds() expected blocked address error, received", err)
+	}
+}
+
+func TestCancelAllOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("method") {
+		case liquiActiveOrders:
+			fmt.Fprint(w, `{"2":{"pair":"eth_btc"},"1":{"pair":"eth_btc"},"3":{"pair":"ltc_btc"}}`)
+		case liquiCancelOrder:
+			if r.Form.Get("order_id") == "2" {
+				fmt.Fprint(w, `{"error":"bad status"}`)
+				return
+			}
+			fmt.Fprintf(w, `{"order_id":%s}`, r.Form.Get("order_id"))
+		}
+	}))
+	defer server.Close()
+
+	var liqui Liqui
+	liqui.SetDefaults()
+	liqui.AuthenticatedAPISupport = true
+	liqui.SetAPIKeys("key", "secret", "", false)
+	liqui.APIUrlSecondary = server.URL
+	liqui.SetRateLimit(true, time.Millisecond, 100)
+	liqui.SetRateLimit(false, time.Millisecond, 100)
+
+	resp, err := liqui.CancelAllOrders("")
+	if err != nil {
+		t.Fatal("Test Failed - liqui CancelAllOrders() error", err)
+	}
+
+	if len(resp.Cancelled) != 2 || resp.Cancelled[0] != 1 || resp.Cancelled[1] != 3 {
+		t.Error("Test Failed - liqui CancelAllOrders() unexpected cancelled orders", resp.Cancelled)
+	}
+
+	if len(resp.Failed) != 1 || resp.Failed[2] == nil {
+		t.Error("Test Failed - liqui CancelAllOrders() unexpected failed orders", resp.Failed)
+	}
+
+	if err = liqui.CancelAllExchangeOrders(); err == nil {
+		t.Error("Test Failed - liqui CancelAllExchangeOrders() returned nil error with failed cancellations")
+	}
+}
+
+func TestTradeLoadsPairInfo(t *testing.T) {
+	var rate string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if common.StringContains(r.URL.Path, liquiInfo) {
+			fmt.Fprint(w, `{"pairs":{"eth_btc":{"decimal_places":5}}}`)
+			return
+		}
+		r.ParseForm()
+		rate = r.Form.Get("rate")
+		fmt.Fprint(w, `{"order_id":1337}`)
+	}))
+	defer server.Close()
+
+	var liqui Liqui
+	liqui.SetDefaults()
+	liqui.AuthenticatedAPISupport = true
+	liqui.SetAPIKeys("key", "secret", "", false)
+	liqui.APIUrl = server.URL
+	liqui.APIUrlSecondary = server.URL
+	liqui.SetRateLimit(true, time.Millisecond, 100)
+	liqui.SetRateLimit(false, time.Millisecond, 100)
+
+	id, err := liqui.Trade("eth_btc", "buy", 1, 0.0123456)
+	if err != nil {
+		t.Fatal("Test Failed - liqui Trade() error", err)
+	}
+	if id != 1337 || rate != "0.01235" {
+		t.Errorf("Test Failed - liqui Trade() unexpected order %d at rate %s", id, rate)
+	}
+
+	rate = ""
+	_, err = liqui.Trade("ltc_btc", "buy", 1, 0.0123456)
+	if err == nil || rate != "" {
+		t.Error("Test Failed - liqui Trade() placed an order without pair info")
+	}
+}
+
+func TestCancelAllOrdersNoOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("method") == liquiActiveOrders {
+			fmt.Fprint(w, `{"success":0,"error":"no orders"}`)
+			return
+		}
+		t.Error("Test Failed - liqui CancelAllOrders() unexpected request", r.Form.Get("method"))
+	}))
+	defer server.Close()
+
+	var liqui Liqui
+	liqui.SetDefaults()
+	liqui.AuthenticatedAPISupport = true
+	liqui.SetAPIKeys("key", "secret", "", false)
+	liqui.APIUrlSecondary = server.URL
+	liqui.SetRateLimit(true, time.Millisecond, 100)
+	liqui.SetRateLimit(false, time.Millisecond, 100)
+
+	resp, err := liqui.CancelAllOrders("")
+	if err != nil {
+		t.Fatal("Test Failed - liqui CancelAllOrders() error", err)
+	}
+
+	if len(resp.Cancelled) != 0 || len(resp.Failed) != 0 {
+		t.Errorf("Test Failed - liqui CancelAllOrders() unexpected result %+v", resp)
+	}
+
+	if err = liqui.CancelAllExchangeOrders(); err != nil {
+		t.Error("Test Failed - liqui CancelAllExchangeOrders() error", err)
+	}
+}
+
+func TestDecodeActiveOrders(t *testing.T) {
+	result := make(map[string]ActiveOrders)
+	err := decodeActiveOrders([]byte(`{"success":0,"error":"invalid pair"}`), result)
+	if err == nil {
+		t.Error("Test Failed - liqui decodeActiveOrders() expected error")
+	}
+
+	err = decodeActiveOrders([]byte(`{"1":{"pair":"eth_btc"}}`), result)
+	if err != nil || result["1"].Pair != "eth_btc" {
+		t.Errorf("Test Failed - liqui decodeActiveOrders() unexpected result %+v %v", result, err)
+	}
+}
+
+func TestCachedTickersConcurrentAccess(t *testing.T) {
+	var liqui Liqui
+	liqui.SetDefaults()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			liqui.updateTickers(map[string]Ticker{"eth_btc": {Last: float64(i)}})
+		}(i)
+		go func() {
+			defer wg.Done()
+			liqui.GetCachedTicker("eth_btc")
+			liqui.GetCachedTickers()
+		}()
+	}
+	wg.Wait()
+
+	if _, ok := liqui.GetCachedTicker("eth_btc"); !ok {
+		t.Error("Test Failed - liqui GetCachedTicker() ticker not found")
+	}
+
+	tickers := liqui.GetCachedTickers()
+	tickers["ltc_btc"] = Ticker{}
+	if _, ok := liqui.GetCachedTicker("ltc_btc"); ok {
+		t.Error("Test Failed - liqui GetCachedTickers() returned the underlying map")
+	}
+}
+
+func TestPairInfoConcurrentAccess(t *testing.T) {
+	var liqui Liqui
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			liqui.SetInfo(Info{Pairs: map[string]PairData{"eth_btc": {DecimalPlaces: i}}})
+		}(i)
+		go func() {
+			defer wg.Done()
+			liqui.GetPairInfo("eth_btc")
+			liqui.GetAvailablePairs(true)
+			liqui.RoundOrderPrecision("eth_btc", 1, 1)
+		}()
+	}
+	wg.Wait()
+
+	if _, ok := liqui.GetPairInfo("eth_btc"); !ok {
+		t.Error("Test Failed - liqui GetPairInfo() pair not found")
+	}
+}
+
+func TestRefreshInfoUntilStopped(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `{"pairs":{"eth_btc":{"decimal_places":5}}}`)
+	}))
+	defer server.Close()
+
+	var liqui Liqui
+	liqui.SetDefaults()
+	liqui.APIUrl = server.URL
+	liqui.SetRateLimit(false, time.Millisecond, 100)
+
+	done := make(chan struct{})
+	go func() {
+		liqui.refreshInfoUntilStopped(time.Millisecond)
+		close(done)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	if atomic.LoadInt32(&requests) != 0 {
+		t.Error("Test Failed - liqui refreshInfoUntilStopped() refreshed while disabled")
+	}
+
+	liqui.SetEnabled(true)
+	for i := 0; i < 500; i++ {
+		if _, ok := liqui.GetPairInfo("eth_btc"); ok {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, ok := liqui.GetPairInfo("eth_btc"); !ok {
+		t.Error("Test Failed - liqui refreshInfoUntilStopped() did not refresh info")
+	}
+
+	liqui.Stop()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Error("Test Failed - liqui refreshInfoUntilStopped() did not return after Stop")
+	}
+}
+
+func TestRunReturnsWithInfoRefresh(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `{"pairs":{"eth_btc":{"decimal_places":5}}}`)
+	}))
+	defer server.Close()
+
+	var liqui Liqui
+	liqui.SetDefaults()
+	liqui.APIUrl = server.URL
+	liqui.DisableAutoPairUpdates = true
+	liqui.InfoRefreshInterval = time.Millisecond
+	liqui.SetRateLimit(false, time.Millisecond, 100)
+	liqui.SetEnabled(true)
+	defer liqui.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		liqui.Run()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Test Failed - liqui Run() did not return with an info refresh interval set")
+	}
+
+	for i := 0; i < 500 && atomic.LoadInt32(&requests) < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&requests) < 2 {
+		t.Error("Test Failed - liqui Run() did not start the info refresher")
+	}
+}
+
+func TestSubmitOrderPostOnly(t *testing.T) {
+	_, err := l.SubmitOrder(exchange.OrderSubmission{
+		Pair:     pair.NewCurrencyPair("ETH", "BTC"),
+		Side:     exchange.OrderSideBuy(),
+		Type:     exchange.OrderTypeLimit(),
+		Amount:   1,
+		Price:    1,
+		PostOnly: true,
+	})
+	if err != exchange.ErrPostOnlyNotSupported {
+		t.Error("Test Failed - liqui SubmitOrder() expected post only not supported error, received", err)
+	}
+}
+
+func TestSubmitOrderTimeInForce(t *testing.T) {
+	for _, tif := range []exchange.TimeInForce{exchange.ImmediateOrCancel, exchange.FillOrKill} {
+		_, err := l.SubmitOrder(exchange.OrderSubmission{
+			Pair:        pair.NewCurrencyPair("ETH", "BTC"),
+			Side:        exchange.OrderSideBuy(),
+			Type:        exchange.OrderTypeLimit(),
+			Amount:      1,
+			Price:       1,
+			TimeInForce: tif,
+		})
+		if err == nil {
+			t.Errorf("Test Failed - liqui SubmitOrder() returned nil error on %s order", tif)
+		}
+	}
+}
+
+func TestSubmitOrderStopLimit(t *testing.T) {
+	_, err := l.SubmitOrder(exchange.OrderSubmission{
+		Pair:      pair.NewCurrencyPair("ETH", "BTC"),
+		Side:      exchange.OrderSideSell(),
+		Type:      exchange.OrderTypeStopLimit(),
+		Amount:    1,
+		Price:     1,
+		StopPrice: 1.1,
+	})
+	if err != exchange.ErrOrderTypeNotSupported {
+		t.Error("Test Failed - liqui SubmitOrder() expected order type not supported error, received", err)
+	}
+}
+
+func TestValidateEnabledPairs(t *testing.T) {
+	var liqui Liqui
+	liqui.Name = "Liqui"
+	liqui.EnabledPairs = []string{"ETH_BTC", "XEM_BTC"}
+	liqui.SetInfo(Info{Pairs: map[string]PairData{
+		"eth_btc": {},
+		"xem_btc": {Hidden: 1},
+	}})
+
+	err := liqui.ValidateEnabledPairs()
+	unavailable, ok := err.(*exchange.UnavailablePairsError)
+	if !ok {
+		t.Fatal("Test Failed - liqui ValidateEnabledPairs() expected unavailable pairs error, received", err)
+	}
+
+	if len(unavailable.Pairs) != 1 || unavailable.Pairs[0] != "XEM_BTC" {
+		t.Error("Test Failed - liqui ValidateEnabledPairs() unexpected pairs", unavailable.Pairs)
+	}
+}
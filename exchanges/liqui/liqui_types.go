@@ -48,28 +48,31 @@ type Trades struct {
 	Timestamp int64   `json:"timestamp"`
 }
 
+// Permissions holds the privileges granted to an API key
+type Permissions struct {
+	Info     bool `json:"info"`
+	Trade    bool `json:"trade"`
+	Withdraw bool `json:"withdraw"`
+}
+
 // AccountInfo contains full account details information
 type AccountInfo struct {
-	Funds  map[string]float64 `json:"funds"`
-	Rights struct {
-		Info     bool `json:"info"`
-		Trade    bool `json:"trade"`
-		Withdraw bool `json:"withdraw"`
-	} `json:"rights"`
-	ServerTime       float64 `json:"server_time"`
-	TransactionCount int     `json:"transaction_count"`
-	OpenOrders       int     `json:"open_orders"`
-	Success          int     `json:"success"`
-	Error            string  `json:"error"`
+	Funds            map[string]float64 `json:"funds"`
+	Rights           Permissions        `json:"rights"`
+	ServerTime       float64            `json:"server_time"`
+	TransactionCount int                `json:"transaction_count"`
+	OpenOrders       int                `json:"open_orders"`
+	Success          int                `json:"success"`
+	Error            string             `json:"error"`
 }
 
 // ActiveOrders holds active order information
 type ActiveOrders struct {
 	Pair             string  `json:"pair"`
-	Type             string  `json:"sell"`
+	Type             string  `json:"type"`
 	Amount           float64 `json:"amount"`
 	Rate             float64 `json:"rate"`
-	TimestampCreated float64 `json:"time_created"`
+	TimestampCreated float64 `json:"timestamp_created"`
 	Status           int     `json:"status"`
 	Success          int     `json:"success"`
 	Error            string  `json:"error"`
@@ -78,11 +81,11 @@ type ActiveOrders struct {
 // OrderInfo holds specific order information
 type OrderInfo struct {
 	Pair             string  `json:"pair"`
-	Type             string  `json:"sell"`
+	Type             string  `json:"type"`
 	StartAmount      float64 `json:"start_amount"`
 	Amount           float64 `json:"amount"`
 	Rate             float64 `json:"rate"`
-	TimestampCreated float64 `json:"time_created"`
+	TimestampCreated float64 `json:"timestamp_created"`
 	Status           int     `json:"status"`
 	Success          int     `json:"success"`
 	Error            string  `json:"error"`
@@ -90,17 +93,24 @@ type OrderInfo struct {
 
 // CancelOrder holds cancelled order information
 type CancelOrder struct {
-	OrderID float64            `json:"order_id"`
+	OrderID int64              `json:"order_id"`
 	Funds   map[string]float64 `json:"funds"`
 	Success int                `json:"success"`
 	Error   string             `json:"error"`
 }
 
+// CancelAllOrdersResponse summarises the orders cancelled by CancelAllOrders
+// and the error for each order which failed to cancel
+type CancelAllOrdersResponse struct {
+	Cancelled []int64
+	Failed    map[int64]error
+}
+
 // Trade holds trading information
 type Trade struct {
 	Received float64            `json:"received"`
 	Remains  float64            `json:"remains"`
-	OrderID  float64            `json:"order_id"`
+	OrderID  int64              `json:"order_id"`
 	Funds    map[string]float64 `json:"funds"`
 	Success  int                `json:"success"`
 	Error    string             `json:"error"`
@@ -112,7 +122,7 @@ type TradeHistory struct {
 	Type      string  `json:"type"`
 	Amount    float64 `json:"amount"`
 	Rate      float64 `json:"rate"`
-	OrderID   float64 `json:"order_id"`
+	OrderID   int64   `json:"order_id"`
 	MyOrder   int     `json:"is_your_order"`
 	Timestamp float64 `json:"timestamp"`
 	Success   int     `json:"success"`
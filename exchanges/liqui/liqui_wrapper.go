@@ -2,8 +2,11 @@ package liqui
 
 import (
 	"errors"
-	"log"
+	"fmt"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -16,28 +19,69 @@ import (
 func (l *Liqui) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
 	go func() {
-		l.Run()
+		l.RunRecovered(l.Run)
 		wg.Done()
 	}()
 }
 
 // Run implements the Liqui wrapper
 func (l *Liqui) Run() {
-	if l.Verbose {
-		log.Printf("%s polling delay: %ds.\n", l.GetName(), l.RESTPollingDelay)
-		log.Printf("%s %d currencies enabled: %s.\n", l.GetName(), len(l.EnabledPairs), l.EnabledPairs)
-	}
+	l.LogDebugf("polling delay: %s.", l.GetRESTPollingDelay())
+	l.LogDebugf("%d currencies enabled: %s.", len(l.EnabledPairs), l.EnabledPairs)
 
-	var err error
-	l.Info, err = l.GetInfo()
+	err := l.RefreshInfo()
 	if err != nil {
-		log.Printf("%s Unable to fetch info.\n", l.GetName())
+		l.LogErrorf("Unable to fetch info %s.", err)
 	} else {
-		exchangeProducts := l.GetAvailablePairs(true)
-		err = l.UpdateCurrencies(exchangeProducts, false, false)
+		pairsErr := l.ValidateEnabledPairs()
+		if pairsErr != nil {
+			l.LogWarnf("%s.", pairsErr)
+		}
+
+		if l.AutoPairUpdatesEnabled() {
+			l.updateAvailableCurrencies(pairsErr != nil)
+		} else {
+			l.LogInfof("auto pair updates disabled, skipping currency update.")
+		}
+	}
+
+	if l.GetAuthenticatedAPISupport() {
+		warnings, err := l.ValidatePermissions()
 		if err != nil {
-			log.Printf("%s Failed to get config.\n", l.GetName())
+			l.LogWarnf("Unable to validate API key permissions %s.", err)
 		}
+		for x := range warnings {
+			l.LogWarnf("%s.", warnings[x])
+		}
+	}
+
+	if l.InfoRefreshInterval > 0 {
+		l.LogDebugf("info refresh interval: %s.", l.InfoRefreshInterval)
+		go l.refreshInfoUntilStopped(l.InfoRefreshInterval)
+	}
+}
+
+// updateAvailableCurrencies updates the available currencies from the currency
+// pairs cache, forcing an update if pairs have been removed. Setting refresh
+// rebuilds the cache from the stored pair information, which is done when an
+// enabled pair fails validation
+func (l *Liqui) updateAvailableCurrencies(refresh bool) {
+	exchangeCurrencies, err := l.GetCachedExchangeCurrencies(l.GetExchangeCurrencies, refresh)
+	if err != nil {
+		l.LogErrorf("Failed to get available symbols %s.", err)
+		return
+	}
+
+	forceUpdate := false
+	removedPairs := l.GetRemovedPairs(exchangeCurrencies)
+	if len(removedPairs) > 0 {
+		l.LogWarnf("contains pairs no longer offered by the exchange: %s, forcing upgrade of available currencies.",
+			removedPairs)
+		forceUpdate = true
+	}
+	err = l.UpdateCurrencies(exchangeCurrencies, false, forceUpdate)
+	if err != nil {
+		l.LogErrorf("Failed to update available currencies %s.", err)
 	}
 }
 
@@ -127,6 +171,7 @@ func (l *Liqui) GetExchangeAccountInfo() (exchange.AccountInfo, error) {
 		exchangeCurrency.CurrencyName = common.StringToUpper(x)
 		exchangeCurrency.TotalValue = y
 		exchangeCurrency.Hold = 0
+		exchangeCurrency.Available = y
 		response.Currencies = append(response.Currencies, exchangeCurrency)
 	}
 
@@ -144,62 +189,311 @@ func (l *Liqui) GetExchangeFundTransferHistory() ([]exchange.FundHistory, error)
 func (l *Liqui) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
-	return resp, errors.New("trade history not yet implemented")
+	return resp, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeServerTime returns the Liqui server time and updates the server time
+// offset used for nonce generation
+func (l *Liqui) GetExchangeServerTime() (time.Time, error) {
+	info, err := l.GetInfo()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	serverTime := time.Unix(info.ServerTime, 0)
+	l.SetServerTimeOffset(serverTime)
+	return serverTime, nil
+}
+
+// Ping checks that the Liqui public API is reachable
+func (l *Liqui) Ping() error {
+	_, err := l.GetInfo()
+	return exchange.NewPingError(l.Name, err)
+}
+
+// AuthenticatedPing checks that Liqui accepts the API credentials
+func (l *Liqui) AuthenticatedPing() error {
+	info, err := l.GetAccountInfo()
+	if err == nil && info.Error != "" {
+		err = exchange.ClassifyError(errors.New(info.Error))
+	}
+	return exchange.NewPingError(l.Name, err)
+}
+
+// GetHistoricCandles returns candlestick data for a currency pair between the
+// start and end times at the specified interval
+func (l *Liqui) GetHistoricCandles(currencyPair pair.CurrencyPair, start, end time.Time, interval time.Duration) (exchange.Candles, error) {
+	return nil, exchange.ErrNotYetImplemented
 }
 
 // SubmitExchangeOrder submits a new order
 func (l *Liqui) SubmitExchangeOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (int64, error) {
-	return 0, errors.New("not yet implemented")
+	resp, err := l.SubmitOrder(exchange.OrderSubmission{
+		Pair:     p,
+		Side:     side,
+		Type:     orderType,
+		Amount:   amount,
+		Price:    price,
+		ClientID: clientID,
+	})
+	return resp.OrderID, err
+}
+
+// SubmitOrder submits a new order and returns the order ID and status. Liqui
+// only supports good till cancelled limit orders and cannot guarantee post
+// only placement
+func (l *Liqui) SubmitOrder(req exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var resp exchange.SubmitOrderResponse
+	if err := req.Validate(); err != nil {
+		return resp, err
+	}
+
+	if req.PostOnly {
+		return resp, exchange.ErrPostOnlyNotSupported
+	}
+
+	if tif := req.GetTimeInForce(); tif != exchange.GoodTillCancelled {
+		return resp, fmt.Errorf("%s time in force %s not supported", l.Name, tif)
+	}
+
+	if req.Type != exchange.OrderTypeLimit() {
+		return resp, exchange.ErrOrderTypeNotSupported
+	}
+
+	if l.CheckOrderBalance {
+		err := l.checkOrderBalance(req)
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	orderID, err := l.Trade(exchange.FormatExchangeCurrency(l.Name, req.Pair).String(),
+		common.StringToLower(req.Side.String()),
+		req.Amount,
+		req.Price)
+	if err != nil {
+		l.SendEvent(exchange.NewSubmitOrderEvent(req, resp, err))
+		return resp, err
+	}
+
+	// Liqui returns an order ID of zero when the order was fully matched
+	resp.OrderID = orderID
+	resp.Status = "open"
+	if orderID == 0 {
+		resp.Status = "filled"
+	}
+	l.SendEvent(exchange.NewSubmitOrderEvent(req, resp, nil))
+	return resp, nil
+}
+
+// checkOrderBalance checks the available balance covers the order, buy orders
+// spend the second currency of the pair and sell orders the first
+func (l *Liqui) checkOrderBalance(req exchange.OrderSubmission) error {
+	info, err := l.GetExchangeAccountInfo()
+	if err != nil {
+		return err
+	}
+
+	if req.Side == exchange.OrderSideBuy() {
+		return exchange.CheckAvailableBalance(info, req.Pair.SecondCurrency.String(),
+			req.Amount*req.Price)
+	}
+	return exchange.CheckAvailableBalance(info, req.Pair.FirstCurrency.String(),
+		req.Amount)
 }
 
 // ModifyExchangeOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (l *Liqui) ModifyExchangeOrder(orderID int64, action exchange.ModifyOrder) (int64, error) {
-	return 0, errors.New("not yet implemented")
+	return 0, exchange.ErrNotYetImplemented
 }
 
 // CancelExchangeOrder cancels an order by its corresponding ID number
 func (l *Liqui) CancelExchangeOrder(orderID int64) error {
-	return errors.New("not yet implemented")
+	return exchange.ErrNotYetImplemented
 }
 
-// CancelAllExchangeOrders cancels all orders associated with a currency pair
+// CancelAllExchangeOrders cancels all active orders across all currency pairs
 func (l *Liqui) CancelAllExchangeOrders() error {
-	return errors.New("not yet implemented")
+	resp, err := l.CancelAllOrders("")
+	if err == nil && len(resp.Failed) > 0 {
+		err = fmt.Errorf("%s cancelled %d orders, failed to cancel %d orders",
+			l.Name, len(resp.Cancelled), len(resp.Failed))
+	}
+
+	l.SendEvent(exchange.ActionEvent{Action: exchange.ActionCancelAllOrders, Err: err})
+	return err
 }
 
 // GetExchangeOrderInfo returns information on a current open order
 func (l *Liqui) GetExchangeOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	var orderDetail exchange.OrderDetail
-	return orderDetail, errors.New("not yet implemented")
+	return orderDetail, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeOpenOrders returns all open orders for a currency pair, or across
+// all currency pairs if the pair is empty
+func (l *Liqui) GetExchangeOpenOrders(currencyPair pair.CurrencyPair) ([]exchange.OrderDetail, error) {
+	var resp map[string]ActiveOrders
+	var err error
+	if currencyPair.Empty() {
+		resp, err = l.GetAllActiveOrders()
+	} else {
+		resp, err = l.GetActiveOrders(exchange.FormatExchangeCurrency(l.Name, currencyPair).String())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return l.convertActiveOrders(resp)
+}
+
+// GetExchangeOrderHistory returns filled orders for a currency pair, or across
+// all currency pairs if the pair is empty, between the start and end times.
+// Individual fills are aggregated into a single order detail per order. The
+// history is built from the account's trades, so orders cancelled without a
+// fill are not included as Liqui has no closed order endpoint
+func (l *Liqui) GetExchangeOrderHistory(currencyPair pair.CurrencyPair, start, end time.Time) ([]exchange.OrderDetail, error) {
+	var currency string
+	if !currencyPair.Empty() {
+		currency = exchange.FormatExchangeCurrency(l.Name, currencyPair).String()
+	}
+
+	resp, err := l.GetTradeHistoryRange(currency, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return exchange.AggregateFills(l.convertTradeHistory(resp)), nil
+}
+
+// convertTradeHistory maps Liqui trades to the exchange order detail type, one
+// per fill, sorted by fill time
+func (l *Liqui) convertTradeHistory(resp map[string]TradeHistory) []exchange.OrderDetail {
+	orders := make([]exchange.OrderDetail, 0, len(resp))
+	for _, trade := range resp {
+		side := exchange.OrderSideBuy()
+		if trade.Type == "sell" {
+			side = exchange.OrderSideSell()
+		}
+
+		currencyPair := pair.NewCurrencyPairDelimiter(common.StringToUpper(trade.Pair), "_")
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:      l.Name,
+			ID:            trade.OrderID,
+			BaseCurrency:  currencyPair.FirstCurrency.String(),
+			QuoteCurrency: currencyPair.SecondCurrency.String(),
+			OrderSide:     string(side),
+			OrderType:     string(exchange.OrderTypeLimit()),
+			CreationTime:  int64(trade.Timestamp),
+			Status:        "filled",
+			Price:         trade.Rate,
+			Amount:        trade.Amount,
+		})
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreationTime < orders[j].CreationTime
+	})
+	return orders
+}
+
+// convertActiveOrders maps Liqui active orders keyed by order ID to the
+// exchange order detail type, sorted by order ID
+func (l *Liqui) convertActiveOrders(resp map[string]ActiveOrders) ([]exchange.OrderDetail, error) {
+	orders := make([]exchange.OrderDetail, 0, len(resp))
+	for id, order := range resp {
+		orderID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		side := exchange.OrderSideBuy()
+		if order.Type == "sell" {
+			side = exchange.OrderSideSell()
+		}
+
+		currencyPair := pair.NewCurrencyPairDelimiter(common.StringToUpper(order.Pair), "_")
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:      l.Name,
+			ID:            orderID,
+			BaseCurrency:  currencyPair.FirstCurrency.String(),
+			QuoteCurrency: currencyPair.SecondCurrency.String(),
+			OrderSide:     string(side),
+			OrderType:     string(exchange.OrderTypeLimit()),
+			CreationTime:  int64(order.TimestampCreated),
+			Status:        "open",
+			Price:         order.Rate,
+			Amount:        order.Amount,
+			OpenVolume:    order.Amount,
+		})
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].ID < orders[j].ID
+	})
+	return orders, nil
 }
 
 // GetExchangeDepositAddress returns a deposit address for a specified currency
 func (l *Liqui) GetExchangeDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
 }
 
 // WithdrawCryptoExchangeFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (l *Liqui) WithdrawCryptoExchangeFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return l.WithdrawExchangeFunds(exchange.WithdrawalRequest{
+		Type:     exchange.CryptoWithdrawal,
+		Currency: cryptocurrency,
+		Amount:   amount,
+		Address:  address,
+	})
 }
 
 // WithdrawFiatExchangeFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (l *Liqui) WithdrawFiatExchangeFunds(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
 }
 
 // WithdrawFiatExchangeFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (l *Liqui) WithdrawFiatExchangeFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
+}
+
+// WithdrawExchangeFunds submits a withdrawal request and returns the
+// withdrawal transaction ID. Only crypto withdrawals are supported
+func (l *Liqui) WithdrawExchangeFunds(req exchange.WithdrawalRequest) (string, error) {
+	if req.Type != exchange.CryptoWithdrawal {
+		return "", exchange.ErrWithdrawTypeNotSupported
+	}
+
+	err := req.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := l.WithdrawCoins(req.Currency.String(), req.Amount, req.Address, req.AddressTag)
+	if err == nil && resp.Error != "" {
+		err = errors.New(resp.Error)
+	}
+
+	if err != nil {
+		l.SendEvent(exchange.NewWithdrawalEvent(req, "", err))
+		return "", err
+	}
+
+	withdrawalID := strconv.FormatInt(resp.TID, 10)
+	l.SendEvent(exchange.NewWithdrawalEvent(req, withdrawalID, nil))
+	return withdrawalID, nil
 }
 
-// GetWebsocket returns a pointer to the exchange websocket
+// GetWebsocket returns a pointer to the exchange websocket. Liqui does not
+// offer a websocket API so this always returns a not supported error.
 func (l *Liqui) GetWebsocket() (*exchange.Websocket, error) {
-	return nil, errors.New("not yet implemented")
+	return nil, errors.New(exchange.WebsocketNotSupported)
 }
 
 // GetFeeByType returns an estimate of fee based on type of transaction
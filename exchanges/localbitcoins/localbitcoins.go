@@ -134,7 +134,7 @@ func (l *LocalBitcoins) SetDefaults() {
 }
 
 // Setup sets exchange configuration parameters
-func (l *LocalBitcoins) Setup(exch config.ExchangeConfig) {
+func (l *LocalBitcoins) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		l.SetEnabled(false)
 	} else {
@@ -150,21 +150,22 @@ func (l *LocalBitcoins) Setup(exch config.ExchangeConfig) {
 		l.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := l.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = l.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = l.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = l.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetAccountInfo lets you retrieve the public user information on a
@@ -692,7 +693,7 @@ func (l *LocalBitcoins) GetOrderbook(currency string) (Orderbook, error) {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (l *LocalBitcoins) SendHTTPRequest(path string, result interface{}) error {
-	return l.SendPayload("GET", path, nil, nil, result, false, l.Verbose)
+	return l.SendPayload("GET", path, nil, nil, result, false, l.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to
@@ -718,7 +719,7 @@ func (l *LocalBitcoins) SendAuthenticatedHTTPRequest(method, path string, params
 	headers["Apiauth-Signature"] = common.StringToUpper(common.HexEncodeToString(hmac))
 	headers["Content-Type"] = "application/x-www-form-urlencoded"
 
-	if l.Verbose {
+	if l.IsVerbose() {
 		log.Printf("Sending POST request to `%s`, path: `%s`, params: `%s`.", l.APIUrl, path, encoded)
 	}
 
@@ -726,7 +727,7 @@ func (l *LocalBitcoins) SendAuthenticatedHTTPRequest(method, path string, params
 		path += "?" + encoded
 	}
 
-	return l.SendPayload(method, l.APIUrl+path, headers, strings.NewReader(encoded), result, true, l.Verbose)
+	return l.SendPayload(method, l.APIUrl+path, headers, strings.NewReader(encoded), result, true, l.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
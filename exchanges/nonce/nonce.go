@@ -6,11 +6,27 @@ import (
 	"time"
 )
 
+// Strategy defines how a nonce is seeded
+type Strategy int
+
+// Nonce strategies, time based strategies seed the nonce from the current time
+// at the given precision and then increment, the counter strategy starts at 1
+const (
+	UnixSeconds Strategy = iota
+	UnixMilliseconds
+	UnixNanoseconds
+	Counter
+)
+
 // Nonce struct holds the nonce value
 type Nonce struct {
 	// Standard nonce
 	n   int64
 	mtx sync.Mutex
+	// Offset between the exchange server time and local time used when
+	// seeding time based nonces
+	offset   time.Duration
+	strategy Strategy
 	// Hash table exclusive exchange specific nonce values
 	boundedCall map[string]int64
 	boundedMtx  sync.Mutex
@@ -45,6 +61,54 @@ func (n *Nonce) Set(val int64) {
 	n.mtx.Unlock()
 }
 
+// SetStrategy sets the strategy used to seed the nonce by Next
+func (n *Nonce) SetStrategy(s Strategy) {
+	n.mtx.Lock()
+	n.strategy = s
+	n.mtx.Unlock()
+}
+
+// Next seeds the nonce using the nonce strategy if it has not been set,
+// otherwise it increments the nonce, and returns the new value
+func (n *Nonce) Next() int64 {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if n.n != 0 {
+		n.n++
+		return n.n
+	}
+
+	now := time.Now().Add(n.offset)
+	switch n.strategy {
+	case UnixMilliseconds:
+		n.n = now.UnixNano() / int64(time.Millisecond)
+	case UnixNanoseconds:
+		n.n = now.UnixNano()
+	case Counter:
+		n.n = 1
+	default:
+		n.n = now.Unix()
+	}
+	return n.n
+}
+
+// SetOffset sets the offset between the exchange server time and local time
+// used when seeding time based nonces
+func (n *Nonce) SetOffset(offset time.Duration) {
+	n.mtx.Lock()
+	n.offset = offset
+	n.mtx.Unlock()
+}
+
+// Now returns the local time adjusted by the server time offset, used to seed
+// time based nonces
+func (n *Nonce) Now() time.Time {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return time.Now().Add(n.offset)
+}
+
 // Returns a string version of the nonce
 func (n *Nonce) String() string {
 	n.mtx.Lock()
@@ -68,10 +132,10 @@ func (n *Nonce) GetValue(exchName string, nanoPrecision bool) Value {
 
 	if n.boundedCall[exchName] == 0 {
 		if nanoPrecision {
-			n.boundedCall[exchName] = time.Now().UnixNano()
+			n.boundedCall[exchName] = n.Now().UnixNano()
 			return Value(n.boundedCall[exchName])
 		}
-		n.boundedCall[exchName] = time.Now().Unix()
+		n.boundedCall[exchName] = n.Now().Unix()
 		return Value(n.boundedCall[exchName])
 	}
 	n.boundedCall[exchName]++
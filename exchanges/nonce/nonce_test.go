@@ -57,6 +57,50 @@ func TestString(t *testing.T) {
 	}
 }
 
+func TestNext(t *testing.T) {
+	var nonce Nonce
+	before := time.Now().Unix()
+	if n := nonce.Next(); n < before || n > time.Now().Unix() {
+		t.Errorf("Test failed. Next() unexpected seconds nonce %d", n)
+	}
+
+	nonce = Nonce{}
+	nonce.SetStrategy(UnixMilliseconds)
+	before = time.Now().UnixNano() / int64(time.Millisecond)
+	if n := nonce.Next(); n < before || n > time.Now().UnixNano()/int64(time.Millisecond) {
+		t.Errorf("Test failed. Next() unexpected milliseconds nonce %d", n)
+	}
+
+	nonce = Nonce{}
+	nonce.SetStrategy(UnixNanoseconds)
+	before = time.Now().UnixNano()
+	if n := nonce.Next(); n < before || n > time.Now().UnixNano() {
+		t.Errorf("Test failed. Next() unexpected nanoseconds nonce %d", n)
+	}
+
+	nonce = Nonce{}
+	nonce.SetStrategy(Counter)
+	if n := nonce.Next(); n != 1 {
+		t.Errorf("Test failed. Next() unexpected counter nonce %d", n)
+	}
+	if n := nonce.Next(); n != 2 {
+		t.Errorf("Test failed. Next() unexpected counter nonce %d", n)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	var nonce Nonce
+	nonce.SetOffset(time.Hour)
+	if nonce.Now().Sub(time.Now()) < time.Minute*59 {
+		t.Error("Test failed. Nonce offset not applied")
+	}
+
+	value := nonce.GetValue("dingdong", false)
+	if int64(value) < time.Now().Add(time.Minute*59).Unix() {
+		t.Errorf("Test failed. GetValue() nonce offset not applied %d", value)
+	}
+}
+
 func TestGetValue(t *testing.T) {
 	var nonce Nonce
 	timeNowNano := strconv.FormatInt(time.Now().UnixNano(), 10)
@@ -106,7 +106,7 @@ func (o *OKCoin) SetDefaults() {
 }
 
 // Setup sets exchange configuration parameters
-func (o *OKCoin) Setup(exch config.ExchangeConfig) {
+func (o *OKCoin) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		o.SetEnabled(false)
 	} else {
@@ -154,23 +154,23 @@ func (o *OKCoin) Setup(exch config.ExchangeConfig) {
 		o.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := o.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = o.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = o.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = o.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = o.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = o.WebsocketSetup(o.WsConnect,
 			exch.Name,
@@ -178,9 +178,10 @@ func (o *OKCoin) Setup(exch config.ExchangeConfig) {
 			okcoinWebsocketURL,
 			o.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetTicker returns the current ticker
@@ -915,7 +916,7 @@ func (o *OKCoin) GetFuturesUserPosition4Fix(symbol, contractType string) {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (o *OKCoin) SendHTTPRequest(path string, result interface{}) error {
-	return o.SendPayload("GET", path, nil, nil, result, false, o.Verbose)
+	return o.SendPayload("GET", path, nil, nil, result, false, o.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request
@@ -931,14 +932,14 @@ func (o *OKCoin) SendAuthenticatedHTTPRequest(method string, v url.Values, resul
 	encoded := v.Encode()
 	path := o.APIUrl + method
 
-	if o.Verbose {
+	if o.IsVerbose() {
 		log.Printf("Sending POST request to %s with params %s\n", path, encoded)
 	}
 
 	headers := make(map[string]string)
 	headers["Content-Type"] = "application/x-www-form-urlencoded"
 
-	return o.SendPayload("POST", path, headers, strings.NewReader(encoded), result, true, o.Verbose)
+	return o.SendPayload("POST", path, headers, strings.NewReader(encoded), result, true, o.IsVerbose())
 }
 
 // SetErrorDefaults sets default error map
@@ -120,7 +120,7 @@ func (o *OKEX) SetDefaults() {
 }
 
 // Setup method sets current configuration details if enabled
-func (o *OKEX) Setup(exch config.ExchangeConfig) {
+func (o *OKEX) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		o.SetEnabled(false)
 	} else {
@@ -137,23 +137,23 @@ func (o *OKEX) Setup(exch config.ExchangeConfig) {
 		o.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := o.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = o.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = o.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = o.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = o.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = o.WebsocketSetup(o.WsConnect,
 			exch.Name,
@@ -161,9 +161,10 @@ func (o *OKEX) Setup(exch config.ExchangeConfig) {
 			okexDefaultWebsocketURL,
 			exch.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetContractPrice returns current contract prices
@@ -895,7 +896,7 @@ func (o *OKEX) GetErrorCode(code interface{}) error {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (o *OKEX) SendHTTPRequest(path string, result interface{}) error {
-	return o.SendPayload("GET", path, nil, nil, result, false, o.Verbose)
+	return o.SendPayload("GET", path, nil, nil, result, false, o.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated http request to a desired
@@ -912,14 +913,14 @@ func (o *OKEX) SendAuthenticatedHTTPRequest(method string, values url.Values, re
 	encoded := values.Encode()
 	path := o.APIUrl + apiVersion + method
 
-	if o.Verbose {
+	if o.IsVerbose() {
 		log.Printf("Sending POST request to %s with params %s\n", path, encoded)
 	}
 
 	headers := make(map[string]string)
 	headers["Content-Type"] = "application/x-www-form-urlencoded"
 
-	return o.SendPayload("POST", path, headers, strings.NewReader(encoded), result, true, o.Verbose)
+	return o.SendPayload("POST", path, headers, strings.NewReader(encoded), result, true, o.IsVerbose())
 }
 
 // SetErrorDefaults sets the full error default list
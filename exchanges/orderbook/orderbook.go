@@ -1,10 +1,15 @@
 package orderbook
 
 import (
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"io"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 )
 
@@ -13,21 +18,24 @@ const (
 	ErrOrderbookForExchangeNotFound = "Ticker for exchange does not exist."
 	ErrPrimaryCurrencyNotFound      = "Error primary currency for orderbook not found."
 	ErrSecondaryCurrencyNotFound    = "Error secondary currency for orderbook not found."
+	ErrOrderbookExpired             = "Error orderbook has expired."
 
-	Spot = "SPOT"
+	Spot   = "SPOT"
+	Margin = "MARGIN"
 )
 
 // Vars for the orderbook package
 var (
 	Orderbooks []Orderbook
 	m          sync.Mutex
+	ttl        time.Duration
 )
 
 // Item stores the amount and price values
 type Item struct {
-	Amount float64
-	Price  float64
-	ID     int64
+	Amount float64 `json:"Amount"`
+	Price  float64 `json:"Price"`
+	ID     int64   `json:"ID"`
 }
 
 // Base holds the fields for the orderbook base
@@ -37,13 +45,13 @@ type Base struct {
 	Bids         []Item            `json:"bids"`
 	Asks         []Item            `json:"asks"`
 	LastUpdated  time.Time         `json:"last_updated"`
-	AssetType    string
+	AssetType    string            `json:"AssetType"`
 }
 
 // Orderbook holds the orderbook information for a currency pair and type
 type Orderbook struct {
-	Orderbook    map[pair.CurrencyItem]map[pair.CurrencyItem]map[string]Base
-	ExchangeName string
+	Orderbook    map[pair.CurrencyItem]map[pair.CurrencyItem]map[string]Base `json:"orderbook"`
+	ExchangeName string                                                      `json:"exchangeName"`
 }
 
 // CalculateTotalBids returns the total amount of bids and the total orderbook
@@ -77,23 +85,65 @@ func (o *Base) Update(Bids, Asks []Item) {
 	o.LastUpdated = time.Now()
 }
 
+// ExportCSV writes every orderbook level to w as CSV price,amount,side rows,
+// bids first followed by asks. An empty orderbook writes only the header
+func (o *Base) ExportCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"price", "amount", "side"})
+	if err != nil {
+		return err
+	}
+
+	levels := []struct {
+		side  string
+		items []Item
+	}{
+		{"bid", o.Bids},
+		{"ask", o.Asks},
+	}
+	for _, level := range levels {
+		for _, x := range level.items {
+			err = writer.Write([]string{
+				strconv.FormatFloat(x.Price, 'f', -1, 64),
+				strconv.FormatFloat(x.Amount, 'f', -1, 64),
+				level.side,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
 // GetOrderbook checks and returns the orderbook given an exchange name and
 // currency pair if it exists
 func GetOrderbook(exchange string, p pair.CurrencyPair, orderbookType string) (Base, error) {
-	orderbook, err := GetOrderbookByExchange(exchange)
-	if err != nil {
-		return Base{}, err
+	m.Lock()
+	defer m.Unlock()
+	orderbook := findOrderbook(exchange)
+	if orderbook == nil {
+		return Base{}, errors.New(ErrOrderbookForExchangeNotFound)
 	}
 
-	if !FirstCurrencyExists(exchange, p.FirstCurrency) {
+	seconds, ok := orderbook.Orderbook[p.FirstCurrency]
+	if !ok {
 		return Base{}, errors.New(ErrPrimaryCurrencyNotFound)
 	}
 
-	if !SecondCurrencyExists(exchange, p) {
+	types, ok := seconds[p.SecondCurrency]
+	if !ok {
 		return Base{}, errors.New(ErrSecondaryCurrencyNotFound)
 	}
 
-	return orderbook.Orderbook[p.FirstCurrency][p.SecondCurrency][orderbookType], nil
+	result, ok := types[orderbookType]
+	if ok && isExpired(result.LastUpdated, time.Now()) {
+		remove(exchange, p, orderbookType)
+		return Base{}, errors.New(ErrOrderbookExpired)
+	}
+	return result, nil
 }
 
 // GetOrderbookByExchange returns an exchange orderbook
@@ -166,26 +216,170 @@ func ProcessOrderbook(exchangeName string, p pair.CurrencyPair, orderbookNew Bas
 	orderbookNew.CurrencyPair = p.Pair().String()
 	orderbookNew.LastUpdated = time.Now()
 
-	orderbook, err := GetOrderbookByExchange(exchangeName)
-	if err != nil {
-		CreateNewOrderbook(exchangeName, p, orderbookNew, orderbookType)
-		return
+	m.Lock()
+	setOrderbook(getOrCreateOrderbook(exchangeName), p, orderbookNew, orderbookType)
+	m.Unlock()
+}
+
+// findOrderbook returns the cached Orderbook for an exchange, or nil if there is
+// none. The returned pointer is only valid while m is held by the caller
+func findOrderbook(exchangeName string) *Orderbook {
+	for x := range Orderbooks {
+		if Orderbooks[x].ExchangeName == exchangeName {
+			return &Orderbooks[x]
+		}
 	}
+	return nil
+}
 
-	if FirstCurrencyExists(exchangeName, p.FirstCurrency) {
-		m.Lock()
-		a := make(map[string]Base)
-		a[orderbookType] = orderbookNew
-		orderbook.Orderbook[p.FirstCurrency][p.SecondCurrency] = a
-		m.Unlock()
-		return
+// getOrCreateOrderbook returns the Orderbook for an exchange, appending a new
+// one to the Orderbooks list if it does not exist. m must be held by the caller
+func getOrCreateOrderbook(exchangeName string) *Orderbook {
+	if orderbook := findOrderbook(exchangeName); orderbook != nil {
+		return orderbook
 	}
 
+	Orderbooks = append(Orderbooks, Orderbook{
+		ExchangeName: exchangeName,
+		Orderbook:    make(map[pair.CurrencyItem]map[pair.CurrencyItem]map[string]Base),
+	})
+	return &Orderbooks[len(Orderbooks)-1]
+}
+
+// setOrderbook stores an orderbook in the Orderbook, creating the currency
+// maps if needed. m must be held by the caller
+func setOrderbook(orderbook *Orderbook, p pair.CurrencyPair, orderbookNew Base, orderbookType string) {
+	if _, ok := orderbook.Orderbook[p.FirstCurrency]; !ok {
+		orderbook.Orderbook[p.FirstCurrency] = make(map[pair.CurrencyItem]map[string]Base)
+	}
+	if _, ok := orderbook.Orderbook[p.FirstCurrency][p.SecondCurrency]; !ok {
+		orderbook.Orderbook[p.FirstCurrency][p.SecondCurrency] = make(map[string]Base)
+	}
+	orderbook.Orderbook[p.FirstCurrency][p.SecondCurrency][orderbookType] = orderbookNew
+}
+
+// SetTTL sets the duration after which a cached orderbook which has not been
+// updated is evicted. A TTL of zero disables eviction
+func SetTTL(d time.Duration) {
 	m.Lock()
-	a := make(map[pair.CurrencyItem]map[string]Base)
-	b := make(map[string]Base)
-	b[orderbookType] = orderbookNew
-	a[p.SecondCurrency] = b
-	orderbook.Orderbook[p.FirstCurrency] = a
+	ttl = d
 	m.Unlock()
 }
+
+// GetTTL returns the duration after which a cached orderbook is evicted
+func GetTTL() time.Duration {
+	m.Lock()
+	defer m.Unlock()
+	return ttl
+}
+
+// Remove removes the cached orderbook for an exchange, currency pair and asset
+// type
+func Remove(exchange string, p pair.CurrencyPair, orderbookType string) error {
+	m.Lock()
+	defer m.Unlock()
+	if !remove(exchange, p, orderbookType) {
+		return fmt.Errorf("%s %s %s orderbook not found", exchange, p.Pair(), orderbookType)
+	}
+	return nil
+}
+
+// EvictExpired removes all cached orderbooks which have not been updated within
+// the TTL and returns the amount evicted
+func EvictExpired() int {
+	m.Lock()
+	defer m.Unlock()
+	if ttl <= 0 {
+		return 0
+	}
+
+	now := time.Now()
+	var evicted int
+	for x := len(Orderbooks) - 1; x >= 0; x-- {
+		exchange := Orderbooks[x].ExchangeName
+		for first, seconds := range Orderbooks[x].Orderbook {
+			for second, types := range seconds {
+				for orderbookType, item := range types {
+					if isExpired(item.LastUpdated, now) {
+						remove(exchange, pair.NewCurrencyPair(first.String(), second.String()), orderbookType)
+						evicted++
+					}
+				}
+			}
+		}
+	}
+	return evicted
+}
+
+// isExpired returns whether a orderbook last updated at lastUpdated has passed
+// the TTL. m must be held by the caller
+func isExpired(lastUpdated, now time.Time) bool {
+	return ttl > 0 && now.Sub(lastUpdated) > ttl
+}
+
+// remove deletes a cached orderbook and any maps left empty by its removal,
+// returning whether it existed. m must be held by the caller
+func remove(exchange string, p pair.CurrencyPair, orderbookType string) bool {
+	for x := range Orderbooks {
+		if Orderbooks[x].ExchangeName != exchange {
+			continue
+		}
+
+		seconds, ok := Orderbooks[x].Orderbook[p.FirstCurrency]
+		if !ok {
+			return false
+		}
+		types, ok := seconds[p.SecondCurrency]
+		if !ok {
+			return false
+		}
+		if _, ok = types[orderbookType]; !ok {
+			return false
+		}
+
+		delete(types, orderbookType)
+		if len(types) == 0 {
+			delete(seconds, p.SecondCurrency)
+		}
+		if len(seconds) == 0 {
+			delete(Orderbooks[x].Orderbook, p.FirstCurrency)
+		}
+		if len(Orderbooks[x].Orderbook) == 0 {
+			Orderbooks = append(Orderbooks[:x], Orderbooks[x+1:]...)
+		}
+		return true
+	}
+	return false
+}
+
+// MarshalOrderbooks returns a JSON snapshot of all cached orderbooks
+func MarshalOrderbooks() ([]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	return common.JSONEncode(Orderbooks)
+}
+
+// UnmarshalOrderbooks loads a JSON snapshot created by MarshalOrderbooks into
+// the cache, keeping the LastUpdated time of each snapshot orderbook
+func UnmarshalOrderbooks(data []byte) error {
+	var snapshot []Orderbook
+	err := common.JSONDecode(data, &snapshot)
+	if err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	for x := range snapshot {
+		orderbook := getOrCreateOrderbook(snapshot[x].ExchangeName)
+		for first, seconds := range snapshot[x].Orderbook {
+			for second, types := range seconds {
+				p := pair.NewCurrencyPair(first.String(), second.String())
+				for orderbookType, base := range types {
+					setOrderbook(orderbook, p, base, orderbookType)
+				}
+			}
+		}
+	}
+	return nil
+}
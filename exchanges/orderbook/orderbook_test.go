@@ -1,7 +1,9 @@
 package orderbook
 
 import (
+	"bytes"
 	"math/rand"
+	"reflect"
 	"strconv"
 	"sync"
 	"testing"
@@ -42,6 +44,33 @@ func TestCalculateTotaAsks(t *testing.T) {
 	}
 }
 
+func TestExportCSV(t *testing.T) {
+	t.Parallel()
+	var base Base
+	var buf bytes.Buffer
+	err := base.ExportCSV(&buf)
+	if err != nil {
+		t.Fatal("Test failed. TestExportCSV error", err)
+	}
+
+	if buf.String() != "price,amount,side\n" {
+		t.Fatalf("Test failed. TestExportCSV unexpected empty orderbook output %q", buf.String())
+	}
+
+	base.Bids = []Item{{Price: 100, Amount: 10}, {Price: 99.5, Amount: 0.25}}
+	base.Asks = []Item{{Price: 101, Amount: 1e-8}}
+	buf.Reset()
+	err = base.ExportCSV(&buf)
+	if err != nil {
+		t.Fatal("Test failed. TestExportCSV error", err)
+	}
+
+	expected := "price,amount,side\n100,10,bid\n99.5,0.25,bid\n101,0.00000001,ask\n"
+	if buf.String() != expected {
+		t.Fatalf("Test failed. TestExportCSV unexpected output %q", buf.String())
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	t.Parallel()
 	currency := pair.NewCurrencyPair("BTC", "USD")
@@ -328,3 +357,144 @@ func TestProcessOrderbook(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestRemove(t *testing.T) {
+	Orderbooks = []Orderbook{}
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	ltcbtc := pair.NewCurrencyPair("LTC", "BTC")
+	ProcessOrderbook("Exchange", btcusd, Base{}, Spot)
+	ProcessOrderbook("Exchange", ltcbtc, Base{}, Spot)
+
+	if err := Remove("Exchange", btcusd, Spot); err != nil {
+		t.Fatal("Test failed. TestRemove error", err)
+	}
+
+	if _, err := GetOrderbook("Exchange", btcusd, Spot); err == nil {
+		t.Error("Test failed. TestRemove returned a removed orderbook")
+	}
+
+	if err := Remove("Exchange", btcusd, Spot); err == nil {
+		t.Error("Test failed. TestRemove returned nil error on a removed orderbook")
+	}
+
+	if err := Remove("Exchange", ltcbtc, Spot); err != nil {
+		t.Fatal("Test failed. TestRemove error", err)
+	}
+
+	if len(Orderbooks) != 0 {
+		t.Errorf("Test failed. TestRemove left %d empty exchange orderbooks", len(Orderbooks))
+	}
+}
+
+func TestEvictExpired(t *testing.T) {
+	Orderbooks = []Orderbook{}
+	defer SetTTL(0)
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	ltcbtc := pair.NewCurrencyPair("LTC", "BTC")
+	ProcessOrderbook("Exchange", btcusd, Base{}, Spot)
+	ProcessOrderbook("Exchange", ltcbtc, Base{}, Spot)
+
+	Orderbooks[0].Orderbook["LTC"]["BTC"][Spot] = Base{LastUpdated: time.Now().Add(-time.Hour)}
+
+	if evicted := EvictExpired(); evicted != 0 {
+		t.Errorf("Test failed. TestEvictExpired evicted %d orderbooks with no TTL set", evicted)
+	}
+
+	SetTTL(time.Minute)
+	if GetTTL() != time.Minute {
+		t.Errorf("Test failed. TestEvictExpired unexpected TTL %v", GetTTL())
+	}
+
+	if evicted := EvictExpired(); evicted != 1 {
+		t.Errorf("Test failed. TestEvictExpired unexpected evicted count %d", evicted)
+	}
+
+	if _, err := GetOrderbook("Exchange", ltcbtc, Spot); err == nil {
+		t.Error("Test failed. TestEvictExpired returned an evicted orderbook")
+	}
+
+	if _, err := GetOrderbook("Exchange", btcusd, Spot); err != nil {
+		t.Error("Test failed. TestEvictExpired evicted an updated orderbook", err)
+	}
+
+	Orderbooks[0].Orderbook["BTC"]["USD"][Spot] = Base{LastUpdated: time.Now().Add(-time.Hour)}
+	if _, err := GetOrderbook("Exchange", btcusd, Spot); err == nil {
+		t.Error("Test failed. TestEvictExpired returned an expired orderbook")
+	}
+}
+
+func TestMarshalOrderbooks(t *testing.T) {
+	Orderbooks = []Orderbook{}
+	p := pair.NewCurrencyPairDelimiter("BTC-USD", "-")
+	ProcessOrderbook("Exchange", p, Base{
+		Asks:      []Item{{Price: 100, Amount: 10, ID: 1}},
+		Bids:      []Item{{Price: 90, Amount: 5, ID: 2}},
+		AssetType: Spot,
+	}, Spot)
+	original, err := GetOrderbook("Exchange", p, Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestMarshalOrderbooks error", err)
+	}
+
+	data, err := MarshalOrderbooks()
+	if err != nil {
+		t.Fatal("Test failed. TestMarshalOrderbooks marshal error", err)
+	}
+
+	Orderbooks = []Orderbook{}
+	err = UnmarshalOrderbooks(data)
+	if err != nil {
+		t.Fatal("Test failed. TestMarshalOrderbooks unmarshal error", err)
+	}
+
+	result, err := GetOrderbook("Exchange", p, Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestMarshalOrderbooks failed to return a reloaded orderbook", err)
+	}
+
+	if !result.LastUpdated.Equal(original.LastUpdated) {
+		t.Errorf("Test failed. TestMarshalOrderbooks LastUpdated %v != %v",
+			result.LastUpdated, original.LastUpdated)
+	}
+
+	result.LastUpdated = original.LastUpdated
+	if !reflect.DeepEqual(result, original) {
+		t.Errorf("Test failed. TestMarshalOrderbooks reloaded orderbook %+v != %+v",
+			result, original)
+	}
+
+	if err = UnmarshalOrderbooks([]byte("{")); err == nil {
+		t.Error("Test failed. TestMarshalOrderbooks returned nil error on invalid JSON")
+	}
+}
+
+func TestGetOrderbookConcurrentEviction(t *testing.T) {
+	Orderbooks = []Orderbook{}
+	SetTTL(time.Minute)
+	defer SetTTL(0)
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	for x := 0; x < 50; x++ {
+		ProcessOrderbook("stale"+strconv.Itoa(x), btcusd, Base{}, Spot)
+		Orderbooks[x].Orderbook["BTC"]["USD"][Spot] = Base{LastUpdated: time.Now().Add(-time.Hour)}
+	}
+	ProcessOrderbook("live", btcusd, Base{Bids: []Item{{Price: 1200, Amount: 1}}}, Spot)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		EvictExpired()
+	}()
+
+	for x := 0; x < 100; x++ {
+		result, err := GetOrderbook("live", btcusd, Spot)
+		if err != nil || len(result.Bids) != 1 || result.Bids[0].Price != 1200 {
+			t.Fatalf("Test failed. TestGetOrderbookConcurrentEviction unexpected orderbook %+v %v", result, err)
+		}
+	}
+	wg.Wait()
+
+	if len(Orderbooks) != 1 {
+		t.Errorf("Test failed. TestGetOrderbookConcurrentEviction unexpected orderbooks %d", len(Orderbooks))
+	}
+}
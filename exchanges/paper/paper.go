@@ -0,0 +1,596 @@
+package paper
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Order statuses used by the paper exchange
+const (
+	statusOpen      = "open"
+	statusFilled    = "filled"
+	statusCancelled = "cancelled"
+)
+
+// errNotSupported is returned by funding methods which cannot be simulated
+var errNotSupported = errors.New("not supported on paper exchange")
+
+// Paper is a simulated exchange which keeps balances and orders in memory and
+// fills orders against the live orderbook of the underlying exchange. Market
+// data methods are passed through to the underlying exchange, order, account,
+// funding and websocket methods never reach it.
+//
+// The base currency of a pair is traded and the quote currency pays for it,
+// following the pair orientation of the underlying exchange, so on Poloniex
+// buying BTC_ETH spends BTC for ETH. Fees are charged as a fraction of the
+// currency received. Fills do not consume orderbook liquidity, so repeated
+// orders can fill against the same orderbook level
+type Paper struct {
+	exchange.IBotExchange
+
+	// MakerFee and TakerFee are the fee rates charged on resting and
+	// immediately matched fills
+	MakerFee float64
+	TakerFee float64
+
+	mtx      sync.Mutex
+	balances map[string]float64
+	orders   []exchange.OrderDetail
+	pairs    map[int64]pair.CurrencyPair
+	orderID  int64
+	tradeID  int64
+}
+
+// New returns a paper exchange trading against the orderbooks of the
+// underlying exchange, starting with the supplied balances keyed by currency
+func New(underlying exchange.IBotExchange, balances map[string]float64) *Paper {
+	p := &Paper{
+		IBotExchange: underlying,
+		balances:     make(map[string]float64),
+		pairs:        make(map[int64]pair.CurrencyPair),
+	}
+
+	for currency, amount := range balances {
+		p.balances[common.StringToUpper(currency)] += amount
+	}
+	return p
+}
+
+// GetName returns the name of the paper exchange, which is the underlying
+// exchange name prefixed with Paper
+func (p *Paper) GetName() string {
+	return "Paper " + p.IBotExchange.GetName()
+}
+
+// Capabilities returns the features supported by the paper exchange. Market
+// data support is that of the underlying exchange, margin, lending, funding
+// and websocket methods are not simulated
+func (p *Paper) Capabilities() exchange.Capabilities {
+	underlying := p.IBotExchange.Capabilities()
+	return exchange.Capabilities{
+		SupportsOHLC:              underlying.SupportsOHLC,
+		SupportsTradeHistory:      underlying.SupportsTradeHistory,
+		SupportsOrderSubmission:   true,
+		SupportsMarketOrders:      true,
+		SupportsOrderCancellation: true,
+		SupportsCancelAllOrders:   true,
+		SupportsOrderInfo:         true,
+		SupportsOpenOrders:        true,
+		SupportsOrderHistory:      true,
+	}
+}
+
+// GetAuthenticatedAPISupport returns true as simulated account and order
+// methods do not require API credentials
+func (p *Paper) GetAuthenticatedAPISupport() bool {
+	return true
+}
+
+// GetWithdrawPermissions returns that no withdrawal methods are supported
+func (p *Paper) GetWithdrawPermissions() uint32 {
+	return exchange.NoAPIWithdrawalMethods
+}
+
+// FormatWithdrawPermissions returns that no withdrawal methods are supported
+func (p *Paper) FormatWithdrawPermissions() string {
+	return exchange.NoAPIWithdrawalMethodsText
+}
+
+// SupportsWithdrawPermissions returns whether the permissions are supported,
+// which is only true when none are requested
+func (p *Paper) SupportsWithdrawPermissions(permissions uint32) bool {
+	return permissions == exchange.NoAPIWithdrawalMethods
+}
+
+// GetWebsocket is not supported
+func (p *Paper) GetWebsocket() (*exchange.Websocket, error) {
+	return nil, errNotSupported
+}
+
+// Subscribe is not supported
+func (p *Paper) Subscribe(currencyPair pair.CurrencyPair, channel exchange.WebsocketChannel, assetType string) error {
+	return errNotSupported
+}
+
+// Unsubscribe is not supported
+func (p *Paper) Unsubscribe(currencyPair pair.CurrencyPair, channel exchange.WebsocketChannel, assetType string) error {
+	return errNotSupported
+}
+
+// GetExchangeAccountInfo returns the simulated balances, funds reserved by
+// open orders are held
+func (p *Paper) GetExchangeAccountInfo() (exchange.AccountInfo, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	response := exchange.AccountInfo{ExchangeName: p.GetName()}
+	holds := p.getHolds()
+	for currency, total := range p.balances {
+		response.Currencies = append(response.Currencies, exchange.AccountCurrencyInfo{
+			CurrencyName: currency,
+			TotalValue:   total,
+			Hold:         holds[currency],
+			Available:    total - holds[currency],
+		})
+	}
+
+	sort.Slice(response.Currencies, func(i, j int) bool {
+		return response.Currencies[i].CurrencyName < response.Currencies[j].CurrencyName
+	})
+	return response, nil
+}
+
+// SubmitExchangeOrder submits a new simulated order
+func (p *Paper) SubmitExchangeOrder(currencyPair pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (int64, error) {
+	resp, err := p.SubmitOrder(exchange.OrderSubmission{
+		Pair:     currencyPair,
+		Side:     side,
+		Type:     orderType,
+		Amount:   amount,
+		Price:    price,
+		ClientID: clientID,
+	})
+	return resp.OrderID, err
+}
+
+// SubmitOrder matches a new order against the underlying exchange orderbook
+// and updates the simulated balances. Any remainder of a good till cancelled
+// limit order rests as an open order, market and immediate or cancel orders
+// cancel it. Stop limit orders are not supported
+func (p *Paper) SubmitOrder(req exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var resp exchange.SubmitOrderResponse
+	if err := req.Validate(); err != nil {
+		return resp, err
+	}
+
+	if req.Type != exchange.OrderTypeLimit() && req.Type != exchange.OrderTypeMarket() {
+		return resp, exchange.ErrOrderTypeNotSupported
+	}
+
+	ob, err := p.GetOrderbookEx(req.Pair, ticker.Spot)
+	if err != nil {
+		return resp, err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	limit := req.Price
+	if req.Type == exchange.OrderTypeMarket() {
+		limit = 0
+	}
+
+	fills := matchOrderbook(ob, req.Side, limit, req.Amount)
+	filled := sumFills(fills)
+
+	if req.PostOnly && filled > 0 {
+		return resp, fmt.Errorf("%s post only order would match immediately",
+			p.GetName())
+	}
+
+	if req.GetTimeInForce() == exchange.FillOrKill && filled < req.Amount {
+		return resp, fmt.Errorf("%s fill or kill order cannot be filled in full",
+			p.GetName())
+	}
+
+	rests := req.Type == exchange.OrderTypeLimit() &&
+		req.GetTimeInForce() == exchange.GoodTillCancelled
+	if err = p.checkFunds(req, fills, rests); err != nil {
+		return resp, err
+	}
+
+	base, quote := p.pairCurrencies(req.Pair)
+	p.orderID++
+	order := exchange.OrderDetail{
+		Exchange:      p.GetName(),
+		ID:            p.orderID,
+		ClientID:      req.ClientID,
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		OrderSide:     string(req.Side),
+		OrderType:     string(req.Type),
+		CreationTime:  time.Now().Unix(),
+		Price:         req.Price,
+		Amount:        req.Amount,
+		OpenVolume:    req.Amount,
+	}
+
+	resp.OrderID = order.ID
+	for x := range fills {
+		resp.Fills = append(resp.Fills, p.fill(&order, fills[x], p.TakerFee))
+	}
+
+	if req.Type == exchange.OrderTypeMarket() {
+		order.Price = exchange.GetTradeHistoryVWAP(resp.Fills)
+	}
+
+	switch {
+	case order.OpenVolume <= 0:
+		order.Status = statusFilled
+	case rests:
+		order.Status = statusOpen
+	default:
+		order.Status = statusCancelled
+	}
+
+	p.orders = append(p.orders, order)
+	p.pairs[order.ID] = req.Pair
+	resp.Status = order.Status
+	return resp, nil
+}
+
+// ModifyExchangeOrder is not supported, orders must be cancelled and
+// resubmitted
+func (p *Paper) ModifyExchangeOrder(orderID int64, action exchange.ModifyOrder) (int64, error) {
+	return 0, errNotSupported
+}
+
+// CancelExchangeOrder cancels an open simulated order, releasing its held funds
+func (p *Paper) CancelExchangeOrder(orderID int64) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	order := p.getOrder(orderID)
+	if order == nil {
+		return fmt.Errorf("%s order %d not found", p.GetName(), orderID)
+	}
+
+	if order.Status != statusOpen {
+		return fmt.Errorf("%s order %d is %s", p.GetName(), orderID, order.Status)
+	}
+
+	order.Status = statusCancelled
+	return nil
+}
+
+// CancelAllExchangeOrders cancels all open simulated orders
+func (p *Paper) CancelAllExchangeOrders() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for x := range p.orders {
+		if p.orders[x].Status == statusOpen {
+			p.orders[x].Status = statusCancelled
+		}
+	}
+	return nil
+}
+
+// GetExchangeOrderInfo returns a simulated order, open orders are first
+// matched against the current orderbook
+func (p *Paper) GetExchangeOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	p.mtx.Lock()
+	currencyPair, ok := p.pairs[orderID]
+	p.mtx.Unlock()
+	if !ok {
+		return exchange.OrderDetail{}, fmt.Errorf("%s order %d not found",
+			p.GetName(), orderID)
+	}
+
+	err := p.MatchOpenOrders(currencyPair)
+	if err != nil {
+		return exchange.OrderDetail{}, err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return *p.getOrder(orderID), nil
+}
+
+// GetExchangeOpenOrders returns the open simulated orders for a currency pair,
+// or across all currency pairs if the pair is empty. Open orders are first
+// matched against the current orderbook
+func (p *Paper) GetExchangeOpenOrders(currencyPair pair.CurrencyPair) ([]exchange.OrderDetail, error) {
+	var pairs []pair.CurrencyPair
+	if currencyPair.Empty() {
+		pairs = p.getOpenPairs()
+	} else {
+		pairs = append(pairs, currencyPair)
+	}
+
+	for x := range pairs {
+		err := p.MatchOpenOrders(pairs[x])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var orders []exchange.OrderDetail
+	for x := range p.orders {
+		if p.orders[x].Status == statusOpen && p.orderMatchesPair(p.orders[x], currencyPair) {
+			orders = append(orders, p.orders[x])
+		}
+	}
+	return orders, nil
+}
+
+// GetExchangeOrderHistory returns the filled and cancelled simulated orders
+// for a currency pair, or across all currency pairs if the pair is empty,
+// created between the start and end times
+func (p *Paper) GetExchangeOrderHistory(currencyPair pair.CurrencyPair, start, end time.Time) ([]exchange.OrderDetail, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var orders []exchange.OrderDetail
+	for x := range p.orders {
+		o := p.orders[x]
+		if o.Status == statusOpen || !p.orderMatchesPair(o, currencyPair) ||
+			o.CreationTime < start.Unix() || o.CreationTime > end.Unix() {
+			continue
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+
+// MatchOpenOrders fills open simulated orders for the currency pair at their
+// limit price where the underlying exchange orderbook crosses them
+func (p *Paper) MatchOpenOrders(currencyPair pair.CurrencyPair) error {
+	if !p.hasOpenOrders(currencyPair) {
+		return nil
+	}
+
+	ob, err := p.GetOrderbookEx(currencyPair, ticker.Spot)
+	if err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for x := range p.orders {
+		order := &p.orders[x]
+		if order.Status != statusOpen || !p.orderMatchesPair(*order, currencyPair) {
+			continue
+		}
+
+		fills := matchOrderbook(ob, exchange.OrderSide(order.OrderSide),
+			order.Price, order.OpenVolume)
+		filled := sumFills(fills)
+		if filled == 0 {
+			continue
+		}
+
+		p.fill(order, orderbook.Item{Price: order.Price, Amount: filled}, p.MakerFee)
+		if order.OpenVolume <= 0 {
+			order.Status = statusFilled
+		}
+	}
+	return nil
+}
+
+// GetExchangeDepositAddress is not supported
+func (p *Paper) GetExchangeDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+	return "", errNotSupported
+}
+
+// GetExchangeFundTransferHistory is not supported
+func (p *Paper) GetExchangeFundTransferHistory() ([]exchange.FundHistory, error) {
+	return nil, errNotSupported
+}
+
+// WithdrawCryptoExchangeFunds is not supported
+func (p *Paper) WithdrawCryptoExchangeFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+	return "", errNotSupported
+}
+
+// WithdrawFiatExchangeFunds is not supported
+func (p *Paper) WithdrawFiatExchangeFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", errNotSupported
+}
+
+// WithdrawExchangeFunds is not supported
+func (p *Paper) WithdrawExchangeFunds(req exchange.WithdrawalRequest) (string, error) {
+	return "", errNotSupported
+}
+
+// matchOrderbook returns the orderbook levels an order would fill against,
+// best price first, up to the amount. A zero limit matches any price
+func matchOrderbook(ob orderbook.Base, side exchange.OrderSide, limit, amount float64) []orderbook.Item {
+	var levels []orderbook.Item
+	if side == exchange.OrderSideBuy() {
+		levels = append(levels, ob.Asks...)
+		sort.Slice(levels, func(i, j int) bool { return levels[i].Price < levels[j].Price })
+	} else {
+		levels = append(levels, ob.Bids...)
+		sort.Slice(levels, func(i, j int) bool { return levels[i].Price > levels[j].Price })
+	}
+
+	var fills []orderbook.Item
+	for x := range levels {
+		if amount <= 0 {
+			break
+		}
+
+		if limit > 0 {
+			if side == exchange.OrderSideBuy() && levels[x].Price > limit ||
+				side == exchange.OrderSideSell() && levels[x].Price < limit {
+				break
+			}
+		}
+
+		fill := levels[x]
+		if fill.Amount > amount {
+			fill.Amount = amount
+		}
+		amount -= fill.Amount
+		fills = append(fills, fill)
+	}
+	return fills
+}
+
+// sumFills returns the total amount filled
+func sumFills(fills []orderbook.Item) float64 {
+	var total float64
+	for x := range fills {
+		total += fills[x].Amount
+	}
+	return total
+}
+
+// checkFunds returns an InsufficientBalanceError if the available balance does not
+// cover the immediate fills and, for resting orders, the remainder held at
+// the limit price. It must be called with the lock held
+func (p *Paper) checkFunds(req exchange.OrderSubmission, fills []orderbook.Item, rests bool) error {
+	currency, quote := p.pairCurrencies(req.Pair)
+	required := req.Amount
+	if req.Side == exchange.OrderSideBuy() {
+		currency = quote
+		required = 0
+		for x := range fills {
+			required += fills[x].Amount * fills[x].Price
+		}
+		if rests {
+			required += (req.Amount - sumFills(fills)) * req.Price
+		}
+	}
+
+	available := p.balances[currency] - p.getHolds()[currency]
+	if required > available {
+		return &exchange.InsufficientBalanceError{
+			Exchange:  p.GetName(),
+			Currency:  currency,
+			Required:  required,
+			Available: available,
+		}
+	}
+	return nil
+}
+
+// pairCurrencies returns the base currency traded and the quote currency which
+// pays for it, the quote currency is first on exchanges such as Poloniex
+func (p *Paper) pairCurrencies(currencyPair pair.CurrencyPair) (base, quote string) {
+	base = currencyPair.FirstCurrency.Upper().String()
+	quote = currencyPair.SecondCurrency.Upper().String()
+	if p.IsQuoteCurrencyFirst() {
+		return quote, base
+	}
+	return base, quote
+}
+
+// fill applies a fill to the order and balances, charging the fee rate on the
+// currency received. It must be called with the lock held
+func (p *Paper) fill(order *exchange.OrderDetail, fill orderbook.Item, feeRate float64) exchange.TradeHistory {
+	cost := fill.Amount * fill.Price
+	if order.OrderSide == string(exchange.OrderSideBuy()) {
+		p.balances[order.QuoteCurrency] -= cost
+		p.balances[order.BaseCurrency] += fill.Amount * (1 - feeRate)
+	} else {
+		p.balances[order.BaseCurrency] -= fill.Amount
+		p.balances[order.QuoteCurrency] += cost * (1 - feeRate)
+	}
+	order.OpenVolume -= fill.Amount
+
+	p.tradeID++
+	return exchange.TradeHistory{
+		Timestamp: time.Now().Unix(),
+		TID:       p.tradeID,
+		Price:     fill.Price,
+		Amount:    fill.Amount,
+		Exchange:  order.Exchange,
+		Type:      order.OrderSide,
+	}
+}
+
+// getHolds returns the funds reserved by open orders keyed by currency. It
+// must be called with the lock held
+func (p *Paper) getHolds() map[string]float64 {
+	holds := make(map[string]float64)
+	for x := range p.orders {
+		o := p.orders[x]
+		if o.Status != statusOpen {
+			continue
+		}
+
+		if o.OrderSide == string(exchange.OrderSideBuy()) {
+			holds[o.QuoteCurrency] += o.OpenVolume * o.Price
+			continue
+		}
+		holds[o.BaseCurrency] += o.OpenVolume
+	}
+	return holds
+}
+
+// getOrder returns the order for the ID or nil if it does not exist. It must
+// be called with the lock held
+func (p *Paper) getOrder(orderID int64) *exchange.OrderDetail {
+	for x := range p.orders {
+		if p.orders[x].ID == orderID {
+			return &p.orders[x]
+		}
+	}
+	return nil
+}
+
+// getOpenPairs returns the currency pairs with open orders
+func (p *Paper) getOpenPairs() []pair.CurrencyPair {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var pairs []pair.CurrencyPair
+	for x := range p.orders {
+		if p.orders[x].Status != statusOpen {
+			continue
+		}
+
+		currencyPair := p.pairs[p.orders[x].ID]
+		if !pair.Contains(pairs, currencyPair, true) {
+			pairs = append(pairs, currencyPair)
+		}
+	}
+	return pairs
+}
+
+// hasOpenOrders returns whether there are open orders for the currency pair
+func (p *Paper) hasOpenOrders(currencyPair pair.CurrencyPair) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for x := range p.orders {
+		if p.orders[x].Status == statusOpen && p.orderMatchesPair(p.orders[x], currencyPair) {
+			return true
+		}
+	}
+	return false
+}
+
+// orderMatchesPair returns whether the order is for the currency pair, an
+// empty pair matches all orders. It must be called with the lock held
+func (p *Paper) orderMatchesPair(order exchange.OrderDetail, currencyPair pair.CurrencyPair) bool {
+	if currencyPair.Empty() {
+		return true
+	}
+	return p.pairs[order.ID].Equal(currencyPair, true)
+}
@@ -0,0 +1,297 @@
+package paper
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+type liveExchange struct {
+	exchange.IBotExchange
+	ob         orderbook.Base
+	quoteFirst bool
+}
+
+func (l *liveExchange) GetName() string {
+	return "Live"
+}
+
+func (l *liveExchange) IsQuoteCurrencyFirst() bool {
+	return l.quoteFirst
+}
+
+func (l *liveExchange) Capabilities() exchange.Capabilities {
+	return exchange.Capabilities{
+		SupportsMargin:      true,
+		SupportsWebsocket:   true,
+		SupportsOHLC:        true,
+		SupportsWithdrawals: true,
+	}
+}
+
+func (l *liveExchange) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	return l.ob, nil
+}
+
+func newTestPaper() (*Paper, *liveExchange) {
+	live := &liveExchange{
+		ob: orderbook.Base{
+			Bids: []orderbook.Item{{Price: 99, Amount: 1}, {Price: 98, Amount: 2}},
+			Asks: []orderbook.Item{{Price: 101, Amount: 2}, {Price: 100, Amount: 1}},
+		},
+	}
+	return New(live, map[string]float64{"btc": 1, "usd": 1000}), live
+}
+
+func getBalance(t *testing.T, p *Paper, currency string) exchange.AccountCurrencyInfo {
+	info, err := p.GetExchangeAccountInfo()
+	if err != nil {
+		t.Fatal("Test failed - Paper GetExchangeAccountInfo() error", err)
+	}
+
+	for x := range info.Currencies {
+		if info.Currencies[x].CurrencyName == currency {
+			return info.Currencies[x]
+		}
+	}
+	return exchange.AccountCurrencyInfo{}
+}
+
+func floatEquals(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestSubmitMarketOrder(t *testing.T) {
+	p, _ := newTestPaper()
+	btcUSD := pair.NewCurrencyPair("BTC", "USD")
+
+	resp, err := p.SubmitOrder(exchange.OrderSubmission{
+		Pair:   btcUSD,
+		Side:   exchange.OrderSideBuy(),
+		Type:   exchange.OrderTypeMarket(),
+		Amount: 2,
+	})
+	if err != nil {
+		t.Fatal("Test failed - Paper SubmitOrder() error", err)
+	}
+
+	if resp.Status != "filled" || len(resp.Fills) != 2 ||
+		resp.Fills[0].Price != 100 || resp.Fills[1].Price != 101 {
+		t.Errorf("Test failed - Paper SubmitOrder() unexpected response %+v", resp)
+	}
+
+	if b := getBalance(t, p, "USD"); !floatEquals(b.TotalValue, 799) {
+		t.Error("Test failed - Paper SubmitOrder() unexpected USD balance", b.TotalValue)
+	}
+
+	if b := getBalance(t, p, "BTC"); !floatEquals(b.TotalValue, 3) {
+		t.Error("Test failed - Paper SubmitOrder() unexpected BTC balance", b.TotalValue)
+	}
+
+	order, err := p.GetExchangeOrderInfo(resp.OrderID)
+	if err != nil {
+		t.Fatal("Test failed - Paper GetExchangeOrderInfo() error", err)
+	}
+
+	if !floatEquals(order.Price, 100.5) || order.OpenVolume != 0 {
+		t.Errorf("Test failed - Paper GetExchangeOrderInfo() unexpected order %+v", order)
+	}
+
+	_, err = p.SubmitOrder(exchange.OrderSubmission{
+		Pair:   btcUSD,
+		Side:   exchange.OrderSideSell(),
+		Type:   exchange.OrderTypeMarket(),
+		Amount: 4,
+	})
+	if err == nil {
+		t.Error("Test failed - Paper SubmitOrder() should error with insufficient funds")
+	}
+}
+
+func TestSubmitLimitOrder(t *testing.T) {
+	p, live := newTestPaper()
+	btcUSD := pair.NewCurrencyPair("BTC", "USD")
+	p.MakerFee = 0.01
+
+	id, err := p.SubmitExchangeOrder(btcUSD, exchange.OrderSideBuy(),
+		exchange.OrderTypeLimit(), 3, 95, "")
+	if err != nil {
+		t.Fatal("Test failed - Paper SubmitExchangeOrder() error", err)
+	}
+
+	if b := getBalance(t, p, "USD"); !floatEquals(b.Hold, 285) || !floatEquals(b.Available, 715) {
+		t.Errorf("Test failed - Paper SubmitExchangeOrder() unexpected USD balance %+v", b)
+	}
+
+	orders, err := p.GetExchangeOpenOrders(pair.CurrencyPair{})
+	if err != nil {
+		t.Fatal("Test failed - Paper GetExchangeOpenOrders() error", err)
+	}
+
+	if len(orders) != 1 || orders[0].ID != id {
+		t.Errorf("Test failed - Paper GetExchangeOpenOrders() unexpected orders %+v", orders)
+	}
+
+	// The market drops through the order price
+	live.ob.Asks = []orderbook.Item{{Price: 94, Amount: 1}}
+	order, err := p.GetExchangeOrderInfo(id)
+	if err != nil {
+		t.Fatal("Test failed - Paper GetExchangeOrderInfo() error", err)
+	}
+
+	if order.Status != "open" || order.OpenVolume != 2 {
+		t.Errorf("Test failed - Paper GetExchangeOrderInfo() unexpected order %+v", order)
+	}
+
+	if b := getBalance(t, p, "BTC"); !floatEquals(b.TotalValue, 1.99) {
+		t.Error("Test failed - Paper GetExchangeOrderInfo() unexpected BTC balance", b.TotalValue)
+	}
+
+	err = p.CancelExchangeOrder(id)
+	if err != nil {
+		t.Fatal("Test failed - Paper CancelExchangeOrder() error", err)
+	}
+
+	if b := getBalance(t, p, "USD"); !floatEquals(b.TotalValue, 905) || b.Hold != 0 {
+		t.Errorf("Test failed - Paper CancelExchangeOrder() unexpected USD balance %+v", b)
+	}
+
+	if p.CancelExchangeOrder(id) == nil {
+		t.Error("Test failed - Paper CancelExchangeOrder() should error on a cancelled order")
+	}
+
+	history, err := p.GetExchangeOrderHistory(btcUSD, time.Now().Add(-time.Minute), time.Now())
+	if err != nil {
+		t.Fatal("Test failed - Paper GetExchangeOrderHistory() error", err)
+	}
+
+	if len(history) != 1 || history[0].Status != "cancelled" {
+		t.Errorf("Test failed - Paper GetExchangeOrderHistory() unexpected orders %+v", history)
+	}
+}
+
+func TestSubmitOrderTimeInForce(t *testing.T) {
+	p, _ := newTestPaper()
+	btcUSD := pair.NewCurrencyPair("BTC", "USD")
+
+	_, err := p.SubmitOrder(exchange.OrderSubmission{
+		Pair:        btcUSD,
+		Side:        exchange.OrderSideSell(),
+		Type:        exchange.OrderTypeLimit(),
+		Amount:      1,
+		Price:       98,
+		TimeInForce: exchange.FillOrKill,
+	})
+	if err != nil {
+		t.Fatal("Test failed - Paper SubmitOrder() error", err)
+	}
+
+	_, err = p.SubmitOrder(exchange.OrderSubmission{
+		Pair:        btcUSD,
+		Side:        exchange.OrderSideBuy(),
+		Type:        exchange.OrderTypeLimit(),
+		Amount:      2,
+		Price:       100,
+		TimeInForce: exchange.FillOrKill,
+	})
+	if err == nil {
+		t.Error("Test failed - Paper SubmitOrder() fill or kill order should error")
+	}
+
+	resp, err := p.SubmitOrder(exchange.OrderSubmission{
+		Pair:        btcUSD,
+		Side:        exchange.OrderSideBuy(),
+		Type:        exchange.OrderTypeLimit(),
+		Amount:      2,
+		Price:       100,
+		TimeInForce: exchange.ImmediateOrCancel,
+	})
+	if err != nil {
+		t.Fatal("Test failed - Paper SubmitOrder() error", err)
+	}
+
+	if resp.Status != "cancelled" || len(resp.Fills) != 1 {
+		t.Errorf("Test failed - Paper SubmitOrder() unexpected response %+v", resp)
+	}
+
+	_, err = p.SubmitOrder(exchange.OrderSubmission{
+		Pair:     btcUSD,
+		Side:     exchange.OrderSideBuy(),
+		Type:     exchange.OrderTypeLimit(),
+		Amount:   1,
+		Price:    100,
+		PostOnly: true,
+	})
+	if err == nil {
+		t.Error("Test failed - Paper SubmitOrder() post only order should error")
+	}
+
+	_, err = p.WithdrawCryptoExchangeFunds("address", "BTC", 1)
+	if err == nil {
+		t.Error("Test failed - Paper WithdrawCryptoExchangeFunds() should error")
+	}
+}
+
+func TestSubmitOrderQuoteCurrencyFirst(t *testing.T) {
+	p, live := newTestPaper()
+	live.quoteFirst = true
+	usdBTC := pair.NewCurrencyPair("USD", "BTC")
+
+	resp, err := p.SubmitOrder(exchange.OrderSubmission{
+		Pair:   usdBTC,
+		Side:   exchange.OrderSideBuy(),
+		Type:   exchange.OrderTypeMarket(),
+		Amount: 2,
+	})
+	if err != nil {
+		t.Fatal("Test failed - Paper SubmitOrder() error", err)
+	}
+
+	if resp.Status != "filled" {
+		t.Errorf("Test failed - Paper SubmitOrder() unexpected response %+v", resp)
+	}
+
+	if b := getBalance(t, p, "USD"); !floatEquals(b.TotalValue, 799) {
+		t.Error("Test failed - Paper SubmitOrder() unexpected USD balance", b.TotalValue)
+	}
+
+	if b := getBalance(t, p, "BTC"); !floatEquals(b.TotalValue, 3) {
+		t.Error("Test failed - Paper SubmitOrder() unexpected BTC balance", b.TotalValue)
+	}
+
+	order, err := p.GetExchangeOrderInfo(resp.OrderID)
+	if err != nil {
+		t.Fatal("Test failed - Paper GetExchangeOrderInfo() error", err)
+	}
+
+	if order.BaseCurrency != "BTC" || order.QuoteCurrency != "USD" {
+		t.Errorf("Test failed - Paper GetExchangeOrderInfo() unexpected order %+v", order)
+	}
+}
+
+func TestSelfContained(t *testing.T) {
+	p, _ := newTestPaper()
+
+	if p.GetName() != "Paper Live" {
+		t.Error("Test failed - Paper GetName() unexpected name", p.GetName())
+	}
+
+	c := p.Capabilities()
+	if c.SupportsMargin || c.SupportsWebsocket || c.SupportsWithdrawals ||
+		!c.SupportsOHLC || !c.SupportsOrderSubmission {
+		t.Errorf("Test failed - Paper Capabilities() unexpected capabilities %+v", c)
+	}
+
+	if p.GetWithdrawPermissions() != exchange.NoAPIWithdrawalMethods ||
+		p.SupportsWithdrawPermissions(exchange.AutoWithdrawCrypto) {
+		t.Error("Test failed - Paper withdraw permissions should be empty")
+	}
+
+	if _, err := p.GetWebsocket(); err == nil {
+		t.Error("Test failed - Paper GetWebsocket() should error")
+	}
+}
@@ -2,17 +2,21 @@ package poloniex
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/url"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/nonce"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -51,12 +55,57 @@ const (
 
 	poloniexAuthRate   = 6
 	poloniexUnauthRate = 6
+
+	// Request weights against the rate limit budget, requests not listed
+	// weigh 1
+	poloniexOrderbookAllWeight = 3
+	poloniexChartDataWeight    = 2
+
+	poloniexDecimalPlaces = 8
+	poloniexDateLayout    = "2006-01-02 15:04:05"
+
+	poloniexTradeHistoryLimit = 10000
+
+	// poloniexClientOrderLookback is how far back the trade history is
+	// searched for an order by client order ID
+	poloniexClientOrderLookback = 24 * time.Hour
+
+	poloniexLoanMinDuration = 2
+	poloniexLoanMaxDuration = 60
+
+	// poloniexDefaultWithdrawalFee is returned for currencies which are
+	// neither in the live returnCurrencies data nor the WithdrawalFees table
+	poloniexDefaultWithdrawalFee = 0
 )
 
+// poloniexChartPeriods holds the candlestick periods in seconds supported by
+// returnChartData
+var poloniexChartPeriods = []int64{300, 900, 1800, 7200, 14400, 86400}
+
 // Poloniex is the overarching type across the poloniex package
 type Poloniex struct {
 	exchange.Base
 	WebsocketConn *websocket.Conn
+
+	withdrawalFees    map[string]float64
+	withdrawalFeesMtx sync.RWMutex
+	clientOrders      exchange.ClientOrderTracker
+	marginPairs       []string
+	marginPairsMtx    sync.Mutex
+
+	// wsStreams maps each websocket channel to the subscribed streams it
+	// carries, the ticker channel covers every pair and each pair channel
+	// carries both orderbook and trade updates
+	wsStreams    map[string]map[string]bool
+	wsStreamsMtx sync.Mutex
+
+	// wsDispatcher routes websocket messages to the handler registered for
+	// their channel
+	wsDispatcher *exchange.WebsocketDispatcher
+
+	// wsWriteMtx serialises writes to the websocket connection, which allows
+	// only one concurrent writer, and guards replacing it on connect
+	wsWriteMtx sync.Mutex
 }
 
 // SetDefaults sets default settings for poloniex
@@ -66,25 +115,41 @@ func (p *Poloniex) SetDefaults() {
 	p.Fee = 0
 	p.Verbose = false
 	p.RESTPollingDelay = 10
+	p.SetNonceStrategy(nonce.UnixNanoseconds)
 	p.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission
 	p.RequestCurrencyPairFormat.Delimiter = "_"
 	p.RequestCurrencyPairFormat.Uppercase = true
 	p.ConfigCurrencyPairFormat.Delimiter = "_"
 	p.ConfigCurrencyPairFormat.Uppercase = true
-	p.AssetTypes = []string{ticker.Spot}
+	p.AssetTypes = []string{ticker.Spot, ticker.Margin}
 	p.SupportsAutoPairUpdating = true
 	p.SupportsRESTTickerBatching = true
+	p.QuoteCurrencyFirst = true
+	p.Features = exchange.Capabilities{
+		SupportsMargin:          true,
+		SupportsLending:         true,
+		SupportsWebsocket:       true,
+		SupportsOHLC:            true,
+		SupportsOrderSubmission: true,
+		SupportsOrderInfo:       true,
+		SupportsOpenOrders:      true,
+		SupportsOrderHistory:    true,
+		SupportsWithdrawals:     true,
+	}
 	p.Requester = request.New(p.Name,
 		request.NewRateLimit(time.Second, poloniexAuthRate),
 		request.NewRateLimit(time.Second, poloniexUnauthRate),
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
 	p.APIUrlDefault = poloniexAPIURL
 	p.APIUrl = p.APIUrlDefault
+	p.TLSPinsDefault = exchange.RootCATLSPins
 	p.WebsocketInit()
+	p.wsDispatcher = p.wsNewDispatcher()
 }
 
-// Setup sets user exchange configuration settings
-func (p *Poloniex) Setup(exch config.ExchangeConfig) {
+// Setup sets user exchange configuration settings, returning the first
+// configuration error encountered
+func (p *Poloniex) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		p.SetEnabled(false)
 	} else {
@@ -93,41 +158,67 @@ func (p *Poloniex) Setup(exch config.ExchangeConfig) {
 		p.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		p.SetHTTPClientTimeout(exch.HTTPTimeout)
 		p.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		p.SetHTTP2Enabled(!exch.DisableHTTP2)
+		p.SetCircuitBreaker(exch.BreakerThreshold, exch.BreakerWindow, exch.BreakerCooldown)
+		p.SetMaxResponseSize(exch.MaxResponseSize)
 		p.RESTPollingDelay = exch.RESTPollingDelay
+		p.SyncNonceWithServerTime = exch.SyncNonceWithServerTime
+		p.CurrencyPairsCacheTTL = exch.CurrencyPairsCacheTTL
+		p.RESTPollingEnabled = exch.EnableRESTPolling
+		p.DisableAutoPairUpdates = exch.DisableAutoPairUpdates
+		p.SetWithdrawalAddressAllowlist(exch.WithdrawalAllowlist)
+		p.SetMaxOrderNotional(exch.MaxOrderNotional, exch.MaxPairOrderNotional)
+		p.CheckOrderBalance = exch.CheckOrderBalance
 		p.Verbose = exch.Verbose
 		p.Websocket.SetEnabled(exch.Websocket)
 		p.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		p.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		p.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
+		// Margin pairs can only be looked up with authenticated API support
+		if !p.AuthenticatedAPISupport {
+			p.AssetTypes = []string{ticker.Spot}
+		}
 		err := p.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = p.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = p.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = p.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
+		}
+		err = p.SetHTTPClientPins(exch.TLSPins)
+		if err != nil {
+			return err
 		}
+		p.SetProxyFallback(exch.ProxyFallback, exch.ProxyBypass)
 		err = p.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
+		pingInterval := exch.WebsocketPingInterval
+		if pingInterval == 0 {
+			pingInterval = poloniexWebsocketPingInterval
+		}
+		p.Websocket.SetHeartbeat(pingInterval, exch.WebsocketPongTimeout,
+			p.wsPing, p.wsInterrupt)
 		err = p.WebsocketSetup(p.WsConnect,
 			exch.Name,
 			exch.Websocket,
 			poloniexWebsocketAddress,
 			exch.WebsocketURL)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetTicker returns current ticker information
@@ -137,7 +228,7 @@ func (p *Poloniex) GetTicker() (map[string]Ticker, error) {
 	}
 
 	resp := response{}
-	path := fmt.Sprintf("%s/public?command=returnTicker", p.APIUrl)
+	path := fmt.Sprintf("%s/public?command=returnTicker", p.GetAPIURL())
 
 	return resp.Data, p.SendHTTPRequest(path, &resp.Data)
 }
@@ -145,7 +236,7 @@ func (p *Poloniex) GetTicker() (map[string]Ticker, error) {
 // GetVolume returns a list of currencies with associated volume
 func (p *Poloniex) GetVolume() (interface{}, error) {
 	var resp interface{}
-	path := fmt.Sprintf("%s/public?command=return24hVolume", p.APIUrl)
+	path := fmt.Sprintf("%s/public?command=return24hVolume", p.GetAPIURL())
 
 	return resp, p.SendHTTPRequest(path, &resp)
 }
@@ -162,13 +253,12 @@ func (p *Poloniex) GetOrderbook(currencyPair string, depth int) (OrderbookAll, e
 	if currencyPair != "" {
 		vals.Set("currencyPair", currencyPair)
 		resp := OrderbookResponse{}
-		path := fmt.Sprintf("%s/public?command=returnOrderBook&%s", p.APIUrl, vals.Encode())
+		path := fmt.Sprintf("%s/public?command=returnOrderBook&%s", p.GetAPIURL(), vals.Encode())
 		err := p.SendHTTPRequest(path, &resp)
 		if err != nil {
 			return oba, err
 		}
 		if len(resp.Error) != 0 {
-			log.Println(resp.Error)
 			return oba, fmt.Errorf("Poloniex GetOrderbook() error: %s", resp.Error)
 		}
 		ob := Orderbook{}
@@ -195,8 +285,8 @@ func (p *Poloniex) GetOrderbook(currencyPair string, depth int) (OrderbookAll, e
 	} else {
 		vals.Set("currencyPair", "all")
 		resp := OrderbookResponseAll{}
-		path := fmt.Sprintf("%s/public?command=returnOrderBook&%s", p.APIUrl, vals.Encode())
-		err := p.SendHTTPRequest(path, &resp.Data)
+		path := fmt.Sprintf("%s/public?command=returnOrderBook&%s", p.GetAPIURL(), vals.Encode())
+		err := p.SendWeightedHTTPRequest(poloniexOrderbookAllWeight, path, &resp.Data)
 		if err != nil {
 			return oba, err
 		}
@@ -241,7 +331,7 @@ func (p *Poloniex) GetTradeHistory(currencyPair, start, end string) ([]TradeHist
 	}
 
 	resp := []TradeHistory{}
-	path := fmt.Sprintf("%s/public?command=returnTradeHistory&%s", p.APIUrl, vals.Encode())
+	path := fmt.Sprintf("%s/public?command=returnTradeHistory&%s", p.GetAPIURL(), vals.Encode())
 
 	return resp, p.SendHTTPRequest(path, &resp)
 }
@@ -260,13 +350,17 @@ func (p *Poloniex) GetChartData(currencyPair, start, end, period string) ([]Char
 	}
 
 	if period != "" {
+		if !isValidChartPeriod(period) {
+			return nil, fmt.Errorf("invalid chart period %s, supported periods %v",
+				period, poloniexChartPeriods)
+		}
 		vals.Set("period", period)
 	}
 
 	resp := []ChartData{}
-	path := fmt.Sprintf("%s/public?command=returnChartData&%s", p.APIUrl, vals.Encode())
+	path := fmt.Sprintf("%s/public?command=returnChartData&%s", p.GetAPIURL(), vals.Encode())
 
-	err := p.SendHTTPRequest(path, &resp)
+	err := p.SendWeightedHTTPRequest(poloniexChartDataWeight, path, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -274,17 +368,55 @@ func (p *Poloniex) GetChartData(currencyPair, start, end, period string) ([]Char
 	return resp, nil
 }
 
+// isValidChartPeriod returns whether the period is supported by
+// returnChartData
+func isValidChartPeriod(period string) bool {
+	seconds, err := strconv.ParseInt(period, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	for _, x := range poloniexChartPeriods {
+		if x == seconds {
+			return true
+		}
+	}
+	return false
+}
+
 // GetCurrencies returns information about currencies
 func (p *Poloniex) GetCurrencies() (map[string]Currencies, error) {
 	type Response struct {
 		Data map[string]Currencies
 	}
 	resp := Response{}
-	path := fmt.Sprintf("%s/public?command=returnCurrencies", p.APIUrl)
+	path := fmt.Sprintf("%s/public?command=returnCurrencies", p.GetAPIURL())
 
 	return resp.Data, p.SendHTTPRequest(path, &resp.Data)
 }
 
+// UpdateWithdrawalFees fetches the per currency withdrawal fees from the
+// returnCurrencies txFee field and caches them for use by GetFee
+func (p *Poloniex) UpdateWithdrawalFees() error {
+	currencies, err := p.GetCurrencies()
+	if err != nil {
+		return err
+	}
+	p.setWithdrawalFees(currencies)
+	return nil
+}
+
+func (p *Poloniex) setWithdrawalFees(currencies map[string]Currencies) {
+	fees := make(map[string]float64, len(currencies))
+	for x := range currencies {
+		fees[x] = currencies[x].TxFee
+	}
+
+	p.withdrawalFeesMtx.Lock()
+	p.withdrawalFees = fees
+	p.withdrawalFeesMtx.Unlock()
+}
+
 // GetExchangeCurrencies returns a list of currencies using the GetTicker API
 // as the GetExchangeCurrencies information doesn't return currency pair information
 func (p *Poloniex) GetExchangeCurrencies() ([]string, error) {
@@ -305,7 +437,7 @@ func (p *Poloniex) GetExchangeCurrencies() ([]string, error) {
 // currency, specified by the "currency" GET parameter.
 func (p *Poloniex) GetLoanOrders(currency string) (LoanOrders, error) {
 	resp := LoanOrders{}
-	path := fmt.Sprintf("%s/public?command=returnLoanOrders&currency=%s", p.APIUrl, currency)
+	path := fmt.Sprintf("%s/public?command=returnLoanOrders&currency=%s", p.GetAPIURL(), currency)
 
 	return resp, p.SendHTTPRequest(path, &resp)
 }
@@ -319,7 +451,15 @@ func (p *Poloniex) GetBalances() (Balance, error) {
 		return Balance{}, err
 	}
 
-	data := result.(map[string]interface{})
+	data, ok := result.(map[string]interface{})
+	if !ok {
+		return Balance{}, errors.New("unexpected balances response")
+	}
+
+	if errMsg, ok := data["error"].(string); ok {
+		return Balance{}, errors.New(errMsg)
+	}
+
 	balance := Balance{}
 	balance.Currency = make(map[string]float64)
 
@@ -488,10 +628,70 @@ func (p *Poloniex) GetAuthenticatedTradeHistory(currency, start, end, limit stri
 	return result, nil
 }
 
-// PlaceOrder places a new order on the exchange
-func (p *Poloniex) PlaceOrder(currency string, rate, amount float64, immediate, fillOrKill, buy bool) (OrderResponse, error) {
+// GetAuthenticatedTradeHistoryRange returns all account trades between the
+// start and end times keyed by currency pair. Poloniex returns the newest
+// trades first and caps each response, so requests are repeated with an
+// earlier end time until the range is exhausted
+func (p *Poloniex) GetAuthenticatedTradeHistoryRange(currency string, start, end time.Time) (map[string][]AuthentictedTradeHistory, error) {
+	result := make(map[string][]AuthentictedTradeHistory)
+	seen := make(map[int64]bool)
+	limit := strconv.Itoa(poloniexTradeHistoryLimit)
+	startStr := strconv.FormatInt(start.Unix(), 10)
+
+	for {
+		resp, err := p.GetAuthenticatedTradeHistory(currency, startStr,
+			strconv.FormatInt(end.Unix(), 10), limit)
+		if err != nil {
+			return nil, err
+		}
+
+		var trades map[string][]AuthentictedTradeHistory
+		switch r := resp.(type) {
+		case AuthenticatedTradeHistoryResponse:
+			trades = map[string][]AuthentictedTradeHistory{currency: r.Data}
+		case AuthenticatedTradeHistoryAll:
+			trades = r.Data
+		}
+
+		var count int
+		oldest := end
+		for symbol, data := range trades {
+			count += len(data)
+			for x := range data {
+				if seen[data[x].GlobalTradeID] {
+					continue
+				}
+				seen[data[x].GlobalTradeID] = true
+				result[symbol] = append(result[symbol], data[x])
+
+				tradeTime, err := time.Parse(poloniexDateLayout, data[x].Date)
+				if err != nil {
+					return nil, err
+				}
+				if tradeTime.Before(oldest) {
+					oldest = tradeTime
+				}
+			}
+		}
+
+		if count < poloniexTradeHistoryLimit || !oldest.Before(end) {
+			return result, nil
+		}
+		end = oldest
+	}
+}
+
+// PlaceOrder places a new order on the exchange. The client order ID is
+// optional and must be a unique integer when set
+func (p *Poloniex) PlaceOrder(currency string, rate, amount float64, immediate, fillOrKill, postOnly, buy bool, clientOrderID string) (OrderResponse, error) {
 	result := OrderResponse{}
 	values := url.Values{}
+	amount, rate = p.RoundOrderPrecision(amount, rate)
+
+	err := p.CheckOrderNotional(currency, amount, rate)
+	if err != nil {
+		return result, err
+	}
 
 	var orderType string
 	if buy {
@@ -512,7 +712,19 @@ func (p *Poloniex) PlaceOrder(currency string, rate, amount float64, immediate,
 		values.Set("fillOrKill", "1")
 	}
 
-	err := p.SendAuthenticatedHTTPRequest("POST", orderType, values, &result)
+	if postOnly {
+		values.Set("postOnly", "1")
+	}
+
+	if clientOrderID != "" {
+		if _, err := strconv.ParseUint(clientOrderID, 10, 64); err != nil {
+			return result, fmt.Errorf("%s invalid client order ID %s, must be an integer",
+				p.Name, clientOrderID)
+		}
+		values.Set("clientOrderId", clientOrderID)
+	}
+
+	err = p.SendAuthenticatedHTTPRequest("POST", orderType, values, &result)
 
 	if err != nil {
 		return result, err
@@ -521,6 +733,13 @@ func (p *Poloniex) PlaceOrder(currency string, rate, amount float64, immediate,
 	return result, nil
 }
 
+// RoundOrderPrecision rounds an order amount and rate to the number of decimal
+// places accepted by Poloniex
+func (p *Poloniex) RoundOrderPrecision(amount, rate float64) (float64, float64) {
+	return common.RoundToDecimals(amount, poloniexDecimalPlaces),
+		common.RoundToDecimals(rate, poloniexDecimalPlaces)
+}
+
 // CancelOrder cancels and order by orderID
 func (p *Poloniex) CancelOrder(orderID int64) (bool, error) {
 	result := GenericResponse{}
@@ -564,16 +783,31 @@ func (p *Poloniex) MoveOrder(orderID int64, rate, amount float64) (MoveOrderResp
 	return result, nil
 }
 
-// Withdraw withdraws a currency to a specific delegated address
-func (p *Poloniex) Withdraw(currency, address string, amount float64) (bool, error) {
+// Withdraw withdraws a currency to a specific delegated address. The
+// paymentID is only sent when set and is required for currencies which need a
+// memo or payment ID
+func (p *Poloniex) Withdraw(currency, address, paymentID string, amount float64) (bool, error) {
+	err := exchange.CheckAddressTag(pair.CurrencyItem(currency), paymentID)
+	if err != nil {
+		return false, err
+	}
+
+	err = p.CheckWithdrawalAddress(pair.CurrencyItem(currency), address)
+	if err != nil {
+		return false, err
+	}
+
 	result := Withdraw{}
 	values := url.Values{}
 
 	values.Set("currency", currency)
 	values.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
 	values.Set("address", address)
+	if paymentID != "" {
+		values.Set("paymentId", paymentID)
+	}
 
-	err := p.SendAuthenticatedHTTPRequest("POST", poloniexWithdraw, values, &result)
+	err = p.SendAuthenticatedHTTPRequest("POST", poloniexWithdraw, values, &result)
 
 	if err != nil {
 		return false, err
@@ -593,6 +827,30 @@ func (p *Poloniex) GetFeeInfo() (Fee, error) {
 	return result, p.SendAuthenticatedHTTPRequest("POST", poloniexFeeInfo, url.Values{}, &result)
 }
 
+// GetMarginPairs returns the currency pairs which can be traded on margin. The
+// pairs are requested once and then cached, requires authenticated API support
+func (p *Poloniex) GetMarginPairs() ([]string, error) {
+	p.marginPairsMtx.Lock()
+	defer p.marginPairsMtx.Unlock()
+
+	if p.marginPairs != nil {
+		return p.marginPairs, nil
+	}
+
+	balances, err := p.GetTradableBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]string, 0, len(balances))
+	for x := range balances {
+		pairs = append(pairs, x)
+	}
+	sort.Strings(pairs)
+	p.marginPairs = pairs
+	return pairs, nil
+}
+
 // GetTradableBalances returns tradable balances
 func (p *Poloniex) GetTradableBalances() (map[string]map[string]float64, error) {
 	type Response struct {
@@ -618,6 +876,31 @@ func (p *Poloniex) GetTradableBalances() (map[string]map[string]float64, error)
 	return balances, nil
 }
 
+// GetAvailableBalances returns available balances per currency for each
+// account type, optionally restricted to a single account such as "margin"
+func (p *Poloniex) GetAvailableBalances(account string) (map[string]map[string]float64, error) {
+	values := url.Values{}
+	if account != "" {
+		values.Set("account", account)
+	}
+
+	result := make(map[string]map[string]string)
+	err := p.SendAuthenticatedHTTPRequest("POST", poloniexAvailableBalances, values, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]map[string]float64)
+	for x, y := range result {
+		balances[x] = make(map[string]float64)
+		for z, w := range y {
+			balances[x][z], _ = strconv.ParseFloat(w, 64)
+		}
+	}
+
+	return balances, nil
+}
+
 // TransferBalance transfers balances between your accounts
 func (p *Poloniex) TransferBalance(currency, from, to string, amount float64) (bool, error) {
 	values := url.Values{}
@@ -653,10 +936,99 @@ func (p *Poloniex) GetMarginAccountSummary() (Margin, error) {
 	return result, nil
 }
 
+// SubmitMarginOrder validates the order against the tradable margin balances
+// for the currency pair, places a margin order and returns it with the loans
+// which were taken out to fund it. The marginBuy and marginSell responses do
+// not include loans, so they are found by comparing the used active loans
+// before and after the order. If the loans cannot be fetched after the order
+// was placed, the order is returned along with the error
+func (p *Poloniex) SubmitMarginOrder(currency string, rate, amount, lendingRate float64, buy bool) (MarginOrderResponse, error) {
+	tradable, err := p.GetTradableBalances()
+	if err != nil {
+		return MarginOrderResponse{}, err
+	}
+
+	err = validateMarginOrder(tradable, currency, rate, amount, buy)
+	if err != nil {
+		return MarginOrderResponse{}, err
+	}
+
+	before, err := p.GetActiveLoans()
+	if err != nil {
+		return MarginOrderResponse{}, err
+	}
+
+	result, err := p.PlaceMarginOrder(currency, rate, amount, lendingRate, buy)
+	if err != nil {
+		return result, err
+	}
+
+	after, err := p.GetActiveLoans()
+	if err != nil {
+		return result, fmt.Errorf("margin order %d placed but its loans could not be fetched: %w",
+			result.OrderNumber, err)
+	}
+
+	result.Loans = newLoans(before.Used, after.Used)
+	return result, nil
+}
+
+// newLoans returns the loans in after which are not in before
+func newLoans(before, after []ActiveLoan) []ActiveLoan {
+	existing := make(map[int64]bool, len(before))
+	for x := range before {
+		existing[before[x].ID] = true
+	}
+
+	var loans []ActiveLoan
+	for x := range after {
+		if !existing[after[x].ID] {
+			loans = append(loans, after[x])
+		}
+	}
+	return loans
+}
+
+// validateMarginOrder checks that a margin order is within the tradable
+// balance for the currency pair. Buy orders are checked against the base
+// currency cost and sell orders against the amount of the traded currency
+func validateMarginOrder(tradable map[string]map[string]float64, currency string, rate, amount float64, buy bool) error {
+	if amount <= 0 || rate <= 0 {
+		return errors.New("margin order amount and rate must be greater than zero")
+	}
+
+	balances, ok := tradable[currency]
+	if !ok {
+		return fmt.Errorf("no tradable margin balance for %s", currency)
+	}
+
+	currencies := common.SplitStrings(currency, "_")
+	if len(currencies) != 2 {
+		return fmt.Errorf("invalid margin currency pair %s", currency)
+	}
+
+	required, balanceCurrency := amount, currencies[1]
+	if buy {
+		required, balanceCurrency = amount*rate, currencies[0]
+	}
+
+	if required > balances[balanceCurrency] {
+		return fmt.Errorf("margin order requires %f %s which exceeds tradable balance %f",
+			required, balanceCurrency, balances[balanceCurrency])
+	}
+	return nil
+}
+
 // PlaceMarginOrder places a margin order
-func (p *Poloniex) PlaceMarginOrder(currency string, rate, amount, lendingRate float64, buy bool) (OrderResponse, error) {
-	result := OrderResponse{}
+func (p *Poloniex) PlaceMarginOrder(currency string, rate, amount, lendingRate float64, buy bool) (MarginOrderResponse, error) {
+	result := MarginOrderResponse{}
 	values := url.Values{}
+	amount, rate = p.RoundOrderPrecision(amount, rate)
+
+	err := p.CheckOrderNotional(currency, amount, rate)
+	if err != nil {
+		return result, err
+	}
 
 	var orderType string
 	if buy {
@@ -673,12 +1045,16 @@ func (p *Poloniex) PlaceMarginOrder(currency string, rate, amount, lendingRate f
 		values.Set("lendingRate", strconv.FormatFloat(lendingRate, 'f', -1, 64))
 	}
 
-	err := p.SendAuthenticatedHTTPRequest("POST", orderType, values, &result)
+	err = p.SendAuthenticatedHTTPRequest("POST", orderType, values, &result)
 
 	if err != nil {
 		return result, err
 	}
 
+	if result.Error != "" {
+		return result, errors.New(result.Error)
+	}
+
 	return result, nil
 }
 
@@ -731,165 +1107,84 @@ func (p *Poloniex) CloseMarginPosition(currency string) (bool, error) {
 	return true, nil
 }
 
-// CreateLoanOffer places a loan offer on the exchange
-func (p *Poloniex) CreateLoanOffer(currency string, amount, rate float64, duration int, autoRenew bool) (int64, error) {
-	values := url.Values{}
-	values.Set("currency", currency)
-	values.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
-	values.Set("duration", strconv.Itoa(duration))
-
-	if autoRenew {
-		values.Set("autoRenew", "1")
-	} else {
-		values.Set("autoRenew", "0")
-	}
-
-	values.Set("lendingRate", strconv.FormatFloat(rate, 'f', -1, 64))
-
-	type Response struct {
-		Success int    `json:"success"`
-		Error   string `json:"error"`
-		OrderID int64  `json:"orderID"`
-	}
-
-	result := Response{}
-
-	err := p.SendAuthenticatedHTTPRequest("POST", poloniexCreateLoanOffer, values, &result)
-
-	if err != nil {
-		return 0, err
-	}
-
-	if result.Success == 0 {
-		return 0, errors.New(result.Error)
-	}
-
-	return result.OrderID, nil
-}
-
-// CancelLoanOffer cancels a loan offer order
-func (p *Poloniex) CancelLoanOffer(orderNumber int64) (bool, error) {
-	result := GenericResponse{}
-	values := url.Values{}
-	values.Set("orderID", strconv.FormatInt(orderNumber, 10))
-
-	err := p.SendAuthenticatedHTTPRequest("POST", poloniexCancelLoanOffer, values, &result)
-
-	if err != nil {
-		return false, err
-	}
-
-	if result.Success == 0 {
-		return false, errors.New(result.Error)
-	}
-
-	return true, nil
+// SendHTTPRequest sends an unauthenticated HTTP request
+func (p *Poloniex) SendHTTPRequest(path string, result interface{}) error {
+	return p.SendWeightedHTTPRequest(1, path, result)
 }
 
-// GetOpenLoanOffers returns all open loan offers
-func (p *Poloniex) GetOpenLoanOffers() (map[string][]LoanOffer, error) {
-	type Response struct {
-		Data map[string][]LoanOffer
-	}
-	result := Response{}
-
-	err := p.SendAuthenticatedHTTPRequest("POST", poloniexOpenLoanOffers, url.Values{}, &result.Data)
-
+// SendWeightedHTTPRequest sends an unauthenticated HTTP request which consumes
+// the supplied weight from the rate limit budget
+func (p *Poloniex) SendWeightedHTTPRequest(weight int, path string, result interface{}) error {
+	var raw json.RawMessage
+	err := p.SendWeightedPayload(weight, "GET", path, nil, nil, &raw, false, p.IsVerbose())
 	if err != nil {
-		return nil, err
-	}
-
-	if result.Data == nil {
-		return nil, errors.New("there are no open loan offers")
+		err = exchange.ClassifyRequestError(p.Name, err)
+	} else {
+		err = exchange.CheckErrorResponse(p.Name, raw)
 	}
 
-	return result.Data, nil
-}
-
-// GetActiveLoans returns active loans
-func (p *Poloniex) GetActiveLoans() (ActiveLoans, error) {
-	result := ActiveLoans{}
-	err := p.SendAuthenticatedHTTPRequest("POST", poloniexActiveLoans, url.Values{}, &result)
-
-	if err != nil {
-		return result, err
+	if err == nil && result != nil {
+		err = common.JSONDecode(raw, result)
 	}
-
-	return result, nil
+	return exchange.WrapRequestError(p.Name, "GET", requestEndpoint(path), err)
 }
 
-// GetLendingHistory returns lending history for the account
-func (p *Poloniex) GetLendingHistory(start, end string) ([]LendingHistory, error) {
-	vals := url.Values{}
-
-	if start != "" {
-		vals.Set("start", start)
+// SendAuthenticatedHTTPRequest sends an authenticated HTTP request
+func (p *Poloniex) SendAuthenticatedHTTPRequest(method, endpoint string, values url.Values, result interface{}) error {
+	if !p.GetAuthenticatedAPISupport() {
+		return exchange.NewCredentialsError(p.Name)
 	}
 
-	if end != "" {
-		vals.Set("end", end)
+	if err := p.CheckAPICredentials(); err != nil {
+		return err
 	}
+	apiKey, apiSecret, _ := p.GetAPIKeys()
+	headers := make(map[string]string)
+	headers["Content-Type"] = "application/x-www-form-urlencoded"
+	headers["Key"] = apiKey
 
-	resp := []LendingHistory{}
-	err := p.SendAuthenticatedHTTPRequest("POST", poloniexLendingHistory, vals, &resp)
-
-	if err != nil {
-		return nil, err
+	if p.Nonce.Get() == 0 && p.SyncNonceWithServerTime {
+		if _, err := p.GetExchangeServerTime(); err != nil {
+			return err
+		}
 	}
-	return resp, nil
-}
+	values.Set("nonce", strconv.FormatInt(p.Nonce.Next(), 10))
+	values.Set("command", endpoint)
 
-// ToggleAutoRenew allows for the autorenew of a contract
-func (p *Poloniex) ToggleAutoRenew(orderNumber int64) (bool, error) {
-	values := url.Values{}
-	values.Set("orderNumber", strconv.FormatInt(orderNumber, 10))
-	result := GenericResponse{}
+	hmac := common.GetHMAC(common.HashSHA512, []byte(values.Encode()), []byte(apiSecret))
+	headers["Sign"] = common.HexEncodeToString(hmac)
 
-	err := p.SendAuthenticatedHTTPRequest("POST", poloniexAutoRenew, values, &result)
+	path := fmt.Sprintf("%s/%s", p.GetAPIURL(), poloniexAPITradingEndpoint)
 
+	var raw json.RawMessage
+	err := p.SendPayload(method, path, headers, bytes.NewBufferString(values.Encode()), &raw, true, p.IsVerbose())
 	if err != nil {
-		return false, err
+		err = exchange.ClassifyRequestError(p.Name, err)
+	} else {
+		err = exchange.CheckErrorResponse(p.Name, raw)
 	}
 
-	if result.Success == 0 {
-		return false, errors.New(result.Error)
+	if err == nil && result != nil {
+		err = common.JSONDecode(raw, result)
 	}
-
-	return true, nil
+	return exchange.WrapRequestError(p.Name, method, endpoint, err)
 }
 
-// SendHTTPRequest sends an unauthenticated HTTP request
-func (p *Poloniex) SendHTTPRequest(path string, result interface{}) error {
-	return p.SendPayload("GET", path, nil, nil, result, false, p.Verbose)
-}
-
-// SendAuthenticatedHTTPRequest sends an authenticated HTTP request
-func (p *Poloniex) SendAuthenticatedHTTPRequest(method, endpoint string, values url.Values, result interface{}) error {
-	if !p.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, p.Name)
-	}
-	headers := make(map[string]string)
-	headers["Content-Type"] = "application/x-www-form-urlencoded"
-	headers["Key"] = p.APIKey
-
-	if p.Nonce.Get() == 0 {
-		p.Nonce.Set(time.Now().UnixNano())
-	} else {
-		p.Nonce.Inc()
+// requestEndpoint returns the command of a public API request path
+func requestEndpoint(path string) string {
+	u, err := url.Parse(path)
+	if err != nil || u.Query().Get("command") == "" {
+		return path
 	}
-	values.Set("nonce", p.Nonce.String())
-	values.Set("command", endpoint)
-
-	hmac := common.GetHMAC(common.HashSHA512, []byte(values.Encode()), []byte(p.APISecret))
-	headers["Sign"] = common.HexEncodeToString(hmac)
-
-	path := fmt.Sprintf("%s/%s", p.APIUrl, poloniexAPITradingEndpoint)
-
-	return p.SendPayload(method, path, headers, bytes.NewBufferString(values.Encode()), result, true, p.Verbose)
+	return u.Query().Get("command")
 }
 
 // GetFee returns an estimate of fee based on type of transaction
 func (p *Poloniex) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
+	if err := feeBuilder.Validate(); err != nil {
+		return 0, err
+	}
+
 	var fee float64
 	switch feeBuilder.FeeType {
 	case exchange.CryptocurrencyTradeFee:
@@ -899,7 +1194,7 @@ func (p *Poloniex) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
 		}
 		fee = calculateTradingFee(feeInfo, feeBuilder.PurchasePrice, feeBuilder.Amount, feeBuilder.IsMaker)
 	case exchange.CryptocurrencyWithdrawalFee:
-		fee = getWithdrawalFee(feeBuilder.FirstCurrency)
+		fee = p.getWithdrawalFee(feeBuilder.FirstCurrency)
 	}
 	if fee < 0 {
 		fee = 0
@@ -917,6 +1212,18 @@ func calculateTradingFee(feeInfo Fee, purchasePrice, amount float64, isMaker boo
 	return fee * amount * purchasePrice
 }
 
-func getWithdrawalFee(currency string) float64 {
-	return WithdrawalFees[currency]
+// getWithdrawalFee returns the cached live withdrawal fee for a currency,
+// falling back to the WithdrawalFees table and then the default fee
+func (p *Poloniex) getWithdrawalFee(currency string) float64 {
+	p.withdrawalFeesMtx.RLock()
+	fee, ok := p.withdrawalFees[currency]
+	p.withdrawalFeesMtx.RUnlock()
+	if ok {
+		return fee
+	}
+
+	if fee, ok = WithdrawalFees[currency]; ok {
+		return fee
+	}
+	return poloniexDefaultWithdrawalFee
 }
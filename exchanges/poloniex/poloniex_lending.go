@@ -0,0 +1,273 @@
+package poloniex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// CreateLoanOffer places a loan offer on the exchange and returns the loan
+// offer ID
+func (p *Poloniex) CreateLoanOffer(req LoanOfferRequest) (int64, error) {
+	if err := req.Validate(); err != nil {
+		return 0, err
+	}
+
+	values := url.Values{}
+	values.Set("currency", req.Currency)
+	values.Set("amount", strconv.FormatFloat(req.Amount, 'f', -1, 64))
+	values.Set("duration", strconv.Itoa(req.Duration))
+
+	if req.AutoRenew {
+		values.Set("autoRenew", "1")
+	} else {
+		values.Set("autoRenew", "0")
+	}
+
+	values.Set("lendingRate", formatLendingRate(req.Rate))
+
+	result := CreateLoanOfferResponse{}
+	err := p.SendAuthenticatedHTTPRequest("POST", poloniexCreateLoanOffer, values, &result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if result.Success == 0 {
+		return 0, errors.New(result.Error)
+	}
+
+	return result.OrderID, nil
+}
+
+// Validate checks the loan offer request currency, amount, rate and duration
+func (l *LoanOfferRequest) Validate() error {
+	if l.Currency == "" {
+		return errors.New("loan offer currency not set")
+	}
+
+	if l.Amount <= 0 || l.Rate <= 0 {
+		return errors.New("loan offer amount and rate must be greater than zero")
+	}
+
+	if l.Duration < poloniexLoanMinDuration || l.Duration > poloniexLoanMaxDuration {
+		return fmt.Errorf("loan offer duration must be between %d and %d days",
+			poloniexLoanMinDuration, poloniexLoanMaxDuration)
+	}
+	return nil
+}
+
+// formatLendingRate converts a daily lending rate to the fixed precision
+// string expected by Poloniex
+func formatLendingRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', poloniexDecimalPlaces, 64)
+}
+
+// CancelLoanOffer cancels a loan offer order
+func (p *Poloniex) CancelLoanOffer(orderNumber int64) (bool, error) {
+	result := GenericResponse{}
+	values := url.Values{}
+	values.Set("orderID", strconv.FormatInt(orderNumber, 10))
+
+	err := p.SendAuthenticatedHTTPRequest("POST", poloniexCancelLoanOffer, values, &result)
+
+	if err != nil {
+		return false, err
+	}
+
+	if result.Success == 0 {
+		return false, errors.New(result.Error)
+	}
+
+	return true, nil
+}
+
+// GetOpenLoanOffers returns all open loan offers by currency. Poloniex returns
+// an empty array when there are no open loan offers, which is returned as an
+// empty map
+func (p *Poloniex) GetOpenLoanOffers() (map[string][]LoanOffer, error) {
+	var raw json.RawMessage
+	err := p.SendAuthenticatedHTTPRequest("POST", poloniexOpenLoanOffers, url.Values{}, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]LoanOffer)
+	if len(raw) == 0 || raw[0] == '[' || string(raw) == "null" {
+		return result, nil
+	}
+
+	err = common.JSONDecode(raw, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RepriceLoanOffers cancels open loan offers for the requested currency whose
+// rate differs from the target rate by at least the threshold and places a
+// single new offer at the target rate. The target rate is taken from the
+// percentile (0 to 1) of the public loan offer book, weighted by amount. If
+// the request amount is zero the cancelled offer amount is re-offered. The new
+// offer is validated before any offer is cancelled. Offers which fail to
+// cancel are reported in the response and are not re-offered, and no new offer
+// is placed if none were cancelled. The new loan offer ID is zero if no offers
+// needed repricing
+func (p *Poloniex) RepriceLoanOffers(req LoanOfferRequest, percentile, threshold float64) (RepriceLoanOffersResponse, error) {
+	resp := RepriceLoanOffersResponse{Failed: make(map[int64]error)}
+	loanOrders, err := p.GetLoanOrders(req.Currency)
+	if err != nil {
+		return resp, err
+	}
+
+	rate, err := loanOfferPercentileRate(loanOrders.Offers, percentile)
+	if err != nil {
+		return resp, err
+	}
+
+	openOffers, err := p.GetOpenLoanOffers()
+	if err != nil {
+		return resp, err
+	}
+
+	stale := staleLoanOffers(openOffers[req.Currency], rate, threshold)
+	if len(stale) == 0 {
+		return resp, nil
+	}
+
+	offer := req
+	offer.Rate = rate
+	if offer.Amount == 0 {
+		for x := range stale {
+			offer.Amount += stale[x].Amount
+		}
+	}
+	if err = offer.Validate(); err != nil {
+		return resp, err
+	}
+
+	var cancelledAmount float64
+	for x := range stale {
+		_, err = p.CancelLoanOffer(stale[x].ID)
+		if err != nil {
+			resp.Failed[stale[x].ID] = err
+			continue
+		}
+		resp.Cancelled = append(resp.Cancelled, stale[x].ID)
+		cancelledAmount += stale[x].Amount
+	}
+
+	if len(resp.Cancelled) == 0 {
+		return resp, fmt.Errorf("%s failed to cancel %d loan offers, no new offer placed",
+			p.Name, len(resp.Failed))
+	}
+
+	if req.Amount == 0 {
+		offer.Amount = cancelledAmount
+	}
+	resp.OfferID, err = p.CreateLoanOffer(offer)
+	return resp, err
+}
+
+// loanOfferPercentileRate returns the rate at which the cumulative offer amount
+// reaches the percentile of the total amount on the loan offer book
+func loanOfferPercentileRate(offers []LoanOrder, percentile float64) (float64, error) {
+	if percentile < 0 || percentile > 1 {
+		return 0, errors.New("loan offer percentile must be between 0 and 1")
+	}
+
+	if len(offers) == 0 {
+		return 0, errors.New("no loan offers available")
+	}
+
+	sorted := make([]LoanOrder, len(offers))
+	copy(sorted, offers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Rate < sorted[j].Rate
+	})
+
+	var total float64
+	for x := range sorted {
+		total += sorted[x].Amount
+	}
+
+	target := total * percentile
+	var cumulative float64
+	for x := range sorted {
+		cumulative += sorted[x].Amount
+		if cumulative >= target {
+			return sorted[x].Rate, nil
+		}
+	}
+	return sorted[len(sorted)-1].Rate, nil
+}
+
+// staleLoanOffers returns the offers whose rate differs from the target rate
+// by at least the threshold
+func staleLoanOffers(offers []LoanOffer, rate, threshold float64) []LoanOffer {
+	var stale []LoanOffer
+	for x := range offers {
+		if math.Abs(offers[x].Rate-rate) >= threshold {
+			stale = append(stale, offers[x])
+		}
+	}
+	return stale
+}
+
+// GetActiveLoans returns active loans
+func (p *Poloniex) GetActiveLoans() (ActiveLoans, error) {
+	result := ActiveLoans{}
+	err := p.SendAuthenticatedHTTPRequest("POST", poloniexActiveLoans, url.Values{}, &result)
+
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// GetLendingHistory returns lending history for the account
+func (p *Poloniex) GetLendingHistory(start, end string) ([]LendingHistory, error) {
+	vals := url.Values{}
+
+	if start != "" {
+		vals.Set("start", start)
+	}
+
+	if end != "" {
+		vals.Set("end", end)
+	}
+
+	resp := []LendingHistory{}
+	err := p.SendAuthenticatedHTTPRequest("POST", poloniexLendingHistory, vals, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ToggleAutoRenew allows for the autorenew of a contract
+func (p *Poloniex) ToggleAutoRenew(orderNumber int64) (bool, error) {
+	values := url.Values{}
+	values.Set("orderNumber", strconv.FormatInt(orderNumber, 10))
+	result := GenericResponse{}
+
+	err := p.SendAuthenticatedHTTPRequest("POST", poloniexAutoRenew, values, &result)
+
+	if err != nil {
+		return false, err
+	}
+
+	if result.Success == 0 {
+		return false, errors.New(result.Error)
+	}
+
+	return true, nil
+}
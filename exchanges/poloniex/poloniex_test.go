@@ -1,11 +1,30 @@
 package poloniex
 
 import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/fixture"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 var p Poloniex
@@ -33,7 +52,46 @@ func TestSetup(t *testing.T) {
 	poloniexConfig.APIKey = apiKey
 	poloniexConfig.APISecret = apiSecret
 
-	p.Setup(poloniexConfig)
+	err = p.Setup(poloniexConfig)
+	if err != nil {
+		t.Error("Test Failed - Poloniex Setup() error", err)
+	}
+}
+
+func TestFormatExchangeCurrency(t *testing.T) {
+	cfg := config.GetConfig()
+	if _, err := cfg.GetExchangeConfig("Poloniex"); err != nil {
+		cfg.LoadConfig("../../testdata/configtest.json")
+	}
+
+	var pol Poloniex
+	pol.SetDefaults()
+
+	tests := []struct {
+		first, second string
+		expected      pair.CurrencyItem
+	}{
+		{symbol.BTC, symbol.ETH, "BTC_ETH"},
+		{"btc", "eth", "BTC_ETH"},
+		{symbol.USDT, symbol.BTC, "USDT_BTC"},
+	}
+
+	for _, test := range tests {
+		currencyPair := pair.NewCurrencyPair(test.first, test.second)
+
+		if result := exchange.FormatExchangeCurrency(pol.Name, currencyPair); result != test.expected {
+			t.Errorf("Test Failed - Poloniex FormatExchangeCurrency() %s_%s expected %s got %s",
+				test.first, test.second, test.expected, result)
+		}
+
+		// The config format must match the format set by SetDefaults
+		result := currencyPair.Display(pol.RequestCurrencyPairFormat.Delimiter,
+			pol.RequestCurrencyPairFormat.Uppercase)
+		if result != test.expected {
+			t.Errorf("Test Failed - Poloniex SetDefaults() request format %s_%s expected %s got %s",
+				test.first, test.second, test.expected, result)
+		}
+	}
 }
 
 func TestGetTicker(t *testing.T) {
@@ -43,6 +101,218 @@ func TestGetTicker(t *testing.T) {
 	}
 }
 
+func TestGetTickerFixture(t *testing.T) {
+	s := fixture.NewServer()
+	defer s.Close()
+
+	err := s.AddFixture("command=returnTicker", "testdata/returnTicker.json")
+	if err != nil {
+		t.Fatal("Test faild - Poloniex fixture error", err)
+	}
+
+	var f Poloniex
+	f.SetDefaults()
+	f.APIUrl = s.URL
+
+	tick, err := f.GetTicker()
+	if err != nil {
+		t.Fatal("Test faild - Poloniex GetTicker() fixture error", err)
+	}
+
+	if len(tick) != 2 {
+		t.Fatalf("Test faild - Poloniex GetTicker() expected 2 tickers, got %d", len(tick))
+	}
+
+	ltc := tick["BTC_LTC"]
+	if ltc.Last != 0.0251 || ltc.LowestAsk != 0.02589999 || ltc.HighestBid != 0.0251 ||
+		ltc.BaseVolume != 6.16485315 || ltc.QuoteVolume != 245.82513926 ||
+		ltc.High24Hr != 0.0258 || ltc.Low24Hr != 0.02453 || ltc.IsFrozen != 0 {
+		t.Errorf("Test faild - Poloniex GetTicker() unexpected BTC_LTC ticker %+v", ltc)
+	}
+
+	if tick["BTC_XMR"].PercentChange != -0.01036414 || tick["BTC_XMR"].IsFrozen != 1 {
+		t.Errorf("Test faild - Poloniex GetTicker() unexpected BTC_XMR ticker %+v",
+			tick["BTC_XMR"])
+	}
+}
+
+func TestRunCurrencyPairsCache(t *testing.T) {
+	s := fixture.NewServer()
+	defer s.Close()
+
+	err := s.AddFixture("command=returnTicker", "testdata/returnTicker.json")
+	if err != nil {
+		t.Fatal("Test faild - Poloniex fixture error", err)
+	}
+
+	dir, err := ioutil.TempDir("", "currencypairs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var f Poloniex
+	f.SetDefaults()
+	f.APIUrl = s.URL
+	f.CurrencyPairsCacheTTL = time.Hour
+	f.CurrencyPairsCacheDir = dir
+	f.DisableAutoPairUpdates = true
+
+	stale := func() ([]string, error) { return []string{"BTC_ETH", "BTC_LTC"}, nil }
+	if _, err = f.GetCachedExchangeCurrencies(stale, true); err != nil {
+		t.Fatal("Test faild - Poloniex GetCachedExchangeCurrencies() error", err)
+	}
+
+	// Every enabled pair is cached, so the cache is used
+	f.EnabledPairs = []string{"BTC_LTC"}
+	f.Run()
+
+	pairs, err := f.GetCachedExchangeCurrencies(stale, false)
+	if err != nil || !common.StringDataCompare(pairs, "BTC_ETH") {
+		t.Errorf("Test faild - Poloniex Run() unexpectedly refreshed the currency pairs cache %v", pairs)
+	}
+
+	// BTC_XMR is missing from the cache, so it is refreshed
+	f.EnabledPairs = []string{"BTC_XMR"}
+	f.Run()
+
+	pairs, err = f.GetCachedExchangeCurrencies(stale, false)
+	if err != nil || len(pairs) != 2 || common.StringDataCompare(pairs, "BTC_ETH") {
+		t.Errorf("Test faild - Poloniex Run() did not refresh the currency pairs cache %v", pairs)
+	}
+}
+
+func TestRunReturnsWhilePolling(t *testing.T) {
+	s := fixture.NewServer()
+	defer s.Close()
+
+	err := s.AddFixture("command=returnTicker", "testdata/returnTicker.json")
+	if err != nil {
+		t.Fatal("Test faild - Poloniex fixture error", err)
+	}
+
+	var f Poloniex
+	f.SetDefaults()
+	f.APIUrl = s.URL
+	f.DisableAutoPairUpdates = true
+	f.RESTPollingEnabled = true
+	f.RESTPollingDelay = time.Millisecond
+	defer f.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		f.Run()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Test faild - Poloniex Run() did not return with REST polling enabled")
+	}
+}
+
+func TestGetExchangeServerTime(t *testing.T) {
+	s := fixture.NewServer()
+	defer s.Close()
+
+	err := s.AddFixture("command=returnTicker", "testdata/returnTicker.json")
+	if err != nil {
+		t.Fatal("Test faild - Poloniex fixture error", err)
+	}
+
+	var f Poloniex
+	f.SetDefaults()
+	f.APIUrl = s.URL
+
+	serverTime, err := f.GetExchangeServerTime()
+	if err != nil {
+		t.Fatal("Test faild - Poloniex GetExchangeServerTime() error", err)
+	}
+
+	if time.Since(serverTime) > time.Minute || f.GetServerTimeOffset() > time.Minute {
+		t.Errorf("Test faild - Poloniex GetExchangeServerTime() unexpected time %v", serverTime)
+	}
+}
+
+func TestPing(t *testing.T) {
+	s := fixture.NewServer()
+	defer s.Close()
+
+	err := s.AddFixture("command=returnTicker", "testdata/returnTicker.json")
+	if err != nil {
+		t.Fatal("Test faild - Poloniex fixture error", err)
+	}
+
+	var f Poloniex
+	f.SetDefaults()
+	f.APIUrl = s.URL
+
+	if err = f.Ping(); err != nil {
+		t.Error("Test faild - Poloniex Ping() error", err)
+	}
+
+	err = f.AuthenticatedPing()
+	if pingErr, ok := err.(*exchange.PingError); !ok || pingErr.Type != exchange.PingAuthError {
+		t.Errorf("Test faild - Poloniex AuthenticatedPing() expected authentication error, received %v", err)
+	}
+}
+
+func TestDefaultTLSPins(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer s.Close()
+
+	var f Poloniex
+	f.SetDefaults()
+	f.APIUrl = s.URL
+	f.SetHTTPClient(s.Client())
+
+	err := f.SetHTTPClientPins(nil)
+	if err != nil {
+		t.Fatal("Test faild - Poloniex SetHTTPClientPins() error", err)
+	}
+
+	_, err = f.GetTicker()
+	if !errors.Is(err, common.ErrCertificatePinMismatch) {
+		t.Errorf("Test faild - Poloniex GetTicker() expected pin mismatch, received %v", err)
+	}
+
+	pin := sha256.Sum256(s.Certificate().RawSubjectPublicKeyInfo)
+	err = f.SetHTTPClientPins([]string{common.Base64Encode(pin[:])})
+	if err != nil {
+		t.Fatal("Test faild - Poloniex SetHTTPClientPins() error", err)
+	}
+
+	if _, err = f.GetTicker(); err != nil {
+		t.Error("Test faild - Poloniex GetTicker() configured pin error", err)
+	}
+}
+
+func TestStop(t *testing.T) {
+	var f Poloniex
+	f.SetDefaults()
+	if err := f.Stop(); err != nil {
+		t.Fatal("Test faild - Poloniex Stop() error", err)
+	}
+
+	var wg sync.WaitGroup
+	f.Start(&wg)
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Test faild - Poloniex Run() did not return after Stop()")
+	}
+}
+
 func TestGetVolume(t *testing.T) {
 	_, err := p.GetVolume()
 	if err != nil {
@@ -71,6 +341,41 @@ func TestGetChartData(t *testing.T) {
 	}
 }
 
+func TestGetChartDataInvalidPeriod(t *testing.T) {
+	_, err := p.GetChartData("BTC_XMR", "1405699200", "1405699400", "60")
+	if err == nil {
+		t.Error("Test faild - Poloniex GetChartData() error cannot be nil")
+	}
+}
+
+func TestGetHistoricCandles(t *testing.T) {
+	s := fixture.NewServer()
+	defer s.Close()
+
+	err := s.AddFixture("command=returnChartData.*currencyPair=BTC_XMR.*period=300",
+		"testdata/returnChartData.json")
+	if err != nil {
+		t.Fatal("Test faild - Poloniex fixture error", err)
+	}
+
+	var f Poloniex
+	f.SetDefaults()
+	f.APIUrl = s.URL
+
+	candles, err := f.GetHistoricCandles(pair.NewCurrencyPairDelimiter("BTC_XMR", "_"),
+		time.Unix(1405699200, 0), time.Unix(1405699500, 0), time.Minute*5)
+	if err != nil {
+		t.Fatal("Test faild - Poloniex GetHistoricCandles() error", err)
+	}
+
+	if len(candles) != 2 || !candles[0].Time.Equal(time.Unix(1405699200, 0)) ||
+		candles[0].Open != 0.00404545 || candles[0].High != 0.0045388 ||
+		candles[0].Low != 0.00403001 || candles[0].Close != 0.00435873 ||
+		candles[0].Volume != 44.34555992 || candles[1].Close != 0.0044 {
+		t.Errorf("Test faild - Poloniex GetHistoricCandles() unexpected candles %+v", candles)
+	}
+}
+
 func TestGetCurrencies(t *testing.T) {
 	_, err := p.GetCurrencies()
 	if err != nil {
@@ -85,6 +390,14 @@ func TestGetLoanOrders(t *testing.T) {
 	}
 }
 
+func TestRoundOrderPrecision(t *testing.T) {
+	amount, rate := p.RoundOrderPrecision(1.123456789, 0.000000015)
+	if amount != 1.12345679 || rate != 0.00000002 {
+		t.Errorf("Test Failed - Poloniex RoundOrderPrecision() received %f @ %.8f",
+			amount, rate)
+	}
+}
+
 func setFeeBuilder() exchange.FeeBuilder {
 	return exchange.FeeBuilder{
 		Amount:              1,
@@ -99,6 +412,252 @@ func setFeeBuilder() exchange.FeeBuilder {
 	}
 }
 
+func TestValidateMarginOrder(t *testing.T) {
+	tradable := map[string]map[string]float64{
+		"BTC_DASH": {"BTC": 1, "DASH": 50},
+	}
+
+	if err := validateMarginOrder(tradable, "BTC_DASH", 0.02, 40, true); err != nil {
+		t.Error("Test Failed - Poloniex validateMarginOrder() error", err)
+	}
+	if err := validateMarginOrder(tradable, "BTC_DASH", 0.02, 60, true); err == nil {
+		t.Error("Test Failed - Poloniex validateMarginOrder() buy exceeding balance")
+	}
+	if err := validateMarginOrder(tradable, "BTC_DASH", 0.02, 50, false); err != nil {
+		t.Error("Test Failed - Poloniex validateMarginOrder() error", err)
+	}
+	if err := validateMarginOrder(tradable, "BTC_DASH", 0.02, 51, false); err == nil {
+		t.Error("Test Failed - Poloniex validateMarginOrder() sell exceeding balance")
+	}
+	if err := validateMarginOrder(tradable, "BTC_XMR", 0.02, 1, false); err == nil {
+		t.Error("Test Failed - Poloniex validateMarginOrder() unknown pair")
+	}
+	if err := validateMarginOrder(tradable, "BTC_DASH", 0.02, 0, true); err == nil {
+		t.Error("Test Failed - Poloniex validateMarginOrder() zero amount")
+	}
+}
+
+func TestMarginOrderResponse(t *testing.T) {
+	var resp MarginOrderResponse
+	err := common.JSONDecode([]byte(`{"success":1,"message":"Margin order placed.","orderNumber":"154407998","resultingTrades":{"BTC_DASH":[{"amount":"1.00000000","date":"2015-05-10 22:47:05","rate":"0.01383692","total":"0.01383692","tradeID":"1213556","type":"buy"}]}}`), &resp)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex MarginOrderResponse decode error", err)
+	}
+	if resp.OrderNumber != 154407998 || len(resp.Trades["BTC_DASH"]) != 1 ||
+		resp.Trades["BTC_DASH"][0].TradeID != 1213556 {
+		t.Errorf("Test Failed - Poloniex MarginOrderResponse unexpected result %+v", resp)
+	}
+}
+
+func TestNewLoans(t *testing.T) {
+	before := []ActiveLoan{{ID: 1, Currency: "BTC"}}
+	after := []ActiveLoan{{ID: 1, Currency: "BTC"}, {ID: 2, Currency: "BTC", Amount: 0.5}}
+
+	loans := newLoans(before, after)
+	if len(loans) != 1 || loans[0].ID != 2 || loans[0].Amount != 0.5 {
+		t.Errorf("Test Failed - Poloniex newLoans() unexpected result %+v", loans)
+	}
+
+	if loans = newLoans(after, after); len(loans) != 0 {
+		t.Errorf("Test Failed - Poloniex newLoans() unexpected result %+v", loans)
+	}
+}
+
+func TestFormatLendingRate(t *testing.T) {
+	if r := formatLendingRate(0.0002); r != "0.00020000" {
+		t.Errorf("Test Failed - Poloniex formatLendingRate() unexpected value %s", r)
+	}
+}
+
+func TestCreateLoanOfferValidation(t *testing.T) {
+	_, err := p.CreateLoanOffer(LoanOfferRequest{Currency: "BTC", Amount: 1, Rate: 0.0002, Duration: 1})
+	if err == nil {
+		t.Error("Test Failed - Poloniex CreateLoanOffer() invalid duration")
+	}
+
+	_, err = p.CreateLoanOffer(LoanOfferRequest{Currency: "BTC", Rate: 0.0002, Duration: 2})
+	if err == nil {
+		t.Error("Test Failed - Poloniex CreateLoanOffer() invalid amount")
+	}
+}
+
+func TestLoanOfferPercentileRate(t *testing.T) {
+	offers := []LoanOrder{
+		{Rate: 0.0003, Amount: 2},
+		{Rate: 0.0001, Amount: 1},
+		{Rate: 0.0002, Amount: 1},
+	}
+
+	rate, err := loanOfferPercentileRate(offers, 0.5)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex loanOfferPercentileRate() error", err)
+	}
+	if rate != 0.0002 {
+		t.Errorf("Test Failed - Poloniex loanOfferPercentileRate() unexpected rate %v", rate)
+	}
+
+	rate, _ = loanOfferPercentileRate(offers, 0.75)
+	if rate != 0.0003 {
+		t.Errorf("Test Failed - Poloniex loanOfferPercentileRate() unexpected rate %v", rate)
+	}
+
+	_, err = loanOfferPercentileRate(offers, 1.5)
+	if err == nil {
+		t.Error("Test Failed - Poloniex loanOfferPercentileRate() invalid percentile")
+	}
+
+	_, err = loanOfferPercentileRate(nil, 0.5)
+	if err == nil {
+		t.Error("Test Failed - Poloniex loanOfferPercentileRate() empty offers")
+	}
+}
+
+func TestStaleLoanOffers(t *testing.T) {
+	offers := []LoanOffer{
+		{ID: 1, Rate: 0.00020},
+		{ID: 2, Rate: 0.00021},
+		{ID: 3, Rate: 0.00030},
+	}
+
+	stale := staleLoanOffers(offers, 0.0002, 0.00005)
+	if len(stale) != 1 || stale[0].ID != 3 {
+		t.Errorf("Test Failed - Poloniex staleLoanOffers() unexpected result %+v", stale)
+	}
+}
+
+func TestRepriceLoanOffers(t *testing.T) {
+	var cancelled []string
+	var offered url.Values
+	openOffers := `{"BTC":[{"id":1,"rate":"0.0003","amount":"1","duration":2,"autoRenew":0,"date":"2018-01-02 03:04:05"},{"id":2,"rate":"0.0004","amount":"2","duration":2,"autoRenew":0,"date":"2018-01-02 03:04:05"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("command") == "returnLoanOrders" {
+			fmt.Fprint(w, `{"offers":[{"rate":"0.0002","amount":"10","rangeMin":2,"rangeMax":2}],"demands":[]}`)
+			return
+		}
+
+		r.ParseForm()
+		switch r.PostForm.Get("command") {
+		case poloniexOpenLoanOffers:
+			fmt.Fprint(w, openOffers)
+		case poloniexCancelLoanOffer:
+			cancelled = append(cancelled, r.PostForm.Get("orderID"))
+			if r.PostForm.Get("orderID") == "2" {
+				fmt.Fprint(w, `{"success":0,"error":"Invalid order number."}`)
+				return
+			}
+			fmt.Fprint(w, `{"success":1,"message":"Loan offer canceled."}`)
+		case poloniexCreateLoanOffer:
+			offered = r.PostForm
+			fmt.Fprint(w, `{"success":1,"message":"Loan order placed.","orderID":1337}`)
+		}
+	}))
+	defer server.Close()
+
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.APIUrl = server.URL
+	polo.AuthenticatedAPISupport = true
+	polo.SetAPIKeys("key", "secret", "", false)
+
+	// An invalid offer is rejected before any offer is cancelled
+	_, err := polo.RepriceLoanOffers(LoanOfferRequest{Currency: "BTC", Duration: 1}, 0.5, 0.00005)
+	if err == nil || len(cancelled) != 0 {
+		t.Error("Test Failed - Poloniex RepriceLoanOffers() cancelled offers for an invalid offer", err)
+	}
+
+	resp, err := polo.RepriceLoanOffers(LoanOfferRequest{Currency: "BTC", Duration: 2}, 0.5, 0.00005)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex RepriceLoanOffers() error", err)
+	}
+	if resp.OfferID != 1337 || len(resp.Cancelled) != 1 || resp.Cancelled[0] != 1 || resp.Failed[2] == nil {
+		t.Errorf("Test Failed - Poloniex RepriceLoanOffers() unexpected result %+v", resp)
+	}
+	if offered.Get("amount") != "1" || offered.Get("lendingRate") != "0.00020000" {
+		t.Error("Test Failed - Poloniex RepriceLoanOffers() unexpected offer", offered)
+	}
+
+	openOffers = `[]`
+	resp, err = polo.RepriceLoanOffers(LoanOfferRequest{Currency: "BTC", Duration: 2}, 0.5, 0.00005)
+	if err != nil || resp.OfferID != 0 {
+		t.Error("Test Failed - Poloniex RepriceLoanOffers() unexpected result without open offers", resp, err)
+	}
+}
+
+func TestActiveLoansResponse(t *testing.T) {
+	var resp ActiveLoans
+	err := common.JSONDecode([]byte(`{"provided":[{"id":75073,"currency":"LTC","rate":"0.00020000","amount":"0.72234880","range":2,"autoRenew":0,"date":"2015-05-10 23:45:05","fees":"0.00006000"}],"used":[]}`), &resp)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex ActiveLoans decode error", err)
+	}
+	if len(resp.Provided) != 1 || resp.Provided[0].Currency != "LTC" ||
+		resp.Provided[0].Rate != 0.0002 || resp.Provided[0].Duration != 2 {
+		t.Errorf("Test Failed - Poloniex ActiveLoans unexpected result %+v", resp)
+	}
+}
+
+func TestConvertOpenOrders(t *testing.T) {
+	var data []Order
+	err := common.JSONDecode([]byte(`[{"orderNumber":"120466","type":"sell","rate":"0.025","startingAmount":"100","amount":"40","total":"1","date":"2018-01-02 03:04:05","margin":0}]`), &data)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex open orders decode error", err)
+	}
+
+	orders, err := p.convertOpenOrders("BTC_LTC", data)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex convertOpenOrders() error", err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("Test Failed - Poloniex convertOpenOrders() unexpected length %d", len(orders))
+	}
+
+	o := orders[0]
+	if o.ID != 120466 || o.OrderSide != string(exchange.OrderSideSell()) ||
+		o.BaseCurrency != "BTC" || o.QuoteCurrency != "LTC" ||
+		o.Price != 0.025 || o.Amount != 100 || o.OpenVolume != 40 ||
+		o.CreationTime != 1514862245 {
+		t.Errorf("Test Failed - Poloniex convertOpenOrders() unexpected result %+v", o)
+	}
+}
+
+func TestConvertTradeHistory(t *testing.T) {
+	var data []AuthentictedTradeHistory
+	err := common.JSONDecode([]byte(`[{"globalTradeID":25129732,"tradeID":"6325758","date":"2016-04-05 08:08:40","rate":"0.02565498","amount":"0.10000000","total":"0.00256549","fee":"0.00200000","orderNumber":"34225313575","type":"buy","category":"exchange"}]`), &data)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex trade history decode error", err)
+	}
+
+	orders, err := p.convertTradeHistory("BTC_ETH", data)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex convertTradeHistory() error", err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("Test Failed - Poloniex convertTradeHistory() unexpected length %d", len(orders))
+	}
+
+	o := orders[0]
+	if o.ID != 34225313575 || o.OrderSide != string(exchange.OrderSideBuy()) ||
+		o.Price != 0.02565498 || o.Amount != 0.1 || o.Status != "filled" ||
+		o.CreationTime != 1459843720 {
+		t.Errorf("Test Failed - Poloniex convertTradeHistory() unexpected result %+v", o)
+	}
+}
+
+func TestGetWithdrawalFee(t *testing.T) {
+	var withdrawer Poloniex
+	if fee := withdrawer.getWithdrawalFee(symbol.BTC); fee != WithdrawalFees[symbol.BTC] {
+		t.Errorf("Test Failed - Poloniex getWithdrawalFee() unexpected fallback fee %v", fee)
+	}
+
+	withdrawer.setWithdrawalFees(map[string]Currencies{symbol.BTC: {TxFee: 0.0001}})
+	if fee := withdrawer.getWithdrawalFee(symbol.BTC); fee != 0.0001 {
+		t.Errorf("Test Failed - Poloniex getWithdrawalFee() unexpected live fee %v", fee)
+	}
+
+	if fee := withdrawer.getWithdrawalFee("hello"); fee != poloniexDefaultWithdrawalFee {
+		t.Errorf("Test Failed - Poloniex getWithdrawalFee() unexpected default fee %v", fee)
+	}
+}
+
 func TestGetFee(t *testing.T) {
 	p.SetDefaults()
 	TestSetup(t)
@@ -127,15 +686,15 @@ func TestGetFee(t *testing.T) {
 			t.Errorf("Test Failed - GetFee() error. Expected: %f, Recieved: %f", float64(0.001), resp)
 			t.Error(err)
 		}
+	}
 
-		// CryptocurrencyTradeFee Negative purchase price
-		feeBuilder = setFeeBuilder()
-		feeBuilder.PurchasePrice = -1000
-		if resp, err := p.GetFee(feeBuilder); resp != float64(0) || err != nil {
-			t.Errorf("Test Failed - GetFee() error. Expected: %f, Recieved: %f", float64(0), resp)
-			t.Error(err)
-		}
+	// CryptocurrencyTradeFee Negative purchase price
+	feeBuilder = setFeeBuilder()
+	feeBuilder.PurchasePrice = -1000
+	if _, err := p.GetFee(feeBuilder); err == nil {
+		t.Error("Test Failed - GetFee() error cannot be nil for negative purchase price")
 	}
+
 	// CryptocurrencyWithdrawalFee Basic
 	feeBuilder = setFeeBuilder()
 	feeBuilder.FeeType = exchange.CryptocurrencyWithdrawalFee
@@ -179,6 +738,18 @@ func TestGetFee(t *testing.T) {
 	}
 }
 
+func TestCalculateTradingFee(t *testing.T) {
+	feeInfo := Fee{MakerFee: 0.001, TakerFee: 0.002}
+
+	if fee := calculateTradingFee(feeInfo, 1000, 1, true); fee != 1 {
+		t.Errorf("Test Failed - calculateTradingFee() maker error. Expected: %f, Recieved: %f", float64(1), fee)
+	}
+
+	if fee := calculateTradingFee(feeInfo, 1000, 1, false); fee != 2 {
+		t.Errorf("Test Failed - calculateTradingFee() taker error. Expected: %f, Recieved: %f", float64(2), fee)
+	}
+}
+
 func TestFormatWithdrawPermissions(t *testing.T) {
 	// Arrange
 	p.SetDefaults()
@@ -190,3 +761,843 @@ func TestFormatWithdrawPermissions(t *testing.T) {
 		t.Errorf("Expected: %s, Recieved: %s", expectedResult, withdrawPermissions)
 	}
 }
+
+func TestSendAuthenticatedHTTPRequestNoCredentials(t *testing.T) {
+	var polo Poloniex
+	polo.Name = "Poloniex"
+	polo.AuthenticatedAPISupport = true
+	polo.SetAPIKeys("", "secret", "", false)
+
+	err := polo.SendAuthenticatedHTTPRequest("POST", poloniexBalances, url.Values{}, nil)
+	if err == nil || err.Error() != "API credentials not set for Poloniex" {
+		t.Error("Test Failed - Poloniex SendAuthenticatedHTTPRequest() unexpected error", err)
+	}
+}
+
+func TestSendAuthenticatedHTTPRequestIPNotWhitelisted(t *testing.T) {
+	s := fixture.NewServer()
+	defer s.Close()
+
+	err := s.AddFixture("tradingApi", "testdata/ipNotAllowed.json")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex fixture error", err)
+	}
+
+	var f Poloniex
+	f.SetDefaults()
+	f.APIUrl = s.URL
+	f.AuthenticatedAPISupport = true
+	f.SetAPIKeys("key", "secret", "", false)
+
+	_, err = f.GetBalances()
+	if err == nil || !common.StringContains(err.Error(), "IP address is not whitelisted") {
+		t.Error("Test Failed - Poloniex GetBalances() expected IP whitelist error, received", err)
+	}
+}
+
+func TestWithdrawExchangeFunds(t *testing.T) {
+	_, err := p.WithdrawExchangeFunds(exchange.WithdrawalRequest{Type: exchange.FiatWithdrawal})
+	if err != exchange.ErrWithdrawTypeNotSupported {
+		t.Error("Test Failed - Poloniex WithdrawExchangeFunds() expected not supported error, received", err)
+	}
+
+	_, err = p.WithdrawExchangeFunds(exchange.WithdrawalRequest{
+		Type:     exchange.CryptoWithdrawal,
+		Currency: "BTC",
+		Amount:   1,
+	})
+	if err == nil {
+		t.Error("Test Failed - Poloniex WithdrawExchangeFunds() returned nil error without an address")
+	}
+}
+
+func TestWithdrawPaymentIDRequired(t *testing.T) {
+	_, err := p.Withdraw("STR", "someaddr", "", 1)
+	if err == nil {
+		t.Error("Test Failed - Poloniex Withdraw() returned nil error without a payment ID")
+	}
+}
+
+func TestWithdrawBlockedAddress(t *testing.T) {
+	var polo Poloniex
+	polo.SetWithdrawalAddressAllowlist(map[string][]string{"BTC": {"allowed"}})
+
+	_, err := polo.Withdraw("BTC", "someaddr", "", 1)
+	if _, ok := err.(*exchange.BlockedAddressError); !ok {
+		t.Error("Test Failed - Poloniex Withdraw() expected blocked address error, received", err)
+	}
+}
+
+func TestErrorClasses(t *testing.T) {
+	var status int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch status {
+		case http.StatusForbidden:
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"error":"Forbidden"}`)
+		case http.StatusTooManyRequests:
+			w.WriteHeader(status)
+			fallthrough
+		default:
+			fmt.Fprint(w, `{"error":"Please do not make more than 6 API calls per second."}`)
+		}
+	}))
+	defer server.Close()
+
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.APIUrl = server.URL
+	polo.AuthenticatedAPISupport = true
+	polo.SetAPIKeys("key", "secret", "", false)
+
+	if _, err := polo.GetTicker(); !errors.Is(err, exchange.ErrRateLimited) {
+		t.Error("Test Failed - Poloniex GetTicker() expected rate limited error, received", err)
+	}
+
+	if _, err := polo.GetCompleteBalances(); !errors.Is(err, exchange.ErrRateLimited) {
+		t.Error("Test Failed - Poloniex GetCompleteBalances() expected rate limited error, received", err)
+	}
+
+	_, err := polo.GetTicker()
+	if err == nil || !strings.HasPrefix(err.Error(), "poloniex: GET returnTicker: ") {
+		t.Error("Test Failed - Poloniex GetTicker() expected endpoint context, received", err)
+	}
+
+	_, err = polo.GetCompleteBalances()
+	if err == nil || !strings.HasPrefix(err.Error(), "poloniex: POST returnCompleteBalances: ") {
+		t.Error("Test Failed - Poloniex GetCompleteBalances() expected endpoint context, received", err)
+	}
+
+	status = http.StatusTooManyRequests
+	_, err = polo.GetTicker()
+	if !errors.Is(err, exchange.ErrRateLimited) || request.StatusCode(err) != status {
+		t.Error("Test Failed - Poloniex GetTicker() expected rate limited HTTP error, received", err)
+	}
+
+	_, err = polo.GetCompleteBalances()
+	if !errors.Is(err, exchange.ErrRateLimited) || request.StatusCode(err) != status {
+		t.Error("Test Failed - Poloniex GetCompleteBalances() expected rate limited HTTP error, received", err)
+	}
+
+	status = http.StatusForbidden
+	_, err = polo.GetCompleteBalances()
+	if !errors.Is(err, exchange.ErrAuthFailed) || request.StatusCode(err) != status {
+		t.Error("Test Failed - Poloniex GetCompleteBalances() expected auth failed HTTP error, received", err)
+	}
+
+	if err := polo.CancelExchangeOrder(1); err != exchange.ErrNotYetImplemented {
+		t.Error("Test Failed - Poloniex CancelExchangeOrder() expected not yet implemented error, received", err)
+	}
+}
+
+func TestGetEnabledCurrenciesByQuote(t *testing.T) {
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.EnabledPairs = []string{"BTC_LTC", "BTC_ETH", "USDT_BTC"}
+
+	c := polo.GetEnabledCurrenciesByQuote("BTC")
+	if len(c) != 2 || c[0].SecondCurrency.String() != "LTC" || c[1].SecondCurrency.String() != "ETH" {
+		t.Errorf("Test Failed - Poloniex GetEnabledCurrenciesByQuote() unexpected pairs %v", c)
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	var polo Poloniex
+	polo.SetDefaults()
+
+	c := polo.Capabilities()
+	if !c.SupportsMargin || !c.SupportsWebsocket || !c.SupportsOHLC ||
+		c.SupportsOrderCancellation || c.SupportsFiat {
+		t.Errorf("Test Failed - Poloniex Capabilities() unexpected values %+v", c)
+	}
+}
+
+func TestPlaceOrderMaxNotional(t *testing.T) {
+	var polo Poloniex
+	polo.SetMaxOrderNotional(1, map[string]float64{"BTC_ETH": 0.5})
+
+	_, err := polo.PlaceOrder("BTC_ETH", 0.05, 20, false, false, false, true, "")
+	if _, ok := err.(*exchange.OrderSizeError); !ok {
+		t.Error("Test Failed - Poloniex PlaceOrder() expected order size error, received", err)
+	}
+
+	_, err = polo.PlaceMarginOrder("BTC_LTC", 0.01, 200, 0, true)
+	if _, ok := err.(*exchange.OrderSizeError); !ok {
+		t.Error("Test Failed - Poloniex PlaceMarginOrder() expected order size error, received", err)
+	}
+}
+
+func TestSubmitOrderPostOnly(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.PostForm
+		fmt.Fprint(w, `{"orderNumber":"31226040","resultingTrades":[]}`)
+	}))
+	defer server.Close()
+
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.APIUrl = server.URL
+	polo.AuthenticatedAPISupport = true
+	polo.SetAPIKeys("key", "secret", "", false)
+
+	req := exchange.OrderSubmission{
+		Pair:     pair.NewCurrencyPair("BTC", "ETH"),
+		Side:     exchange.OrderSideBuy(),
+		Type:     exchange.OrderTypeMarket(),
+		Amount:   1,
+		Price:    0.01,
+		PostOnly: true,
+	}
+	if _, err := polo.SubmitOrder(req); err == nil {
+		t.Error("Test Failed - Poloniex SubmitOrder() returned nil error on market order")
+	}
+
+	req.Type = exchange.OrderTypeLimit()
+	resp, err := polo.SubmitOrder(req)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex SubmitOrder() error", err)
+	}
+
+	if resp.OrderID != 31226040 || resp.Status != "open" {
+		t.Error("Test Failed - Poloniex SubmitOrder() unexpected response", resp)
+	}
+
+	if form.Get("command") != "buy" || form.Get("postOnly") != "1" {
+		t.Error("Test Failed - Poloniex SubmitOrder() unexpected request", form)
+	}
+}
+
+func TestSubmitOrderCheckBalance(t *testing.T) {
+	var orders int
+	var balancesUnavailable bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("command") == "returnCompleteBalances" {
+			if balancesUnavailable {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			fmt.Fprint(w, `{"BTC":{"available":"0.5","onOrders":"0.5","btcValue":"1"},"ETH":{"available":"2","onOrders":"0","btcValue":"0.1"}}`)
+			return
+		}
+		orders++
+		fmt.Fprint(w, `{"orderNumber":"31226040","resultingTrades":[]}`)
+	}))
+	defer server.Close()
+
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.APIUrl = server.URL
+	polo.AuthenticatedAPISupport = true
+	polo.SetAPIKeys("key", "secret", "", false)
+	polo.CheckOrderBalance = true
+
+	// Buying 20 ETH at 0.05 BTC requires 1 BTC, only 0.5 BTC is available
+	req := exchange.OrderSubmission{
+		Pair:   pair.NewCurrencyPair("BTC", "ETH"),
+		Side:   exchange.OrderSideBuy(),
+		Type:   exchange.OrderTypeLimit(),
+		Amount: 20,
+		Price:  0.05,
+	}
+	_, err := polo.SubmitOrder(req)
+	if _, ok := err.(*exchange.InsufficientBalanceError); !ok {
+		t.Error("Test Failed - Poloniex SubmitOrder() expected insufficient balance error, received", err)
+	}
+
+	req.Side = exchange.OrderSideSell()
+	req.Amount = 2
+	if _, err = polo.SubmitOrder(req); err != nil {
+		t.Error("Test Failed - Poloniex SubmitOrder() error", err)
+	}
+
+	if orders != 1 {
+		t.Error("Test Failed - Poloniex SubmitOrder() unexpected order count", orders)
+	}
+
+	// A failed balance check never reaches the exchange, so retrying the
+	// client order ID places the order
+	req.ClientID = "1337"
+	balancesUnavailable = true
+	if _, err = polo.SubmitOrder(req); err == nil {
+		t.Error("Test Failed - Poloniex SubmitOrder() expected balance check error")
+	}
+	balancesUnavailable = false
+	if _, err = polo.SubmitOrder(req); err != nil {
+		t.Error("Test Failed - Poloniex SubmitOrder() error", err)
+	}
+	if orders != 2 {
+		t.Error("Test Failed - Poloniex SubmitOrder() unexpected order count", orders)
+	}
+}
+
+func TestSubmitOrderTimeInForce(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.PostForm
+		fmt.Fprint(w, `{"orderNumber":"31226040","resultingTrades":[]}`)
+	}))
+	defer server.Close()
+
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.APIUrl = server.URL
+	polo.AuthenticatedAPISupport = true
+	polo.SetAPIKeys("key", "secret", "", false)
+
+	tests := map[exchange.TimeInForce]string{
+		exchange.ImmediateOrCancel: "immediateOrCancel",
+		exchange.FillOrKill:        "fillOrKill",
+	}
+
+	for tif, param := range tests {
+		_, err := polo.SubmitOrder(exchange.OrderSubmission{
+			Pair:        pair.NewCurrencyPair("BTC", "ETH"),
+			Side:        exchange.OrderSideSell(),
+			Type:        exchange.OrderTypeLimit(),
+			Amount:      1,
+			Price:       0.01,
+			TimeInForce: tif,
+		})
+		if err != nil {
+			t.Fatal("Test Failed - Poloniex SubmitOrder() error", err)
+		}
+
+		if form.Get("command") != "sell" || form.Get(param) != "1" {
+			t.Errorf("Test Failed - Poloniex SubmitOrder() %s unexpected request %v", tif, form)
+		}
+	}
+}
+
+func TestSubmitOrderStopLimit(t *testing.T) {
+	_, err := p.SubmitOrder(exchange.OrderSubmission{
+		Pair:      pair.NewCurrencyPair("BTC", "ETH"),
+		Side:      exchange.OrderSideSell(),
+		Type:      exchange.OrderTypeStopLimit(),
+		Amount:    1,
+		Price:     1,
+		StopPrice: 1.1,
+	})
+	if err != exchange.ErrOrderTypeNotSupported {
+		t.Error("Test Failed - Poloniex SubmitOrder() expected order type not supported error, received", err)
+	}
+}
+
+func TestSubmitExchangeOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"orderNumber":"31226040","resultingTrades":[]}`)
+	}))
+	defer server.Close()
+
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.APIUrl = server.URL
+	polo.AuthenticatedAPISupport = true
+	polo.SetAPIKeys("key", "secret", "", false)
+
+	orderID, err := polo.SubmitExchangeOrder(pair.NewCurrencyPair("BTC", "ETH"),
+		exchange.OrderSideBuy(), exchange.OrderTypeLimit(), 1, 0.01, "")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex SubmitExchangeOrder() error", err)
+	}
+
+	if orderID != 31226040 {
+		t.Error("Test Failed - Poloniex SubmitExchangeOrder() unexpected order ID", orderID)
+	}
+}
+
+func TestConvertOrderResponse(t *testing.T) {
+	order := OrderResponse{
+		OrderNumber: 31226040,
+		Trades: []ResultingTrades{
+			{Amount: 0.5, Date: "2014-10-18 23:03:21", Rate: 0.01, TradeID: 1, Type: "buy"},
+			{Amount: 0.25, Date: "2014-10-18 23:03:21", Rate: 0.011, TradeID: 2, Type: "buy"},
+		},
+	}
+
+	resp, err := p.convertOrderResponse(order, 1, exchange.GoodTillCancelled)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex convertOrderResponse() error", err)
+	}
+
+	if resp.OrderID != 31226040 || resp.Status != "open" || len(resp.Fills) != 2 {
+		t.Error("Test Failed - Poloniex convertOrderResponse() unexpected response", resp)
+	}
+
+	if resp.Fills[0].TID != 1 || resp.Fills[0].Timestamp != 1413673401 || resp.Fills[1].Price != 0.011 {
+		t.Error("Test Failed - Poloniex convertOrderResponse() unexpected fills", resp.Fills)
+	}
+
+	resp, _ = p.convertOrderResponse(order, 1, exchange.ImmediateOrCancel)
+	if resp.Status != "cancelled" {
+		t.Error("Test Failed - Poloniex convertOrderResponse() expected cancelled status, received", resp.Status)
+	}
+
+	resp, _ = p.convertOrderResponse(order, 0.75, exchange.GoodTillCancelled)
+	if resp.Status != "filled" {
+		t.Error("Test Failed - Poloniex convertOrderResponse() expected filled status, received", resp.Status)
+	}
+}
+
+func TestSubmitOrderClientID(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.PostForm
+		fmt.Fprint(w, `{"orderNumber":"31226040","clientOrderId":"1337","resultingTrades":[]}`)
+	}))
+	defer server.Close()
+
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.APIUrl = server.URL
+	polo.AuthenticatedAPISupport = true
+	polo.SetAPIKeys("key", "secret", "", false)
+
+	req := exchange.OrderSubmission{
+		Pair:     pair.NewCurrencyPair("BTC", "ETH"),
+		Side:     exchange.OrderSideBuy(),
+		Type:     exchange.OrderTypeLimit(),
+		Amount:   1,
+		Price:    0.01,
+		ClientID: "1337",
+	}
+	if _, err := polo.SubmitOrder(req); err != nil {
+		t.Fatal("Test Failed - Poloniex SubmitOrder() error", err)
+	}
+
+	if form.Get("clientOrderId") != "1337" {
+		t.Error("Test Failed - Poloniex SubmitOrder() unexpected client order ID", form.Get("clientOrderId"))
+	}
+
+	req.ClientID = "invalid"
+	if _, err := polo.SubmitOrder(req); err == nil {
+		t.Error("Test Failed - Poloniex SubmitOrder() returned nil error on invalid client ID")
+	}
+}
+
+func TestGetExchangeOrderInfoByClientID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"BTC_ETH":[{"orderNumber":"120466","type":"sell","rate":"0.025","startingAmount":"100","amount":"40","total":"1","date":"2018-01-02 03:04:05","margin":0,"clientOrderId":"1337"}],"BTC_LTC":[]}`)
+	}))
+	defer server.Close()
+
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.APIUrl = server.URL
+	polo.AuthenticatedAPISupport = true
+	polo.SetAPIKeys("key", "secret", "", false)
+
+	order, err := polo.GetExchangeOrderInfoByClientID("1337")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex GetExchangeOrderInfoByClientID() error", err)
+	}
+
+	if order.ID != 120466 || order.ClientID != "1337" {
+		t.Error("Test Failed - Poloniex GetExchangeOrderInfoByClientID() unexpected order", order)
+	}
+
+	order, err = polo.GetExchangeOrderInfo(120466)
+	if err != nil || order.ClientID != "1337" {
+		t.Error("Test Failed - Poloniex GetExchangeOrderInfo() unexpected result", order, err)
+	}
+
+	if _, err = polo.GetExchangeOrderInfoByClientID("1"); err == nil {
+		t.Error("Test Failed - Poloniex GetExchangeOrderInfoByClientID() returned nil error on unknown client ID")
+	}
+}
+
+func TestGetExchangeOrderInfoByClientIDFilled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.PostForm.Get("command") {
+		case poloniexOrders:
+			fmt.Fprint(w, `{"BTC_ETH":[]}`)
+		case poloniexTradeHistory:
+			fmt.Fprintf(w, `{"BTC_ETH":[{"globalTradeID":1,"tradeID":"2","date":"%s","rate":"0.01","amount":"1","total":"0.01","fee":"0.0015","orderNumber":"120466","type":"buy","category":"exchange","clientOrderId":"1337"}]}`,
+				time.Now().UTC().Format(poloniexDateLayout))
+		}
+	}))
+	defer server.Close()
+
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.APIUrl = server.URL
+	polo.AuthenticatedAPISupport = true
+	polo.SetAPIKeys("key", "secret", "", false)
+
+	order, err := polo.GetExchangeOrderInfoByClientID("1337")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex GetExchangeOrderInfoByClientID() error", err)
+	}
+
+	if order.ID != 120466 || order.Status != "filled" {
+		t.Error("Test Failed - Poloniex GetExchangeOrderInfoByClientID() unexpected order", order)
+	}
+}
+
+func TestSubmitOrderDuplicateClientID(t *testing.T) {
+	var orders int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.PostForm.Get("command") {
+		case poloniexOrderBuy:
+			orders++
+			fmt.Fprint(w, `{"orderNumber":"120466","clientOrderId":"1337","resultingTrades":[]}`)
+		case poloniexOrders:
+			fmt.Fprint(w, `{"BTC_ETH":[{"orderNumber":"120466","type":"buy","rate":"0.01","startingAmount":"1","amount":"1","total":"0.01","date":"2018-01-02 03:04:05","margin":0,"clientOrderId":"1337"}]}`)
+		}
+	}))
+	defer server.Close()
+
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.APIUrl = server.URL
+	polo.AuthenticatedAPISupport = true
+	polo.SetAPIKeys("key", "secret", "", false)
+
+	req := exchange.OrderSubmission{
+		Pair:     pair.NewCurrencyPair("BTC", "ETH"),
+		Side:     exchange.OrderSideBuy(),
+		Type:     exchange.OrderTypeLimit(),
+		Amount:   1,
+		Price:    0.01,
+		ClientID: "1337",
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := polo.SubmitOrder(req)
+		if err != nil {
+			t.Fatal("Test Failed - Poloniex SubmitOrder() error", err)
+		}
+
+		if resp.OrderID != 120466 {
+			t.Error("Test Failed - Poloniex SubmitOrder() unexpected order ID", resp.OrderID)
+		}
+	}
+
+	if orders != 1 {
+		t.Error("Test Failed - Poloniex SubmitOrder() placed a duplicate order")
+	}
+}
+
+func TestValidateEnabledPairs(t *testing.T) {
+	s := fixture.NewServer()
+	defer s.Close()
+
+	err := s.AddFixture("command=returnTicker", "testdata/returnTicker.json")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex fixture error", err)
+	}
+
+	var f Poloniex
+	f.SetDefaults()
+	f.APIUrl = s.URL
+	f.EnabledPairs = []string{"BTC_LTC", "BTC_XMR"}
+
+	if err = f.ValidateEnabledPairs(); err != nil {
+		t.Error("Test Failed - Poloniex ValidateEnabledPairs() error", err)
+	}
+
+	f.EnabledPairs = append(f.EnabledPairs, "BTC_DOGE")
+	err = f.ValidateEnabledPairs()
+	unavailable, ok := err.(*exchange.UnavailablePairsError)
+	if !ok || len(unavailable.Pairs) != 1 || unavailable.Pairs[0] != "BTC_DOGE" {
+		t.Error("Test Failed - Poloniex ValidateEnabledPairs() expected unavailable pairs error, received", err)
+	}
+}
+
+func TestUpdateTickerMargin(t *testing.T) {
+	s := fixture.NewServer()
+	defer s.Close()
+
+	err := s.AddFixture("command=returnTicker", "testdata/returnTicker.json")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex fixture error", err)
+	}
+
+	err = s.AddFixture("tradingApi", "testdata/returnTradableBalances.json")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex fixture error", err)
+	}
+
+	var f Poloniex
+	f.SetDefaults()
+	f.APIUrl = s.URL
+	f.AuthenticatedAPISupport = true
+	f.SetAPIKeys("key", "secret", "", false)
+	f.EnabledPairs = []string{"BTC_LTC", "BTC_XMR"}
+
+	marginPairs, err := f.GetMarginPairs()
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex GetMarginPairs() error", err)
+	}
+	if len(marginPairs) != 2 || marginPairs[0] != "BTC_XMR" || marginPairs[1] != "USDT_BTC" {
+		t.Error("Test Failed - Poloniex GetMarginPairs() unexpected pairs", marginPairs)
+	}
+
+	xmr := pair.NewCurrencyPairDelimiter("BTC_XMR", "_")
+	_, err = f.UpdateTicker(xmr, ticker.Margin)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex UpdateTicker() margin error", err)
+	}
+
+	margin, err := f.GetTickerPrice(xmr, ticker.Margin)
+	if err != nil || margin.Last != 0.019 {
+		t.Error("Test Failed - Poloniex GetTickerPrice() unexpected margin ticker", margin, err)
+	}
+
+	ltc := pair.NewCurrencyPairDelimiter("BTC_LTC", "_")
+	if _, err = ticker.GetTicker(f.Name, ltc, ticker.Margin); err == nil {
+		t.Error("Test Failed - Poloniex UpdateTicker() processed a margin ticker for a spot only pair")
+	}
+
+	ob, err := f.UpdateOrderbook(xmr, orderbook.Margin)
+	if err == nil || len(ob.Bids) != 0 {
+		t.Error("Test Failed - Poloniex UpdateOrderbook() expected missing orderbook fixture error")
+	}
+
+	if _, err = f.UpdateTicker(xmr, "FUTURES"); err != exchange.ErrAssetTypeNotSupported {
+		t.Error("Test Failed - Poloniex UpdateTicker() expected unsupported asset type error, received", err)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	var f Poloniex
+	f.SetDefaults()
+
+	btceth := pair.NewCurrencyPairDelimiter("BTC_ETH", "_")
+	btcltc := pair.NewCurrencyPairDelimiter("BTC_LTC", "_")
+
+	err := f.Subscribe(btceth, exchange.WebsocketChannel("candles"), ticker.Spot)
+	if err != exchange.ErrWebsocketChannelNotSupported {
+		t.Error("Test Failed - Poloniex Subscribe() unsupported channel error", err)
+	}
+
+	err = f.Subscribe(btceth, exchange.WebsocketTickerChannel, ticker.Margin)
+	if err != exchange.ErrAssetTypeNotSupported {
+		t.Error("Test Failed - Poloniex Subscribe() unsupported asset type error", err)
+	}
+
+	streams := []struct {
+		p       pair.CurrencyPair
+		channel exchange.WebsocketChannel
+	}{
+		{btceth, exchange.WebsocketTickerChannel},
+		{btcltc, exchange.WebsocketTickerChannel},
+		{btceth, exchange.WebsocketOrderbookChannel},
+		{btceth, exchange.WebsocketTradesChannel},
+	}
+	for _, s := range streams {
+		err = f.Subscribe(s.p, s.channel, ticker.Spot)
+		if err != nil {
+			t.Fatal("Test Failed - Poloniex Subscribe() error", err)
+		}
+	}
+
+	subs := f.Websocket.GetSubscriptions()
+	if len(subs) != 2 || subs[0] != "1002" || subs[1] != "BTC_ETH" {
+		t.Errorf("Test Failed - Poloniex Subscribe() unexpected subscriptions %v", subs)
+	}
+
+	for _, s := range streams[1:] {
+		err = f.Unsubscribe(s.p, s.channel, ticker.Spot)
+		if err != nil {
+			t.Fatal("Test Failed - Poloniex Unsubscribe() error", err)
+		}
+	}
+
+	subs = f.Websocket.GetSubscriptions()
+	if len(subs) != 1 || subs[0] != "1002" {
+		t.Errorf("Test Failed - Poloniex Unsubscribe() unexpected subscriptions %v", subs)
+	}
+
+	err = f.Unsubscribe(btcltc, exchange.WebsocketTickerChannel, ticker.Spot)
+	if err == nil {
+		t.Error("Test Failed - Poloniex Unsubscribe() should error when not subscribed")
+	}
+}
+
+func TestWsProcessTrade(t *testing.T) {
+	var f Poloniex
+	f.SetDefaults()
+
+	var data []interface{}
+	err := common.JSONDecode([]byte(`["t","42706057",1,"0.05567134","0.00181421",1522877119]`), &data)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex JSONDecode error", err)
+	}
+
+	trade, err := f.WsProcessTrade(data, "BTC_ETH")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex WsProcessTrade() error", err)
+	}
+
+	if trade.CurrencyPair.Pair().String() != "BTC_ETH" || trade.Price != 0.05567134 ||
+		trade.Amount != 0.00181421 || trade.Side != "BUY" ||
+		trade.Timestamp.Unix() != 1522877119 || trade.Exchange != "Poloniex" {
+		t.Errorf("Test Failed - Poloniex WsProcessTrade() unexpected trade %v", trade)
+	}
+
+	data[2] = float64(0)
+	trade, err = f.WsProcessTrade(data, "BTC_ETH")
+	if err != nil || trade.Side != "SELL" {
+		t.Error("Test Failed - Poloniex WsProcessTrade() unexpected side", trade.Side, err)
+	}
+
+	data[3] = "rawr"
+	if _, err = f.WsProcessTrade(data, "BTC_ETH"); err == nil {
+		t.Error("Test Failed - Poloniex WsProcessTrade() should error on invalid price")
+	}
+
+	if _, err = f.WsProcessTrade(data[:3], "BTC_ETH"); err == nil {
+		t.Error("Test Failed - Poloniex WsProcessTrade() should error on short trade")
+	}
+}
+
+func TestWsDispatch(t *testing.T) {
+	var f Poloniex
+	f.SetDefaults()
+	f.Websocket.DataHandler = make(chan interface{}, 10)
+
+	if err := f.wsDispatcher.Dispatch([]byte(`[1010]`)); err != nil {
+		t.Error("Test Failed - Poloniex Dispatch() heartbeat error", err)
+	}
+
+	trade := []byte(`[148,123,[["t","42706057",1,"0.05567134","0.00181421",1522877119]]]`)
+	if err := f.wsDispatcher.Dispatch(trade); err == nil {
+		t.Error("Test Failed - Poloniex Dispatch() should error on an unsubscribed pair")
+	}
+
+	if err := f.wsSubscribeHandlers("BTC_ETH"); err != nil {
+		t.Fatal("Test Failed - Poloniex wsSubscribeHandlers() error", err)
+	}
+
+	if err := f.wsDispatcher.Dispatch([]byte(`["BTC_ETH",0]`)); err == nil {
+		t.Error("Test Failed - Poloniex Dispatch() should error on a failed subscription")
+	}
+
+	if err := f.wsDispatcher.Dispatch(trade); err != nil {
+		t.Fatal("Test Failed - Poloniex Dispatch() trade error", err)
+	}
+
+	select {
+	case data := <-f.Websocket.DataHandler:
+		td, ok := data.(exchange.TradeData)
+		if !ok || td.CurrencyPair.Pair().String() != "BTC_ETH" || td.Price != 0.05567134 {
+			t.Errorf("Test Failed - Poloniex Dispatch() unexpected data %v", data)
+		}
+	default:
+		t.Error("Test Failed - Poloniex Dispatch() trade not sent to the data handler")
+	}
+
+	f.wsUnsubscribeHandlers("BTC_ETH")
+	if err := f.wsDispatcher.Dispatch(trade); err == nil {
+		t.Error("Test Failed - Poloniex Dispatch() should error once unsubscribed")
+	}
+
+	if err := f.wsDispatcher.Dispatch([]byte(`{"error":"rawr"}`)); err == nil {
+		t.Error("Test Failed - Poloniex Dispatch() should error on an invalid message")
+	}
+}
+
+func TestGetAccountValue(t *testing.T) {
+	s := fixture.NewServer()
+	defer s.Close()
+
+	err := s.AddFixture("command=returnTicker", "testdata/returnTicker.json")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex fixture error", err)
+	}
+
+	err = s.AddFixture("tradingApi", "testdata/returnCompleteBalances.json")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex fixture error", err)
+	}
+
+	var f Poloniex
+	f.SetDefaults()
+	f.APIUrl = s.URL
+	f.AuthenticatedAPISupport = true
+	f.SetAPIKeys("key", "secret", "", false)
+	// Markets are valued whether or not they are enabled
+	f.EnabledPairs = []string{"BTC_XMR"}
+	f.AvailablePairs = []string{"BTC_LTC", "BTC_XMR"}
+
+	// Prices come from the shared ticker cache, refresh it from the fixture
+	for _, p := range f.AvailablePairs {
+		_, err = f.UpdateTicker(pair.NewCurrencyPairDelimiter(p, "_"), ticker.Spot)
+		if err != nil {
+			t.Fatal("Test Failed - Poloniex UpdateTicker() error", err)
+		}
+	}
+
+	// DOGE has no market and is skipped
+	value, err := f.GetAccountValue("btc")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex GetAccountValue() error", err)
+	}
+	if math.Abs(value-1.365) > 1e-9 {
+		t.Error("Test Failed - Poloniex GetAccountValue() unexpected BTC value", value)
+	}
+
+	// BTC is converted using the inverse BTC_LTC market
+	value, err = f.GetAccountValue("LTC")
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex GetAccountValue() error", err)
+	}
+	if math.Abs(value-(10+1/0.0251)) > 1e-9 {
+		t.Error("Test Failed - Poloniex GetAccountValue() unexpected LTC value", value)
+	}
+}
+
+func TestWsSendCommandConcurrent(t *testing.T) {
+	received := make(chan struct{}, 50)
+	var upgrader websocket.Upgrader
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+			received <- struct{}{}
+		}
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal("Test Failed - Poloniex websocket dial error", err)
+	}
+	defer conn.Close()
+
+	var polo Poloniex
+	polo.SetDefaults()
+	polo.WebsocketConn = conn
+
+	var wg sync.WaitGroup
+	for i := 0; i < cap(received); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := polo.wsSendCommand("subscribe", wsTickerDataID); err != nil {
+				t.Error("Test Failed - Poloniex wsSendCommand() error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < cap(received); i++ {
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Test Failed - Poloniex wsSendCommand() messages not received")
+		}
+	}
+}
@@ -143,13 +143,15 @@ type DepositsWithdrawals struct {
 
 // Order hold order information
 type Order struct {
-	OrderNumber int64   `json:"orderNumber,string"`
-	Type        string  `json:"type"`
-	Rate        float64 `json:"rate,string"`
-	Amount      float64 `json:"amount,string"`
-	Total       float64 `json:"total,string"`
-	Date        string  `json:"date"`
-	Margin      float64 `json:"margin"`
+	OrderNumber    int64   `json:"orderNumber,string"`
+	Type           string  `json:"type"`
+	Rate           float64 `json:"rate,string"`
+	StartingAmount float64 `json:"startingAmount,string"`
+	Amount         float64 `json:"amount,string"`
+	Total          float64 `json:"total,string"`
+	Date           string  `json:"date"`
+	Margin         float64 `json:"margin"`
+	ClientOrderID  string  `json:"clientOrderId"`
 }
 
 // OpenOrdersResponseAll holds all open order responses
@@ -174,6 +176,7 @@ type AuthentictedTradeHistory struct {
 	OrderNumber   int64   `json:"orderNumber,string"`
 	Type          string  `json:"type"`
 	Category      string  `json:"category"`
+	ClientOrderID string  `json:"clientOrderId"`
 }
 
 // AuthenticatedTradeHistoryAll holds the full client trade history
@@ -198,8 +201,21 @@ type ResultingTrades struct {
 
 // OrderResponse is a response type of trades
 type OrderResponse struct {
-	OrderNumber int64             `json:"orderNumber,string"`
-	Trades      []ResultingTrades `json:"resultingTrades"`
+	OrderNumber   int64             `json:"orderNumber,string"`
+	ClientOrderID string            `json:"clientOrderId"`
+	Trades        []ResultingTrades `json:"resultingTrades"`
+}
+
+// MarginOrderResponse is a response type for margin orders, resulting trades
+// are keyed by currency pair. Poloniex does not return the loans taken to fund
+// the order, Loans is filled in by SubmitMarginOrder from the active loans
+type MarginOrderResponse struct {
+	Success     int                          `json:"success"`
+	Message     string                       `json:"message"`
+	Error       string                       `json:"error"`
+	OrderNumber int64                        `json:"orderNumber,string"`
+	Trades      map[string][]ResultingTrades `json:"resultingTrades"`
+	Loans       []ActiveLoan                 `json:"-"`
 }
 
 // GenericResponse is a response type for exchange generic responses
@@ -239,6 +255,19 @@ type Margin struct {
 	CurrentMargin float64 `json:"currentMargin,string"`
 }
 
+// MarginAccountInfo holds the margin account balances, kept separate from the
+// exchange account info
+type MarginAccountInfo struct {
+	TotalValue    float64
+	Equity        float64
+	BorrowedValue float64
+	CurrentMargin float64
+	// Available holds the available margin account balance per currency
+	Available map[string]float64
+	// Borrowable holds the tradable amount per currency for each margin market
+	Borrowable map[string]map[string]float64
+}
+
 // MarginPosition holds margin positional information
 type MarginPosition struct {
 	Amount            float64 `json:"amount,string"`
@@ -256,14 +285,52 @@ type LoanOffer struct {
 	Rate      float64 `json:"rate,string"`
 	Amount    float64 `json:"amount,string"`
 	Duration  int     `json:"duration"`
-	AutoRenew bool    `json:"autoRenew,int"`
+	AutoRenew int     `json:"autoRenew"`
+	Date      string  `json:"date"`
+}
+
+// LoanOfferRequest holds the parameters for creating a loan offer, the rate is
+// the daily lending rate and the duration is in days
+type LoanOfferRequest struct {
+	Currency  string
+	Amount    float64
+	Rate      float64
+	Duration  int
+	AutoRenew bool
+}
+
+// RepriceLoanOffersResponse holds the new loan offer ID and the loan offers
+// cancelled, or which failed to cancel, when repricing loan offers
+type RepriceLoanOffersResponse struct {
+	OfferID   int64
+	Cancelled []int64
+	Failed    map[int64]error
+}
+
+// CreateLoanOfferResponse is a response type for creating loan offers
+type CreateLoanOfferResponse struct {
+	Success int    `json:"success"`
+	Message string `json:"message"`
+	Error   string `json:"error"`
+	OrderID int64  `json:"orderID"`
+}
+
+// ActiveLoan holds active loan information
+type ActiveLoan struct {
+	ID        int64   `json:"id"`
+	Currency  string  `json:"currency"`
+	Rate      float64 `json:"rate,string"`
+	Amount    float64 `json:"amount,string"`
+	Duration  int     `json:"range"`
+	AutoRenew int     `json:"autoRenew"`
 	Date      string  `json:"date"`
+	Fees      float64 `json:"fees,string"`
 }
 
 // ActiveLoans shows the full active loans on the exchange
 type ActiveLoans struct {
-	Provided []LoanOffer `json:"provided"`
-	Used     []LoanOffer `json:"used"`
+	Provided []ActiveLoan `json:"provided"`
+	Used     []ActiveLoan `json:"used"`
 }
 
 // LendingHistory holds the full lending history data
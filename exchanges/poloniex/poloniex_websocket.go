@@ -3,7 +3,6 @@ package poloniex
 import (
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -14,6 +13,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 const (
@@ -22,6 +22,10 @@ const (
 	wsTickerDataID           = 1002
 	ws24HourExchangeVolumeID = 1003
 	wsHeartbeat              = 1010
+
+	// Poloniex sends a heartbeat every second without other traffic, so a
+	// connection silent for twice the ping interval is treated as dead
+	poloniexWebsocketPingInterval = 5 * time.Second
 )
 
 // WsConnect initiates a websocket connection
@@ -40,15 +44,22 @@ func (p *Poloniex) WsConnect() error {
 		dialer.Proxy = http.ProxyURL(proxy)
 	}
 
-	var err error
-	p.WebsocketConn, _, err = dialer.Dial(p.Websocket.GetWebsocketURL(),
+	conn, _, err := dialer.Dial(p.Websocket.GetWebsocketURL(),
 		http.Header{})
 	if err != nil {
 		return err
 	}
 
-	go p.WsReadData()
-	go p.WsHandleData()
+	p.wsWriteMtx.Lock()
+	p.WebsocketConn = conn
+	p.wsWriteMtx.Unlock()
+
+	p.WebsocketConn.SetPongHandler(func(string) error {
+		p.Websocket.TrafficAlert <- struct{}{}
+		return nil
+	})
+
+	go p.WsReadData(conn)
 
 	return p.WsSubscribe()
 }
@@ -62,7 +73,7 @@ func (p *Poloniex) WsSubscribe() error {
 		return err
 	}
 
-	err = p.WebsocketConn.WriteMessage(websocket.TextMessage, tickerJSON)
+	err = p.wsWrite(tickerJSON)
 	if err != nil {
 		return err
 	}
@@ -71,12 +82,21 @@ func (p *Poloniex) WsSubscribe() error {
 	for _, nextPair := range pairs {
 		fPair := exchange.FormatExchangeCurrency(p.GetName(), nextPair)
 
+		err = p.wsSubscribeHandlers(fPair.String())
+		if err != nil {
+			return err
+		}
+
 		orderbookJSON, err := common.JSONEncode(WsCommand{
 			Command: "subscribe",
 			Channel: fPair.String(),
 		})
 
-		err = p.WebsocketConn.WriteMessage(websocket.TextMessage, orderbookJSON)
+		if err != nil {
+			return err
+		}
+
+		err = p.wsWrite(orderbookJSON)
 		if err != nil {
 			return err
 		}
@@ -84,187 +104,413 @@ func (p *Poloniex) WsSubscribe() error {
 	return nil
 }
 
-// WsReadData reads data from the websocket connection
-func (p *Poloniex) WsReadData() {
-	p.Websocket.Wg.Add(1)
+// Subscribe subscribes to the websocket ticker, orderbook or trades channel
+// for the currency pair. The subscription is sent once connected and replayed
+// on reconnect
+func (p *Poloniex) Subscribe(currency pair.CurrencyPair, channel exchange.WebsocketChannel, assetType string) error {
+	name, wsChannel, err := p.wsChannel(currency, channel, assetType)
+	if err != nil {
+		return err
+	}
+
+	p.wsStreamsMtx.Lock()
+	defer p.wsStreamsMtx.Unlock()
+
+	if len(p.wsStreams[name]) == 0 {
+		err = p.Websocket.AddSubscription(name, func() error {
+			if symbol, ok := wsChannel.(string); ok {
+				err := p.wsSubscribeHandlers(symbol)
+				if err != nil {
+					return err
+				}
+			}
+			return p.wsSendCommand("subscribe", wsChannel)
+		})
+		if err != nil {
+			return err
+		}
+
+		if p.wsStreams == nil {
+			p.wsStreams = make(map[string]map[string]bool)
+		}
+		p.wsStreams[name] = make(map[string]bool)
+	}
+
+	p.wsStreams[name][wsStream(currency, channel)] = true
+	return nil
+}
+
+// Unsubscribe unsubscribes from the websocket ticker, orderbook or trades
+// channel for the currency pair
+func (p *Poloniex) Unsubscribe(currency pair.CurrencyPair, channel exchange.WebsocketChannel, assetType string) error {
+	name, wsChannel, err := p.wsChannel(currency, channel, assetType)
+	if err != nil {
+		return err
+	}
+
+	p.wsStreamsMtx.Lock()
+	defer p.wsStreamsMtx.Unlock()
+
+	stream := wsStream(currency, channel)
+	if !p.wsStreams[name][stream] {
+		return fmt.Errorf("poloniex_websocket.go error - not subscribed to %s",
+			stream)
+	}
+
+	delete(p.wsStreams[name], stream)
+	if len(p.wsStreams[name]) != 0 {
+		return nil
+	}
+
+	p.Websocket.RemoveSubscription(name)
+	if symbol, ok := wsChannel.(string); ok {
+		p.wsUnsubscribeHandlers(symbol)
+	}
+
+	if !p.Websocket.IsConnected() {
+		return nil
+	}
+	return p.wsSendCommand("unsubscribe", wsChannel)
+}
+
+// wsChannel returns the subscription name and Poloniex websocket channel for
+// the currency pair stream
+func (p *Poloniex) wsChannel(currency pair.CurrencyPair, channel exchange.WebsocketChannel, assetType string) (string, interface{}, error) {
+	if assetType != ticker.Spot {
+		return "", nil, exchange.ErrAssetTypeNotSupported
+	}
+
+	switch channel {
+	case exchange.WebsocketTickerChannel:
+		return strconv.Itoa(wsTickerDataID), wsTickerDataID, nil
+	case exchange.WebsocketOrderbookChannel, exchange.WebsocketTradesChannel:
+		fPair := exchange.FormatExchangeCurrency(p.GetName(), currency).String()
+		return fPair, fPair, nil
+	default:
+		return "", nil, exchange.ErrWebsocketChannelNotSupported
+	}
+}
+
+// wsStream returns the stream name for a currency pair channel
+func wsStream(currency pair.CurrencyPair, channel exchange.WebsocketChannel) string {
+	return channel.String() + " " + currency.Pair().Upper().String()
+}
+
+// wsSendCommand sends a subscribe or unsubscribe command for the channel
+func (p *Poloniex) wsSendCommand(command string, channel interface{}) error {
+	commandJSON, err := common.JSONEncode(WsCommand{
+		Command: command,
+		Channel: channel,
+	})
+	if err != nil {
+		return err
+	}
+	return p.wsWrite(commandJSON)
+}
+
+// wsWrite sends a text message, serialised with all other websocket writes
+// as subscriptions may be sent from the caller's goroutine while a reconnect
+// resubscribes
+func (p *Poloniex) wsWrite(data []byte) error {
+	p.wsWriteMtx.Lock()
+	defer p.wsWriteMtx.Unlock()
+	return p.WebsocketConn.WriteMessage(websocket.TextMessage, data)
+}
+
+// wsPing sends a ping to check the connection is still alive
+func (p *Poloniex) wsPing() error {
+	return p.WebsocketConn.WriteControl(websocket.PingMessage, nil,
+		time.Now().Add(exchange.WebsocketTrafficLimitTime))
+}
 
+// wsInterrupt unblocks WsReadData so it returns on shutdown
+func (p *Poloniex) wsInterrupt() error {
+	if p.WebsocketConn == nil {
+		return nil
+	}
+	return p.WebsocketConn.SetReadDeadline(time.Now())
+}
+
+// WsReadData reads data from the websocket connection and dispatches it to
+// the handler subscribed to its channel
+func (p *Poloniex) WsReadData(conn *websocket.Conn) {
 	defer func() {
-		err := p.WebsocketConn.Close()
+		err := conn.Close()
 		if err != nil {
 			p.Websocket.DataHandler <- fmt.Errorf("poloniex_websocket.go - Unable to to close Websocket connection. Error: %s",
 				err)
 		}
-		p.Websocket.Wg.Done()
 	}()
 
-	for {
-		select {
-		case <-p.Websocket.ShutdownC:
-			return
+	p.wsDispatcher.Run(p.Websocket, conn)
+}
 
-		default:
-			_, resp, err := p.WebsocketConn.ReadMessage()
-			if err != nil {
-				p.Websocket.DataHandler <- err
-				return
-			}
+// wsMessageChannel returns the channel of a websocket message, which is the
+// first element of the message array and is either a numeric channel ID or a
+// currency pair symbol
+func wsMessageChannel(raw []byte) (string, error) {
+	msg, err := decodeWsMessage(raw)
+	if err != nil {
+		return "", err
+	}
+
+	if len(msg) == 0 {
+		return "", errors.New("poloniex_websocket.go error - empty message")
+	}
 
-			p.Websocket.TrafficAlert <- struct{}{}
-			p.Websocket.Intercomm <- exchange.WebsocketResponse{Raw: resp}
+	switch channel := msg[0].(type) {
+	case float64:
+		return strconv.FormatInt(int64(channel), 10), nil
+	case string:
+		return channel, nil
+	default:
+		return "", fmt.Errorf("poloniex_websocket.go error - invalid channel in message %s",
+			raw)
+	}
+}
+
+// wsNewDispatcher returns a dispatcher with the heartbeat and account wide
+// channels registered, currency pair channels are registered as they are
+// subscribed
+func (p *Poloniex) wsNewDispatcher() *exchange.WebsocketDispatcher {
+	d := exchange.NewWebsocketDispatcher(wsMessageChannel)
+	d.Subscribe(strconv.Itoa(wsHeartbeat), func([]byte) error { return nil })
+	d.Subscribe(strconv.Itoa(wsAccountNotificationID), p.wsHandleAcknowledgement)
+	d.Subscribe(strconv.Itoa(ws24HourExchangeVolumeID), p.wsHandleAcknowledgement)
+	d.Subscribe(strconv.Itoa(wsTickerDataID), p.wsHandleTicker)
+	return d
+}
+
+// wsSubscribeHandlers registers the handler for the currency pair channel,
+// which Poloniex acknowledges by symbol and then streams under the numeric
+// currency pair ID
+func (p *Poloniex) wsSubscribeHandlers(symbol string) error {
+	handler := func(raw []byte) error {
+		return p.wsHandleCurrencyPair(raw, symbol)
+	}
+
+	err := p.wsDispatcher.Subscribe(symbol, handler)
+	if err != nil {
+		return err
+	}
+
+	if id, ok := wsCurrencyPairID(symbol); ok {
+		return p.wsDispatcher.Subscribe(strconv.FormatInt(id, 10), handler)
+	}
+	return nil
+}
+
+// wsUnsubscribeHandlers removes the handler for the currency pair channel
+func (p *Poloniex) wsUnsubscribeHandlers(symbol string) {
+	p.wsDispatcher.Unsubscribe(symbol)
+	if id, ok := wsCurrencyPairID(symbol); ok {
+		p.wsDispatcher.Unsubscribe(strconv.FormatInt(id, 10))
+	}
+}
+
+// wsCurrencyPairID returns the numeric channel ID of the currency pair
+func wsCurrencyPairID(symbol string) (int64, bool) {
+	for id, s := range CurrencyPairID {
+		if s == symbol {
+			return id, true
 		}
 	}
+	return 0, false
+}
+
+// decodeWsMessage decodes a websocket message array
+func decodeWsMessage(raw []byte) ([]interface{}, error) {
+	var msg []interface{}
+	err := common.JSONDecode(raw, &msg)
+	return msg, err
 }
 
-// WsHandleData handles data from the websocket connection
-func (p *Poloniex) WsHandleData() {
-	p.Websocket.Wg.Add(1)
-	defer p.Websocket.Wg.Done()
+// wsHandleAcknowledgement checks a [channelID, 1] subscription response
+func (p *Poloniex) wsHandleAcknowledgement(raw []byte) error {
+	msg, err := decodeWsMessage(raw)
+	if err != nil {
+		return err
+	}
+
+	if len(msg) != 2 {
+		return nil
+	}
+
+	if status, _ := msg[1].(float64); status != 1 {
+		return fmt.Errorf("poloniex_websocket.go error - subscription to channel %v failed",
+			msg[0])
+	}
+	return nil
+}
 
-	for {
-		select {
-		case <-p.Websocket.ShutdownC:
-			return
+// wsHandleTicker handles the ticker channel
+func (p *Poloniex) wsHandleTicker(raw []byte) error {
+	msg, err := decodeWsMessage(raw)
+	if err != nil {
+		return err
+	}
+
+	if len(msg) != 3 {
+		return p.wsHandleAcknowledgement(raw)
+	}
+
+	tickerData, ok := msg[2].([]interface{})
+	if !ok || len(tickerData) != 10 {
+		return fmt.Errorf("poloniex_websocket.go error - invalid ticker %s", raw)
+	}
+
+	var ticker WsTicker
+	ticker.LastPrice, _ = tickerData[0].(float64)
+	// ticker.LowestAsk, _ = strconv.ParseFloat(tickerData[1].(string), 64)
+	ticker.HighestBid, _ = strconv.ParseFloat(tickerData[2].(string), 64)
+	ticker.PercentageChange, _ = strconv.ParseFloat(tickerData[3].(string), 64)
+	ticker.BaseCurrencyVolume24H, _ = strconv.ParseFloat(tickerData[4].(string), 64)
+	ticker.QuoteCurrencyVolume24H, _ = strconv.ParseFloat(tickerData[5].(string), 64)
+	frozen, _ := strconv.ParseInt(tickerData[6].(string), 10, 64)
+	if frozen == 1 {
+		ticker.IsFrozen = true
+	}
+	ticker.HighestTradeIn24H, _ = tickerData[7].(float64)
+	ticker.LowestTradePrice24H, _ = strconv.ParseFloat(tickerData[8].(string), 64)
+
+	p.Websocket.DataHandler <- exchange.TickerData{
+		Timestamp: time.Now(),
+		Exchange:  p.GetName(),
+		AssetType: "SPOT",
+		LowPrice:  ticker.LowestAsk,
+		HighPrice: ticker.HighestBid,
+	}
+	return nil
+}
+
+// wsHandleCurrencyPair handles the orderbook and trade channel of a currency
+// pair
+func (p *Poloniex) wsHandleCurrencyPair(raw []byte, symbol string) error {
+	msg, err := decodeWsMessage(raw)
+	if err != nil {
+		return err
+	}
+
+	if len(msg) == 2 {
+		if status, _ := msg[1].(float64); status != 1 {
+			return fmt.Errorf("poloniex.go error - orderbook subscription failed with symbol %s",
+				symbol)
+		}
+		return nil
+	}
 
-		case resp := <-p.Websocket.Intercomm:
-			var check []interface{}
-			err := common.JSONDecode(resp.Raw, &check)
+	if len(msg) != 3 {
+		return fmt.Errorf("poloniex_websocket.go error - invalid message %s", raw)
+	}
+
+	updates, ok := msg[2].([]interface{})
+	if !ok {
+		return fmt.Errorf("poloniex_websocket.go error - invalid message %s", raw)
+	}
+
+	for _, element := range updates {
+		data, ok := element.([]interface{})
+		if !ok || len(data) < 2 {
+			continue
+		}
+
+		switch data[0] {
+		case "i":
+			// Snapshot
+			snapshot, ok := data[1].(map[string]interface{})
+			if !ok {
+				return errors.New("poloniex.go error - could not find orderbook snapshot in map")
+			}
+
+			orderbookData, ok := snapshot["orderBook"].([]interface{})
+			if !ok {
+				return errors.New("poloniex.go error - could not find orderbook data in map")
+			}
+
+			err = p.WsProcessOrderbookSnapshot(orderbookData, symbol)
 			if err != nil {
-				log.Fatal("poloniex_websocket.go - ", err)
+				return err
 			}
 
-			switch len(check) {
-			case 1:
-				if check[0].(float64) == wsHeartbeat {
-					continue
-				}
+			p.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
+				Exchange: p.GetName(),
+				Asset:    "SPOT",
+				Pair:     pair.NewCurrencyPairFromString(symbol),
+			}
 
-			case 2:
-				switch check[0].(type) {
-				case float64:
-					subscriptionID := check[0].(float64)
-					if subscriptionID == ws24HourExchangeVolumeID ||
-						subscriptionID == wsAccountNotificationID ||
-						subscriptionID == wsTickerDataID {
-						if check[1].(float64) != 1 {
-							p.Websocket.DataHandler <- errors.New("poloniex.go error - Subcription failed")
-							continue
-						}
-						continue
-					}
-
-				case string:
-					orderbookSubscriptionID := check[0].(string)
-					if check[1].(float64) != 1 {
-						p.Websocket.DataHandler <- fmt.Errorf("poloniex.go error - orderbook subscription failed with symbol %s",
-							orderbookSubscriptionID)
-						continue
-					}
-				}
+		case "o":
+			err = p.WsProcessOrderbookUpdate(data, symbol)
+			if err != nil {
+				return err
+			}
 
-			case 3:
-				switch len(check[2].([]interface{})) {
-				case 1:
-					// Snapshot
-					datalevel1 := check[2].([]interface{})
-					datalevel2 := datalevel1[0].([]interface{})
-
-					switch datalevel2[1].(type) {
-					case float64:
-						err := p.WsProcessOrderbookUpdate(datalevel2,
-							CurrencyPairID[int64(check[0].(float64))])
-						if err != nil {
-							log.Fatal(err)
-						}
-
-						p.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
-							Exchange: p.GetName(),
-							Asset:    "SPOT",
-							// Pair: pair.NewCurrencyPairFromString(currencyPair),
-						}
-
-					case map[string]interface{}:
-						datalevel3 := datalevel2[1].(map[string]interface{})
-						currencyPair, ok := datalevel3["currencyPair"].(string)
-						if !ok {
-							log.Fatal("poloniex.go error - could not find currency pair in map")
-						}
-
-						orderbookData, ok := datalevel3["orderBook"].([]interface{})
-						if !ok {
-							log.Fatal("poloniex.go error - could not find orderbook data in map")
-						}
-
-						err := p.WsProcessOrderbookSnapshot(orderbookData, currencyPair)
-						if err != nil {
-							log.Fatal(err)
-						}
-
-						p.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
-							Exchange: p.GetName(),
-							Asset:    "SPOT",
-							Pair:     pair.NewCurrencyPairFromString(currencyPair),
-						}
-						continue
-					}
-
-				case 10:
-					tickerData := check[2].([]interface{})
-					var ticker WsTicker
-
-					ticker.LastPrice, _ = tickerData[0].(float64)
-					// ticker.LowestAsk, _ = strconv.ParseFloat(tickerData[1].(string), 64)
-					ticker.HighestBid, _ = strconv.ParseFloat(tickerData[2].(string), 64)
-					ticker.PercentageChange, _ = strconv.ParseFloat(tickerData[3].(string), 64)
-					ticker.BaseCurrencyVolume24H, _ = strconv.ParseFloat(tickerData[4].(string), 64)
-					ticker.QuoteCurrencyVolume24H, _ = strconv.ParseFloat(tickerData[5].(string), 64)
-					frozen, _ := strconv.ParseInt(tickerData[6].(string), 10, 64)
-					if frozen == 1 {
-						ticker.IsFrozen = true
-					}
-					ticker.HighestTradeIn24H, _ = tickerData[7].(float64)
-					ticker.LowestTradePrice24H, _ = strconv.ParseFloat(tickerData[8].(string), 64)
-
-					p.Websocket.DataHandler <- exchange.TickerData{
-						Timestamp: time.Now(),
-						Exchange:  p.GetName(),
-						AssetType: "SPOT",
-						LowPrice:  ticker.LowestAsk,
-						HighPrice: ticker.HighestBid,
-					}
-
-				default:
-					for _, element := range check[2].([]interface{}) {
-						switch element.(type) {
-						case []interface{}:
-							data := element.([]interface{})
-							if data[0].(string) == "o" {
-								p.WsProcessOrderbookUpdate(data, CurrencyPairID[int64(check[0].(float64))])
-								continue
-							}
-
-							var trade WsTrade
-
-							id, _ := strconv.ParseInt(data[0].(string), 10, 64)
-							trade.Symbol = CurrencyPairID[id]
-							trade.TradeID, _ = data[0].(int64)
-							trade.Side, _ = data[0].(string)
-							trade.Volume, _ = data[0].(float64)
-							trade.Price, _ = data[0].(float64)
-							trade.Timestamp, _ = data[0].(int64)
-
-							p.Websocket.DataHandler <- exchange.TradeData{
-								Timestamp: time.Unix(trade.Timestamp, 0),
-								// CurrencyPair: pair.NewCurrencyPairFromString(trade.Symbol),
-								Side:   trade.Side,
-								Amount: trade.Volume,
-								Price:  trade.Price,
-							}
-						}
-					}
-				}
+			p.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
+				Exchange: p.GetName(),
+				Asset:    "SPOT",
+				Pair:     pair.NewCurrencyPairFromString(symbol),
+			}
+
+		case "t":
+			trade, err := p.WsProcessTrade(data, symbol)
+			if err != nil {
+				return err
 			}
+
+			p.Websocket.PublishTrade(trade)
+			p.Websocket.DataHandler <- trade
 		}
 	}
+	return nil
+}
+
+// WsProcessTrade converts a websocket trade update in the format
+// ["t", tradeID, side, rate, amount, timestamp] to a normalised trade
+func (p *Poloniex) WsProcessTrade(data []interface{}, symbol string) (exchange.TradeData, error) {
+	if len(data) != 6 || symbol == "" {
+		return exchange.TradeData{}, fmt.Errorf("poloniex_websocket.go error - invalid trade %v for symbol %s",
+			data, symbol)
+	}
+
+	var trade WsTrade
+	var err error
+	trade.Symbol = symbol
+
+	tradeID, _ := data[1].(string)
+	trade.TradeID, err = strconv.ParseInt(tradeID, 10, 64)
+	if err != nil {
+		return exchange.TradeData{}, err
+	}
+
+	trade.Side = "SELL"
+	if side, _ := data[2].(float64); side == 1 {
+		trade.Side = "BUY"
+	}
+
+	price, _ := data[3].(string)
+	trade.Price, err = strconv.ParseFloat(price, 64)
+	if err != nil {
+		return exchange.TradeData{}, err
+	}
+
+	volume, _ := data[4].(string)
+	trade.Volume, err = strconv.ParseFloat(volume, 64)
+	if err != nil {
+		return exchange.TradeData{}, err
+	}
+
+	timestamp, _ := data[5].(float64)
+	trade.Timestamp = int64(timestamp)
+
+	return exchange.TradeData{
+		Timestamp:    time.Unix(trade.Timestamp, 0),
+		CurrencyPair: pair.NewCurrencyPairDelimiter(trade.Symbol, "_"),
+		AssetType:    ticker.Spot,
+		Exchange:     p.GetName(),
+		EventType:    "trade",
+		Price:        trade.Price,
+		Amount:       trade.Volume,
+		Side:         trade.Side,
+	}, nil
 }
 
 // WsProcessOrderbookSnapshot processes a new orderbook snapshot into a local
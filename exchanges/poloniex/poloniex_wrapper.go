@@ -2,8 +2,10 @@ package poloniex
 
 import (
 	"errors"
-	"log"
+	"fmt"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -16,45 +18,145 @@ import (
 func (p *Poloniex) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
 	go func() {
-		p.Run()
+		p.RunRecovered(p.Run)
 		wg.Done()
 	}()
 }
 
 // Run implements the Poloniex wrapper
 func (p *Poloniex) Run() {
-	if p.Verbose {
-		log.Printf("%s Websocket: %s (url: %s).\n", p.GetName(), common.IsEnabled(p.Websocket.IsEnabled()), poloniexWebsocketAddress)
-		log.Printf("%s polling delay: %ds.\n", p.GetName(), p.RESTPollingDelay)
-		log.Printf("%s %d currencies enabled: %s.\n", p.GetName(), len(p.EnabledPairs), p.EnabledPairs)
+	p.LogDebugf("Websocket: %s (url: %s).", common.IsEnabled(p.Websocket.IsEnabled()), poloniexWebsocketAddress)
+	p.LogDebugf("polling delay: %s.", p.GetRESTPollingDelay())
+	p.LogDebugf("%d currencies enabled: %s.", len(p.EnabledPairs), p.EnabledPairs)
+
+	if p.IsStopped() {
+		return
 	}
 
-	exchangeCurrencies, err := p.GetExchangeCurrencies()
+	exchangeCurrencies, err := p.GetCachedExchangeCurrencies(p.GetExchangeCurrencies, false)
+	if err == nil && p.CheckEnabledPairs(exchangeCurrencies) != nil {
+		// An enabled pair missing from the cached pairs may have been listed
+		// since the cache was written, so refresh it before reporting
+		exchangeCurrencies, err = p.GetCachedExchangeCurrencies(p.GetExchangeCurrencies, true)
+	}
 	if err != nil {
-		log.Printf("%s Failed to get available symbols.\n", p.GetName())
+		p.LogErrorf("Failed to get available symbols %s.", err)
 	} else {
-		forceUpdate := false
-		if common.StringDataCompare(p.AvailablePairs, "BTC_USDT") {
-			log.Printf("%s contains invalid pair, forcing upgrade of available currencies.\n",
-				p.GetName())
-			forceUpdate = true
+		if err = p.CheckEnabledPairs(exchangeCurrencies); err != nil {
+			p.LogWarnf("%s.", err)
+		}
+
+		if p.AutoPairUpdatesEnabled() {
+			p.updateAvailableCurrencies(exchangeCurrencies)
+		} else {
+			p.LogInfof("auto pair updates disabled, skipping currency update.")
+		}
+	}
+
+	if p.IsStopped() {
+		return
+	}
+
+	err = p.UpdateWithdrawalFees()
+	if err != nil {
+		p.LogWarnf("Failed to update withdrawal fees %s.", err)
+	}
+
+	// Polling runs until the exchange is stopped, so Run returns without
+	// waiting for it
+	if p.RESTPollingEnabled {
+		go p.PollUntilStopped(p.pollTickers)
+	}
+}
+
+// ValidateEnabledPairs returns an UnavailablePairsError if any enabled pairs
+// are not offered by the exchange
+func (p *Poloniex) ValidateEnabledPairs() error {
+	exchangeCurrencies, err := p.GetCachedExchangeCurrencies(p.GetExchangeCurrencies, false)
+	if err != nil {
+		return err
+	}
+	return p.CheckEnabledPairs(exchangeCurrencies)
+}
+
+// updateAvailableCurrencies updates the available currencies from the exchange
+// currency pairs, forcing an update if pairs have been removed
+func (p *Poloniex) updateAvailableCurrencies(exchangeCurrencies []string) {
+	forceUpdate := false
+	removedPairs := p.GetRemovedPairs(exchangeCurrencies)
+	if len(removedPairs) > 0 {
+		p.LogWarnf("contains pairs no longer offered by the exchange: %s, forcing upgrade of available currencies.",
+			removedPairs)
+		forceUpdate = true
+	}
+	err := p.UpdateCurrencies(exchangeCurrencies, false, forceUpdate)
+	if err != nil {
+		p.LogErrorf("Failed to update available currencies %s.", err)
+	}
+}
+
+// pollTickers updates and processes the tickers for all enabled currency pairs
+// under each asset type. Margin tickers are only processed with authenticated
+// API support, which is required to look up the margin pairs
+func (p *Poloniex) pollTickers() {
+	if len(p.GetEnabledCurrencies()) == 0 {
+		return
+	}
+
+	tick, err := p.GetTicker()
+	if err != nil {
+		p.LogErrorf("Failed to update tickers %s.", err)
+		return
+	}
+
+	for _, assetType := range p.GetAssetTypes() {
+		if assetType == ticker.Margin && !p.GetAuthenticatedAPISupport() {
+			continue
 		}
-		err = p.UpdateCurrencies(exchangeCurrencies, false, forceUpdate)
+
+		err = p.processTickers(tick, assetType, pair.CurrencyPair{})
 		if err != nil {
-			log.Printf("%s Failed to update available currencies %s.\n", p.GetName(), err)
+			p.LogErrorf("Failed to update %s tickers %s.", assetType, err)
 		}
 	}
 }
 
-// UpdateTicker updates and returns the ticker for a currency pair
+// UpdateTicker updates and returns the ticker for a currency pair. A spot pair
+// which is not enabled is updated along with the enabled pairs
 func (p *Poloniex) UpdateTicker(currencyPair pair.CurrencyPair, assetType string) (ticker.Price, error) {
 	var tickerPrice ticker.Price
+	if !p.SupportsAssetType(assetType) {
+		return tickerPrice, exchange.ErrAssetTypeNotSupported
+	}
+
 	tick, err := p.GetTicker()
 	if err != nil {
 		return tickerPrice, err
 	}
 
-	for _, x := range p.GetEnabledCurrencies() {
+	err = p.processTickers(tick, assetType, currencyPair)
+	if err != nil {
+		return tickerPrice, err
+	}
+	return ticker.GetTicker(p.Name, currencyPair, assetType)
+}
+
+// processTickers processes the tickers for the enabled currency pairs traded as
+// the asset type, and for the requested spot pair if it is not empty
+func (p *Poloniex) processTickers(tick map[string]Ticker, assetType string, requested pair.CurrencyPair) error {
+	pairs, err := p.getAssetPairs(assetType)
+	if err != nil {
+		return err
+	}
+
+	if assetType == ticker.Spot && !requested.Empty() && !pair.Contains(pairs, requested, true) {
+		if _, ok := tick[exchange.FormatExchangeCurrency(p.GetName(), requested).String()]; ok {
+			pairs = append(pairs, requested)
+		}
+	}
+
+	updates := make([]ticker.Update, 0, len(pairs))
+	for _, x := range pairs {
 		var tp ticker.Price
 		curr := exchange.FormatExchangeCurrency(p.GetName(), x).String()
 		tp.Pair = x
@@ -64,9 +166,39 @@ func (p *Poloniex) UpdateTicker(currencyPair pair.CurrencyPair, assetType string
 		tp.Last = tick[curr].Last
 		tp.Low = tick[curr].Low24Hr
 		tp.Volume = tick[curr].BaseVolume
-		ticker.ProcessTicker(p.GetName(), x, tp, assetType)
+		updates = append(updates, ticker.Update{Pair: x, Price: tp})
+	}
+
+	if errs := ticker.ProcessTickers(p.GetName(), updates, assetType); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// getAssetPairs returns the enabled currency pairs which are traded as the
+// asset type
+func (p *Poloniex) getAssetPairs(assetType string) ([]pair.CurrencyPair, error) {
+	enabledPairs := p.GetEnabledCurrencies()
+	switch assetType {
+	case ticker.Spot:
+		return enabledPairs, nil
+	case ticker.Margin:
+		marginPairs, err := p.GetMarginPairs()
+		if err != nil {
+			return nil, err
+		}
+
+		var pairs []pair.CurrencyPair
+		for x := range enabledPairs {
+			curr := exchange.FormatExchangeCurrency(p.Name, enabledPairs[x]).String()
+			if common.StringDataCompare(marginPairs, curr) {
+				pairs = append(pairs, enabledPairs[x])
+			}
+		}
+		return pairs, nil
+	default:
+		return nil, exchange.ErrAssetTypeNotSupported
 	}
-	return ticker.GetTicker(p.Name, currencyPair, assetType)
 }
 
 // GetTickerPrice returns the ticker for a currency pair
@@ -90,12 +222,17 @@ func (p *Poloniex) GetOrderbookEx(currencyPair pair.CurrencyPair, assetType stri
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (p *Poloniex) UpdateOrderbook(currencyPair pair.CurrencyPair, assetType string) (orderbook.Base, error) {
 	var orderBook orderbook.Base
+	pairs, err := p.getAssetPairs(assetType)
+	if err != nil {
+		return orderBook, err
+	}
+
 	orderbookNew, err := p.GetOrderbook("", 1000)
 	if err != nil {
 		return orderBook, err
 	}
 
-	for _, x := range p.GetEnabledCurrencies() {
+	for _, x := range pairs {
 		currency := exchange.FormatExchangeCurrency(p.Name, x).String()
 		data, ok := orderbookNew.Data[currency]
 		if !ok {
@@ -126,7 +263,7 @@ func (p *Poloniex) UpdateOrderbook(currencyPair pair.CurrencyPair, assetType str
 func (p *Poloniex) GetExchangeAccountInfo() (exchange.AccountInfo, error) {
 	var response exchange.AccountInfo
 	response.ExchangeName = p.GetName()
-	accountBalance, err := p.GetBalances()
+	accountBalance, err := p.GetCompleteBalances()
 	if err != nil {
 		return response, err
 	}
@@ -134,12 +271,97 @@ func (p *Poloniex) GetExchangeAccountInfo() (exchange.AccountInfo, error) {
 	for x, y := range accountBalance.Currency {
 		var exchangeCurrency exchange.AccountCurrencyInfo
 		exchangeCurrency.CurrencyName = x
-		exchangeCurrency.TotalValue = y
+		exchangeCurrency.TotalValue = y.Available + y.OnOrders
+		exchangeCurrency.Hold = y.OnOrders
+		exchangeCurrency.Available = y.Available
 		response.Currencies = append(response.Currencies, exchangeCurrency)
 	}
 	return response, nil
 }
 
+// GetAccountValue returns the total value of all account balances in the
+// reference currency, converted at the last traded price from GetTickerPrice
+// of each currency's available market against the reference, whether or not
+// the market is enabled. Currencies without a market against the reference
+// are skipped
+func (p *Poloniex) GetAccountValue(referenceCurrency string) (float64, error) {
+	info, err := p.GetExchangeAccountInfo()
+	if err != nil {
+		return 0, err
+	}
+
+	reference := common.StringToUpper(referenceCurrency)
+	availablePairs := p.GetAvailableCurrencies()
+	var total float64
+	for _, balance := range info.Currencies {
+		currency := common.StringToUpper(balance.CurrencyName)
+		if balance.TotalValue == 0 {
+			continue
+		}
+
+		if currency == reference {
+			total += balance.TotalValue
+			continue
+		}
+
+		// Poloniex markets are priced in the first currency of the pair
+		market := pair.NewCurrencyPairDelimiter(reference+"_"+currency, "_")
+		inverse := false
+		if !pair.Contains(availablePairs, market, true) {
+			market = market.Swap()
+			inverse = true
+			if !pair.Contains(availablePairs, market, true) {
+				continue
+			}
+		}
+
+		price, err := p.GetTickerPrice(market, ticker.Spot)
+		if err != nil {
+			return 0, err
+		}
+
+		last := price.Last
+		if last == 0 {
+			continue
+		}
+
+		if inverse {
+			total += balance.TotalValue / last
+			continue
+		}
+		total += balance.TotalValue * last
+	}
+	return total, nil
+}
+
+// GetMarginAccountInfo retrieves the margin account equity, available margin
+// balances and borrowable amounts for the Poloniex exchange
+func (p *Poloniex) GetMarginAccountInfo() (MarginAccountInfo, error) {
+	var response MarginAccountInfo
+	summary, err := p.GetMarginAccountSummary()
+	if err != nil {
+		return response, err
+	}
+
+	response.TotalValue = summary.TotalValue
+	response.Equity = summary.NetValue
+	response.BorrowedValue = summary.BorrowedValue
+	response.CurrentMargin = summary.CurrentMargin
+
+	available, err := p.GetAvailableBalances("margin")
+	if err != nil {
+		return response, err
+	}
+	response.Available = available["margin"]
+
+	response.Borrowable, err = p.GetTradableBalances()
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
 // GetExchangeFundTransferHistory returns funding history, deposits and
 // withdrawals
 func (p *Poloniex) GetExchangeFundTransferHistory() ([]exchange.FundHistory, error) {
@@ -151,57 +373,415 @@ func (p *Poloniex) GetExchangeFundTransferHistory() ([]exchange.FundHistory, err
 func (p *Poloniex) GetExchangeHistory(currencyPair pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
-	return resp, errors.New("trade history not yet implemented")
+	return resp, exchange.ErrNotYetImplemented
+}
+
+// GetExchangeServerTime returns the Poloniex server time taken from the returnTicker
+// response date and updates the server time offset used for nonce generation
+func (p *Poloniex) GetExchangeServerTime() (time.Time, error) {
+	_, err := p.GetTicker()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	serverTime, err := p.LastServerDate()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	p.SetServerTimeOffset(serverTime)
+	return serverTime, nil
+}
+
+// Ping checks that the Poloniex public API is reachable
+func (p *Poloniex) Ping() error {
+	_, err := p.GetTicker()
+	return exchange.NewPingError(p.Name, err)
+}
+
+// AuthenticatedPing checks that Poloniex accepts the API credentials
+func (p *Poloniex) AuthenticatedPing() error {
+	_, err := p.GetBalances()
+	return exchange.NewPingError(p.Name, err)
+}
+
+// GetHistoricCandles returns candlestick data for a currency pair between the
+// start and end times at the specified interval
+func (p *Poloniex) GetHistoricCandles(currencyPair pair.CurrencyPair, start, end time.Time, interval time.Duration) (exchange.Candles, error) {
+	chartData, err := p.GetChartData(
+		exchange.FormatExchangeCurrency(p.Name, currencyPair).String(),
+		strconv.FormatInt(start.Unix(), 10),
+		strconv.FormatInt(end.Unix(), 10),
+		strconv.FormatInt(int64(interval/time.Second), 10))
+	if err != nil {
+		return nil, err
+	}
+
+	var candles exchange.Candles
+	for x := range chartData {
+		if chartData[x].Error != "" {
+			return nil, errors.New(chartData[x].Error)
+		}
+		candles = append(candles, exchange.Candle{
+			Time:   time.Unix(int64(chartData[x].Date), 0),
+			Open:   chartData[x].Open,
+			High:   chartData[x].High,
+			Low:    chartData[x].Low,
+			Close:  chartData[x].Close,
+			Volume: chartData[x].Volume,
+		})
+	}
+	return candles, nil
 }
 
 // SubmitExchangeOrder submits a new order
 func (p *Poloniex) SubmitExchangeOrder(currencyPair pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (int64, error) {
-	return 0, errors.New("not yet implemented")
+	resp, err := p.SubmitOrder(exchange.OrderSubmission{
+		Pair:     currencyPair,
+		Side:     side,
+		Type:     orderType,
+		Amount:   amount,
+		Price:    price,
+		ClientID: clientID,
+	})
+	return resp.OrderID, err
+}
+
+// SubmitOrder submits a new order and returns the order ID, status and any
+// trades matched on placement. Poloniex only supports limit orders, the
+// trading API has no market or stop orders. Orders resubmitted with the client
+// order ID of an earlier submission return the existing order instead of
+// placing a duplicate
+func (p *Poloniex) SubmitOrder(req exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var resp exchange.SubmitOrderResponse
+	if err := req.Validate(); err != nil {
+		return resp, err
+	}
+
+	if req.Type != exchange.OrderTypeLimit() {
+		return resp, exchange.ErrOrderTypeNotSupported
+	}
+
+	if p.CheckOrderBalance {
+		if err := p.checkOrderBalance(req); err != nil {
+			return resp, err
+		}
+	}
+
+	resp, err := p.clientOrders.SubmitOrderOnce(req, p.placeOrder, p.GetExchangeOrderInfoByClientID)
+	p.SendEvent(exchange.NewSubmitOrderEvent(req, resp, err))
+	return resp, err
+}
+
+// placeOrder places a validated order submission
+func (p *Poloniex) placeOrder(req exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var resp exchange.SubmitOrderResponse
+	tif := req.GetTimeInForce()
+	order, err := p.PlaceOrder(exchange.FormatExchangeCurrency(p.Name, req.Pair).String(),
+		req.Price,
+		req.Amount,
+		tif == exchange.ImmediateOrCancel,
+		tif == exchange.FillOrKill,
+		req.PostOnly,
+		req.Side == exchange.OrderSideBuy(),
+		req.ClientID)
+	if err != nil {
+		return resp, err
+	}
+
+	amount, _ := p.RoundOrderPrecision(req.Amount, req.Price)
+	return p.convertOrderResponse(order, amount, tif)
+}
+
+// checkOrderBalance checks the available balance covers the order. Poloniex
+// pairs are priced in the first currency, so buy orders spend the first
+// currency and sell orders the second
+func (p *Poloniex) checkOrderBalance(req exchange.OrderSubmission) error {
+	info, err := p.GetExchangeAccountInfo()
+	if err != nil {
+		return err
+	}
+
+	if req.Side == exchange.OrderSideBuy() {
+		return exchange.CheckAvailableBalance(info, req.Pair.FirstCurrency.String(),
+			req.Amount*req.Price)
+	}
+	return exchange.CheckAvailableBalance(info, req.Pair.SecondCurrency.String(),
+		req.Amount)
+}
+
+// convertOrderResponse maps a Poloniex order response to the exchange submit
+// order response. Unfilled immediate or cancel and fill or kill orders are
+// cancelled by Poloniex rather than left on the orderbook
+func (p *Poloniex) convertOrderResponse(order OrderResponse, amount float64, tif exchange.TimeInForce) (exchange.SubmitOrderResponse, error) {
+	resp := exchange.SubmitOrderResponse{
+		OrderID: order.OrderNumber,
+		Status:  "open",
+	}
+
+	var filled float64
+	for x := range order.Trades {
+		traded, err := time.Parse(poloniexDateLayout, order.Trades[x].Date)
+		if err != nil {
+			return resp, err
+		}
+
+		resp.Fills = append(resp.Fills, exchange.TradeHistory{
+			Timestamp: traded.Unix(),
+			TID:       order.Trades[x].TradeID,
+			Price:     order.Trades[x].Rate,
+			Amount:    order.Trades[x].Amount,
+			Exchange:  p.Name,
+			Type:      order.Trades[x].Type,
+		})
+		filled += order.Trades[x].Amount
+	}
+
+	switch {
+	case filled >= amount:
+		resp.Status = "filled"
+	case tif != exchange.GoodTillCancelled:
+		resp.Status = "cancelled"
+	}
+	return resp, nil
 }
 
 // ModifyExchangeOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (p *Poloniex) ModifyExchangeOrder(orderID int64, action exchange.ModifyOrder) (int64, error) {
-	return 0, errors.New("not yet implemented")
+	return 0, exchange.ErrNotYetImplemented
 }
 
 // CancelExchangeOrder cancels an order by its corresponding ID number
 func (p *Poloniex) CancelExchangeOrder(orderID int64) error {
-	return errors.New("not yet implemented")
+	return exchange.ErrNotYetImplemented
 }
 
 // CancelAllExchangeOrders cancels all orders associated with a currency pair
 func (p *Poloniex) CancelAllExchangeOrders() error {
-	return errors.New("not yet implemented")
+	return exchange.ErrNotYetImplemented
 }
 
 // GetExchangeOrderInfo returns information on a current open order
 func (p *Poloniex) GetExchangeOrderInfo(orderID int64) (exchange.OrderDetail, error) {
-	var orderDetail exchange.OrderDetail
-	return orderDetail, errors.New("not yet implemented")
+	return p.findOpenOrder(fmt.Sprintf("order %d", orderID), func(o *exchange.OrderDetail) bool {
+		return o.ID == orderID
+	})
+}
+
+// GetExchangeOrderInfoByClientID returns information on an order by the client
+// order ID it was submitted with, allowing orders to be reconciled when the
+// order number was not received. Open orders are searched first, then orders
+// filled within the client order lookback
+func (p *Poloniex) GetExchangeOrderInfoByClientID(clientID string) (exchange.OrderDetail, error) {
+	match := func(o *exchange.OrderDetail) bool {
+		return o.ClientID == clientID
+	}
+	order, err := p.findOpenOrder(fmt.Sprintf("client order %s", clientID), match)
+	if err == nil {
+		return order, nil
+	}
+
+	end := time.Now()
+	filled, historyErr := p.GetExchangeOrderHistory(pair.CurrencyPair{}, end.Add(-poloniexClientOrderLookback), end)
+	if historyErr != nil {
+		return exchange.OrderDetail{}, historyErr
+	}
+	for x := range filled {
+		if match(&filled[x]) {
+			return filled[x], nil
+		}
+	}
+	return exchange.OrderDetail{}, fmt.Errorf("%s client order %s not found in open or recent orders",
+		p.Name, clientID)
+}
+
+// findOpenOrder returns the first open order across all currency pairs which
+// matches
+func (p *Poloniex) findOpenOrder(description string, match func(o *exchange.OrderDetail) bool) (exchange.OrderDetail, error) {
+	orders, err := p.GetExchangeOpenOrders(pair.CurrencyPair{})
+	if err != nil {
+		return exchange.OrderDetail{}, err
+	}
+
+	for x := range orders {
+		if match(&orders[x]) {
+			return orders[x], nil
+		}
+	}
+	return exchange.OrderDetail{}, fmt.Errorf("%s %s not found in open orders",
+		p.Name, description)
+}
+
+// GetExchangeOpenOrders returns all open orders for a currency pair, or across
+// all currency pairs if the pair is empty
+func (p *Poloniex) GetExchangeOpenOrders(currencyPair pair.CurrencyPair) ([]exchange.OrderDetail, error) {
+	var currency string
+	if !currencyPair.Empty() {
+		currency = exchange.FormatExchangeCurrency(p.Name, currencyPair).String()
+	}
+
+	resp, err := p.GetOpenOrders(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	switch r := resp.(type) {
+	case OpenOrdersResponse:
+		orders, err = p.convertOpenOrders(currency, r.Data)
+		if err != nil {
+			return nil, err
+		}
+	case OpenOrdersResponseAll:
+		for symbol, data := range r.Data {
+			converted, err := p.convertOpenOrders(symbol, data)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, converted...)
+		}
+	}
+	return orders, nil
+}
+
+// GetExchangeOrderHistory returns filled orders for a currency pair, or across
+// all currency pairs if the pair is empty, between the start and end times.
+// Individual fills are aggregated into a single order detail per order. The
+// history is built from the account's trades, so orders cancelled without a
+// fill are not included as Poloniex has no closed order endpoint
+func (p *Poloniex) GetExchangeOrderHistory(currencyPair pair.CurrencyPair, start, end time.Time) ([]exchange.OrderDetail, error) {
+	var currency string
+	if !currencyPair.Empty() {
+		currency = exchange.FormatExchangeCurrency(p.Name, currencyPair).String()
+	}
+
+	resp, err := p.GetAuthenticatedTradeHistoryRange(currency, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	for symbol, data := range resp {
+		converted, err := p.convertTradeHistory(symbol, data)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, converted...)
+	}
+	return exchange.AggregateFills(orders), nil
+}
+
+// convertTradeHistory maps Poloniex account trades for a currency pair to the
+// exchange order detail type, one per fill
+func (p *Poloniex) convertTradeHistory(symbol string, data []AuthentictedTradeHistory) ([]exchange.OrderDetail, error) {
+	currencyPair := pair.NewCurrencyPairDelimiter(symbol, "_")
+	orders := make([]exchange.OrderDetail, 0, len(data))
+	for x := range data {
+		created, err := time.Parse(poloniexDateLayout, data[x].Date)
+		if err != nil {
+			return nil, err
+		}
+
+		side := exchange.OrderSideBuy()
+		if data[x].Type == poloniexOrderSell {
+			side = exchange.OrderSideSell()
+		}
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:      p.Name,
+			ID:            data[x].OrderNumber,
+			ClientID:      data[x].ClientOrderID,
+			BaseCurrency:  currencyPair.FirstCurrency.String(),
+			QuoteCurrency: currencyPair.SecondCurrency.String(),
+			OrderSide:     string(side),
+			OrderType:     string(exchange.OrderTypeLimit()),
+			CreationTime:  created.Unix(),
+			Status:        "filled",
+			Price:         data[x].Rate,
+			Amount:        data[x].Amount,
+		})
+	}
+	return orders, nil
+}
+
+// convertOpenOrders maps Poloniex open orders for a currency pair to the
+// exchange order detail type
+func (p *Poloniex) convertOpenOrders(symbol string, data []Order) ([]exchange.OrderDetail, error) {
+	currencyPair := pair.NewCurrencyPairDelimiter(symbol, "_")
+	orders := make([]exchange.OrderDetail, 0, len(data))
+	for x := range data {
+		created, err := time.Parse(poloniexDateLayout, data[x].Date)
+		if err != nil {
+			return nil, err
+		}
+
+		side := exchange.OrderSideBuy()
+		if data[x].Type == poloniexOrderSell {
+			side = exchange.OrderSideSell()
+		}
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:      p.Name,
+			ID:            data[x].OrderNumber,
+			ClientID:      data[x].ClientOrderID,
+			BaseCurrency:  currencyPair.FirstCurrency.String(),
+			QuoteCurrency: currencyPair.SecondCurrency.String(),
+			OrderSide:     string(side),
+			OrderType:     string(exchange.OrderTypeLimit()),
+			CreationTime:  created.Unix(),
+			Status:        "open",
+			Price:         data[x].Rate,
+			Amount:        data[x].StartingAmount,
+			OpenVolume:    data[x].Amount,
+		})
+	}
+	return orders, nil
 }
 
 // GetExchangeDepositAddress returns a deposit address for a specified currency
 func (p *Poloniex) GetExchangeDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
 }
 
 // WithdrawCryptoExchangeFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (p *Poloniex) WithdrawCryptoExchangeFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return p.WithdrawExchangeFunds(exchange.WithdrawalRequest{
+		Type:     exchange.CryptoWithdrawal,
+		Currency: cryptocurrency,
+		Amount:   amount,
+		Address:  address,
+	})
 }
 
 // WithdrawFiatExchangeFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (p *Poloniex) WithdrawFiatExchangeFunds(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
 }
 
 // WithdrawFiatExchangeFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (p *Poloniex) WithdrawFiatExchangeFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", exchange.ErrNotYetImplemented
+}
+
+// WithdrawExchangeFunds submits a withdrawal request. Only crypto withdrawals
+// are supported and Poloniex does not return a withdrawal ID
+func (p *Poloniex) WithdrawExchangeFunds(req exchange.WithdrawalRequest) (string, error) {
+	if req.Type != exchange.CryptoWithdrawal {
+		return "", exchange.ErrWithdrawTypeNotSupported
+	}
+
+	err := req.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = p.Withdraw(req.Currency.String(), req.Address, req.AddressTag, req.Amount)
+	p.SendEvent(exchange.NewWithdrawalEvent(req, "", err))
+	return "", err
 }
 
 // GetWebsocket returns a pointer to the exchange websocket
@@ -0,0 +1,152 @@
+package request
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Default circuit breaker durations used when none are supplied
+const (
+	DefaultCircuitBreakerWindow   = time.Minute
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+)
+
+// CircuitOpenError is returned by SendPayload without sending the request while
+// the circuit breaker is open after repeated request failures
+type CircuitOpenError struct {
+	Exchange string
+	Until    time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s circuit open after repeated request failures, requests blocked until %s",
+		e.Exchange, e.Until.Format(time.RFC3339))
+}
+
+// circuitBreaker stops requests to an exchange for the cooldown once threshold
+// consecutive requests fail within the window. Once the cooldown expires a
+// single request is let through to test recovery, which closes the circuit on
+// success or reopens it on failure
+type circuitBreaker struct {
+	name      string
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+
+	mtx          sync.Mutex
+	failures     int
+	firstFailure time.Time
+	openUntil    time.Time
+	probing      bool
+}
+
+// allow returns a CircuitOpenError if a request may not be sent
+func (c *circuitBreaker) allow() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.openUntil.IsZero() {
+		return nil
+	}
+
+	if c.probing || time.Now().Before(c.openUntil) {
+		return &CircuitOpenError{Exchange: c.name, Until: c.openUntil}
+	}
+
+	c.probing = true
+	log.Printf("%s circuit half open, testing recovery", c.name)
+	return nil
+}
+
+// record records the outcome of a sent request
+func (c *circuitBreaker) record(failed bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now()
+	if c.probing {
+		c.probing = false
+		if failed {
+			c.openUntil = now.Add(c.cooldown)
+			log.Printf("%s circuit recovery test failed, reopened for %s", c.name, c.cooldown)
+			return
+		}
+		c.openUntil = time.Time{}
+		c.failures = 0
+		log.Printf("%s circuit closed", c.name)
+		return
+	}
+
+	if !failed {
+		c.failures = 0
+		return
+	}
+
+	if c.failures == 0 || now.Sub(c.firstFailure) > c.window {
+		c.failures = 0
+		c.firstFailure = now
+	}
+	c.failures++
+
+	if c.failures >= c.threshold && c.openUntil.IsZero() {
+		c.openUntil = now.Add(c.cooldown)
+		log.Printf("%s circuit opened after %d consecutive request failures, blocking requests for %s",
+			c.name, c.failures, c.cooldown)
+	}
+}
+
+// SetCircuitBreaker stops requests for the cooldown once threshold consecutive
+// requests fail within the window, see CircuitOpenError. Only connection
+// errors, timeouts and HTTP 5xx responses count as failures. A zero threshold
+// disables the circuit breaker and zero durations use the defaults
+func (r *Requester) SetCircuitBreaker(threshold int, window, cooldown time.Duration) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if threshold <= 0 {
+		r.breaker = nil
+		return
+	}
+
+	if window <= 0 {
+		window = DefaultCircuitBreakerWindow
+	}
+
+	if cooldown <= 0 {
+		cooldown = DefaultCircuitBreakerCooldown
+	}
+
+	r.breaker = &circuitBreaker{
+		name:      r.Name,
+		threshold: threshold,
+		window:    window,
+		cooldown:  cooldown,
+	}
+}
+
+// getCircuitBreaker returns the circuit breaker or nil if it is disabled
+func (r *Requester) getCircuitBreaker() *circuitBreaker {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.breaker
+}
+
+// allowRequest returns a CircuitOpenError if the circuit breaker is open
+func (r *Requester) allowRequest() error {
+	b := r.getCircuitBreaker()
+	if b == nil {
+		return nil
+	}
+	return b.allow()
+}
+
+// recordCircuitBreaker records a sent request outcome with the circuit breaker
+func (r *Requester) recordCircuitBreaker(failed bool) {
+	b := r.getCircuitBreaker()
+	if b == nil {
+		return
+	}
+	b.record(failed)
+}
@@ -0,0 +1,100 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var failing, requests int32 = 1, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+		w.Write([]byte(`{"success":1}`))
+	}))
+	defer srv.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	r.SetCircuitBreaker(2, time.Minute, 50*time.Millisecond)
+
+	var result interface{}
+	for x := 0; x < 2; x++ {
+		err := r.SendPayload("GET", srv.URL, nil, nil, &result, false, false)
+		if StatusCode(err) != http.StatusBadGateway {
+			t.Fatalf("unexpected values %v", err)
+		}
+	}
+
+	err := r.SendPayload("GET", srv.URL, nil, nil, &result, false, false)
+	if _, ok := err.(*CircuitOpenError); !ok {
+		t.Fatalf("unexpected values %v", err)
+	}
+	if atomic.LoadInt32(&requests) != 2 {
+		t.Fatal("request sent while circuit open")
+	}
+
+	// A failed recovery test reopens the circuit
+	time.Sleep(60 * time.Millisecond)
+	err = r.SendPayload("GET", srv.URL, nil, nil, &result, false, false)
+	if StatusCode(err) != http.StatusBadGateway {
+		t.Fatalf("unexpected values %v", err)
+	}
+	err = r.SendPayload("GET", srv.URL, nil, nil, &result, false, false)
+	if _, ok := err.(*CircuitOpenError); !ok {
+		t.Fatalf("unexpected values %v", err)
+	}
+
+	// A successful recovery test closes the circuit
+	atomic.StoreInt32(&failing, 0)
+	time.Sleep(60 * time.Millisecond)
+	for x := 0; x < 3; x++ {
+		err = r.SendPayload("GET", srv.URL, nil, nil, &result, false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if atomic.LoadInt32(&requests) != 6 {
+		t.Fatalf("unexpected values %d", atomic.LoadInt32(&requests))
+	}
+}
+
+func TestCircuitBreakerWindow(t *testing.T) {
+	b := &circuitBreaker{name: "test", threshold: 2, window: 20 * time.Millisecond, cooldown: time.Minute}
+
+	b.record(true)
+	time.Sleep(30 * time.Millisecond)
+	b.record(true)
+	if b.allow() != nil {
+		t.Fatal("circuit opened by failures outside the window")
+	}
+
+	b.record(false)
+	b.record(true)
+	if b.allow() != nil {
+		t.Fatal("circuit opened by non consecutive failures")
+	}
+
+	b.record(true)
+	if b.allow() == nil {
+		t.Fatal("circuit not opened")
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	r.SetCircuitBreaker(2, 0, 0)
+	if r.getCircuitBreaker().window != DefaultCircuitBreakerWindow ||
+		r.getCircuitBreaker().cooldown != DefaultCircuitBreakerCooldown {
+		t.Fatal("unexpected values")
+	}
+
+	r.SetCircuitBreaker(0, 0, 0)
+	if r.getCircuitBreaker() != nil || r.allowRequest() != nil {
+		t.Fatal("unexpected values")
+	}
+}
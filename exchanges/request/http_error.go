@@ -0,0 +1,39 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// maxHTTPErrorBody is the maximum length of the response body included in an
+// HTTPError message
+const maxHTTPErrorBody = 256
+
+// HTTPError is returned by SendPayload when an exchange responds with a non 2xx
+// HTTP status code. Body holds the raw response body so exchange specific error
+// messages are kept
+type HTTPError struct {
+	Exchange   string
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	body := RedactBody(e.Body)
+	if len(body) > maxHTTPErrorBody {
+		body = body[:maxHTTPErrorBody] + "..."
+	}
+	return fmt.Sprintf("%s unexpected HTTP status %d %s: %s",
+		e.Exchange, e.StatusCode, http.StatusText(e.StatusCode), body)
+}
+
+// StatusCode returns the HTTP status code of an HTTPError anywhere in the error
+// chain, or zero if err was not caused by a non 2xx response
+func StatusCode(err error) int {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"slow down"}`))
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.Write([]byte(`{"success":1}`))
+		}
+	}))
+	defer srv.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+
+	var result interface{}
+	err := r.SendPayload("GET", srv.URL+"/limited", nil, nil, &result, false, false)
+	httpErr, ok := err.(*HTTPError)
+	if !ok || httpErr.StatusCode != http.StatusTooManyRequests ||
+		!strings.Contains(err.Error(), "slow down") {
+		t.Fatalf("unexpected values %v", err)
+	}
+
+	err = r.SendPayload("GET", srv.URL+"/forbidden", nil, nil, &result, false, false)
+	if StatusCode(fmt.Errorf("wrapped: %w", err)) != http.StatusForbidden {
+		t.Fatalf("unexpected values %v", err)
+	}
+
+	err = r.SendPayload("GET", srv.URL, nil, nil, &result, false, false)
+	if err != nil || StatusCode(err) != 0 {
+		t.Fatalf("unexpected values %v", err)
+	}
+}
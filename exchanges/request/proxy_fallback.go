@@ -0,0 +1,80 @@
+package request
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// proxyFallbackTransport sends requests through the proxied transport and
+// switches to the direct transport for the bypass duration once threshold
+// consecutive requests fail with a connection error. The proxy is tried again
+// once the bypass expires
+type proxyFallbackTransport struct {
+	name      string
+	proxied   *http.Transport
+	direct    *http.Transport
+	threshold int
+	bypass    time.Duration
+
+	mtx         sync.Mutex
+	failures    int
+	bypassUntil time.Time
+}
+
+// RoundTrip implements http.RoundTripper
+func (p *proxyFallbackTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if p.bypassing() {
+		return p.direct.RoundTrip(req)
+	}
+
+	resp, err := p.proxied.RoundTrip(req)
+	if err != nil {
+		if _, ok := err.(net.Error); ok {
+			p.recordFailure(err)
+		}
+		return resp, err
+	}
+
+	p.mtx.Lock()
+	p.failures = 0
+	p.mtx.Unlock()
+	return resp, nil
+}
+
+// bypassing returns whether requests should currently skip the proxy
+func (p *proxyFallbackTransport) bypassing() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.bypassUntil.IsZero() {
+		return false
+	}
+
+	if time.Now().Before(p.bypassUntil) {
+		return true
+	}
+
+	p.bypassUntil = time.Time{}
+	log.Printf("%s proxy bypass expired, retrying proxy", p.name)
+	return false
+}
+
+// recordFailure counts a proxy connection failure and starts bypassing the
+// proxy once the threshold is reached
+func (p *proxyFallbackTransport) recordFailure(err error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.failures++
+	if p.failures < p.threshold {
+		return
+	}
+
+	p.failures = 0
+	p.bypassUntil = time.Now().Add(p.bypass)
+	log.Printf("%s proxy failed %d consecutive requests, last error: %s. Connecting directly for %s",
+		p.name, p.threshold, err, p.bypass)
+}
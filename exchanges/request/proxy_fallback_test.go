@@ -0,0 +1,82 @@
+package request
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestProxyFallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"success":1}`))
+	}))
+	defer srv.Close()
+
+	// Reserve an address with nothing listening on it for the proxy
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyAddr := l.Addr().String()
+	l.Close()
+
+	u, err := url.Parse("http://" + proxyAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	r.SetProxyFallback(2, 50*time.Millisecond)
+	err = r.SetProxy(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := r.HTTPClient.Transport.(*proxyFallbackTransport); !ok {
+		t.Fatal("unexpected values")
+	}
+
+	for x := 0; x < 2; x++ {
+		if _, err = r.HTTPClient.Get(srv.URL); err == nil {
+			t.Fatal("unexpected values")
+		}
+	}
+
+	resp, err := r.HTTPClient.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("proxy not bypassed %s", err)
+	}
+	resp.Body.Close()
+
+	time.Sleep(100 * time.Millisecond)
+
+	if _, err = r.HTTPClient.Get(srv.URL); err == nil {
+		t.Fatal("proxy not retried after the bypass expired")
+	}
+}
+
+func TestProxyFallbackDisabled(t *testing.T) {
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	r.SetProxyFallback(0, 0)
+
+	u, err := url.Parse("http://localhost:8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetProxy(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := r.HTTPClient.Transport.(*http.Transport); !ok {
+		t.Fatal("unexpected values")
+	}
+
+	if failures, bypass := r.GetProxyFallback(); failures != 0 || bypass != defaultProxyBypassDuration {
+		t.Fatalf("unexpected values %d %s", failures, bypass)
+	}
+}
@@ -0,0 +1,120 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Response headers used to adapt the request rate to the exchange quota
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// maxRateQuotaDelay bounds the delay before a request so a bad or far future
+// reset header cannot stall an exchange's requests indefinitely
+const maxRateQuotaDelay = time.Minute
+
+// rateQuota tracks the request quota reported in response headers. Requests
+// are not delayed while more than half of the quota remains, below that the
+// remaining requests are spread evenly until the quota resets. Without quota
+// headers requests are only limited by the static rate limit
+type rateQuota struct {
+	mtx         sync.Mutex
+	limit       int
+	remaining   int
+	reset       time.Time
+	nextAllowed time.Time
+}
+
+// update stores the quota from the response headers, clearing it if they are
+// absent or invalid
+func (q *rateQuota) update(h http.Header, now time.Time) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	remaining, err := strconv.Atoi(h.Get(RateLimitRemainingHeader))
+	if err != nil || remaining < 0 {
+		q.reset = time.Time{}
+		return
+	}
+
+	reset, ok := parseRateLimitReset(h.Get(RateLimitResetHeader), now)
+	if !ok || !reset.After(now) {
+		q.reset = time.Time{}
+		return
+	}
+
+	limit, err := strconv.Atoi(h.Get(RateLimitLimitHeader))
+	if err != nil {
+		limit = 0
+	}
+
+	q.limit = limit
+	q.remaining = remaining
+	q.reset = reset
+}
+
+// reserve returns how long to wait before sending a request, at most
+// maxRateQuotaDelay, and reserves a request from the quota
+func (q *rateQuota) reserve(now time.Time) time.Duration {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if q.reset.IsZero() {
+		return 0
+	}
+
+	if !now.Before(q.reset) {
+		q.reset = time.Time{}
+		return 0
+	}
+
+	if q.limit > 0 && q.remaining > q.limit/2 {
+		q.remaining--
+		return 0
+	}
+
+	start := now
+	if q.nextAllowed.After(start) {
+		start = q.nextAllowed
+	}
+
+	if q.remaining <= 0 {
+		// Wait for the quota to reset, the response then updates the quota
+		if q.reset.After(start) {
+			start = q.reset
+		}
+		q.nextAllowed = start
+	} else {
+		interval := q.reset.Sub(start) / time.Duration(q.remaining+1)
+		q.remaining--
+		q.nextAllowed = start.Add(interval)
+	}
+
+	if q.nextAllowed.Sub(now) > maxRateQuotaDelay {
+		q.nextAllowed = now.Add(maxRateQuotaDelay)
+	}
+	return q.nextAllowed.Sub(now)
+}
+
+// parseRateLimitReset parses a reset header given as either seconds until the
+// reset or a Unix timestamp in seconds or milliseconds
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	reset, err := strconv.ParseFloat(value, 64)
+	if err != nil || reset < 0 {
+		return time.Time{}, false
+	}
+
+	switch {
+	case reset >= 1e12:
+		return time.Unix(0, int64(reset)*int64(time.Millisecond)), true
+	case reset >= 1e9:
+		return time.Unix(int64(reset), 0), true
+	default:
+		return now.Add(time.Duration(reset * float64(time.Second))), true
+	}
+}
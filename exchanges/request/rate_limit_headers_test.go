@@ -0,0 +1,114 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func quotaHeaders(limit, remaining int, reset string) http.Header {
+	h := make(http.Header)
+	h.Set(RateLimitLimitHeader, strconv.Itoa(limit))
+	h.Set(RateLimitRemainingHeader, strconv.Itoa(remaining))
+	h.Set(RateLimitResetHeader, reset)
+	return h
+}
+
+func TestRateQuotaReserve(t *testing.T) {
+	now := time.Now()
+	var q rateQuota
+
+	if q.reserve(now) != 0 {
+		t.Fatal("request delayed without quota headers")
+	}
+
+	q.update(quotaHeaders(100, 80, "10"), now)
+	if q.reserve(now) != 0 {
+		t.Fatal("request delayed with more than half the quota remaining")
+	}
+
+	q.update(quotaHeaders(100, 9, "10"), now)
+	if d := q.reserve(now); d != time.Second {
+		t.Fatalf("unexpected values %v", d)
+	}
+	if d := q.reserve(now); d <= time.Second {
+		t.Fatalf("requests not spread %v", d)
+	}
+
+	q.update(quotaHeaders(100, 0, strconv.FormatInt(now.Add(5*time.Second).Unix(), 10)), now)
+	if d := q.reserve(now); d <= 4*time.Second || d > 5*time.Second {
+		t.Fatalf("request not delayed until reset %v", d)
+	}
+
+	q.update(make(http.Header), now)
+	if q.reserve(now) != 0 {
+		t.Fatal("static limit not used once headers are absent")
+	}
+
+	q.update(quotaHeaders(100, 0, "1"), now)
+	if q.reserve(now.Add(2*time.Second)) != 0 {
+		t.Fatal("request delayed after the quota reset")
+	}
+
+	q.update(quotaHeaders(100, 0, strconv.FormatInt(now.Add(24*time.Hour).Unix(), 10)), now)
+	if d := q.reserve(now); d != maxRateQuotaDelay {
+		t.Fatalf("delay not clamped to the maximum %v", d)
+	}
+	if d := q.reserve(now); d != maxRateQuotaDelay {
+		t.Fatalf("repeated delay not clamped to the maximum %v", d)
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"30", now.Add(30 * time.Second)},
+		{"1.5", now.Add(1500 * time.Millisecond)},
+		{"1530000000", time.Unix(1530000000, 0)},
+		{"1530000000500", time.Unix(1530000000, int64(500*time.Millisecond))},
+	}
+
+	for x := range tests {
+		reset, ok := parseRateLimitReset(tests[x].value, now)
+		if !ok || !reset.Equal(tests[x].expected) {
+			t.Errorf("unexpected values %s %v", tests[x].value, reset)
+		}
+	}
+
+	if _, ok := parseRateLimitReset("rawr", now); ok {
+		t.Error("unexpected values")
+	}
+}
+
+func TestAdaptiveThrottling(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set(RateLimitLimitHeader, "10")
+		w.Header().Set(RateLimitRemainingHeader, "1")
+		w.Header().Set(RateLimitResetHeader, "0.2")
+		w.Write([]byte(`{"success":1}`))
+	}))
+	defer srv.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+
+	var result interface{}
+	err := r.SendPayload("GET", srv.URL, nil, nil, &result, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	err = r.SendPayload("GET", srv.URL, nil, nil, &result, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if time.Since(start) < 50*time.Millisecond {
+		t.Fatal("request not throttled")
+	}
+}
@@ -0,0 +1,82 @@
+package request
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces sensitive values in verbose request logging
+const RedactedValue = "[REDACTED]"
+
+// sensitiveFields are the normalised header, form and JSON field names whose
+// values are redacted from verbose logging
+var sensitiveFields = map[string]bool{
+	"key":           true,
+	"apikey":        true,
+	"accesskey":     true,
+	"sign":          true,
+	"signature":     true,
+	"apisign":       true,
+	"secret":        true,
+	"apisecret":     true,
+	"secretkey":     true,
+	"password":      true,
+	"passphrase":    true,
+	"token":         true,
+	"authorization": true,
+}
+
+var jsonFieldRegex = regexp.MustCompile(`"([^"\\]+)"(\s*:\s*)("(?:[^"\\]|\\.)*")`)
+
+// IsSensitiveField returns whether values of the header, form or JSON field
+// are redacted from verbose logging. Case, hyphens and underscores are
+// ignored so X-API-Key, api_key and apiKey all match
+func IsSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	name = strings.Replace(name, "-", "", -1)
+	name = strings.Replace(name, "_", "", -1)
+	name = strings.TrimPrefix(name, "x")
+	return sensitiveFields[name]
+}
+
+// RedactHeaders returns a copy of the headers with sensitive values redacted
+func RedactHeaders(headers map[string]string) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if IsSensitiveField(k) {
+			v = RedactedValue
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+// RedactBody returns a JSON or URL encoded form body with sensitive field
+// values redacted. The order of form fields is kept
+func RedactBody(body string) string {
+	trimmed := strings.TrimSpace(body)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return jsonFieldRegex.ReplaceAllStringFunc(body, func(field string) string {
+			m := jsonFieldRegex.FindStringSubmatch(field)
+			if !IsSensitiveField(m[1]) {
+				return field
+			}
+			return `"` + m[1] + `"` + m[2] + `"` + RedactedValue + `"`
+		})
+	}
+
+	fields := strings.Split(body, "&")
+	for x := range fields {
+		kv := strings.SplitN(fields[x], "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		name, err := url.QueryUnescape(kv[0])
+		if err != nil || !IsSensitiveField(name) {
+			continue
+		}
+		fields[x] = kv[0] + "=" + RedactedValue
+	}
+	return strings.Join(fields, "&")
+}
@@ -0,0 +1,58 @@
+package request
+
+import (
+	"testing"
+)
+
+func TestIsSensitiveField(t *testing.T) {
+	for _, name := range []string{"Key", "Sign", "X-API-Key", "api_key", "apiKey", "secret", "Authorization"} {
+		if !IsSensitiveField(name) {
+			t.Errorf("%s not sensitive", name)
+		}
+	}
+
+	for _, name := range []string{"Content-Type", "method", "nonce", "pair", "address"} {
+		if IsSensitiveField(name) {
+			t.Errorf("%s sensitive", name)
+		}
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := map[string]string{
+		"Key":          "apikey",
+		"Sign":         "signature",
+		"Content-Type": "application/x-www-form-urlencoded",
+	}
+
+	redacted := RedactHeaders(headers)
+	if redacted["Key"] != RedactedValue || redacted["Sign"] != RedactedValue {
+		t.Fatalf("unexpected values %v", redacted)
+	}
+
+	if redacted["Content-Type"] != headers["Content-Type"] {
+		t.Fatalf("unexpected values %v", redacted)
+	}
+
+	if headers["Key"] != "apikey" {
+		t.Fatal("headers modified")
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	form := "method=Trade&api_key=abc&nonce=1&signature=def"
+	expected := "method=Trade&api_key=[REDACTED]&nonce=1&signature=[REDACTED]"
+	if result := RedactBody(form); result != expected {
+		t.Fatalf("unexpected values %s", result)
+	}
+
+	body := `{"success":1,"return":{"apiKey":"abc","secret" : "d\"ef","address":"1abc"}}`
+	expected = `{"success":1,"return":{"apiKey":"[REDACTED]","secret" : "[REDACTED]","address":"1abc"}}`
+	if result := RedactBody(body); result != expected {
+		t.Fatalf("unexpected values %s", result)
+	}
+
+	if result := RedactBody("rawr"); result != "rawr" {
+		t.Fatalf("unexpected values %s", result)
+	}
+}
@@ -1,6 +1,7 @@
 package request
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
+	"golang.org/x/net/proxy"
 )
 
 var supportedMethods = []string{"GET", "POST", "HEAD", "PUT", "DELETE", "OPTIONS", "CONNECT"}
@@ -20,6 +22,7 @@ var supportedMethods = []string{"GET", "POST", "HEAD", "PUT", "DELETE", "OPTIONS
 const (
 	maxRequestJobs              = 50
 	proxyTLSTimeout             = 15 * time.Second
+	defaultProxyBypassDuration  = time.Minute
 	defaultTimeoutRetryAttempts = 3
 )
 
@@ -35,9 +38,48 @@ type Requester struct {
 	m                    sync.Mutex
 	Jobs                 chan Job
 	WorkerStarted        bool
+	metrics              *metrics
+	userAgents           []string
+	userAgentIndex       int
+	serverDate           time.Time
+	proxyFallback        int
+	proxyBypass          time.Duration
+	breaker              *circuitBreaker
+	quota                rateQuota
+	maxResponseSize      int64
 }
 
-// RateLimit struct
+// LatencyBuckets defines the upper bounds of the request latency histogram
+var LatencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Stats holds request instrumentation for a Requester
+type Stats struct {
+	Name         string
+	Requests     int64
+	Errors       int64
+	TotalLatency time.Duration
+	// Latency holds a request count per LatencyBuckets upper bound, with one
+	// additional trailing bucket for requests exceeding the largest bound
+	Latency []int64
+}
+
+// metrics records request instrumentation when enabled
+type metrics struct {
+	m     sync.Mutex
+	stats Stats
+}
+
+// RateLimit holds a request weight budget per duration. Requests weigh 1
+// unless sent with SendWeightedPayload
 type RateLimit struct {
 	Duration time.Duration
 	Rate     int
@@ -62,6 +104,7 @@ type Job struct {
 	JobResult   chan *JobResult
 	AuthRequest bool
 	Verbose     bool
+	Weight      int
 }
 
 // NewRateLimit creates a new RateLimit
@@ -116,25 +159,47 @@ func (r *RateLimit) GetDuration() time.Duration {
 	return r.Duration
 }
 
+// startCycle resets the requests counter, carrying over any weight beyond the
+// rate used by a request heavier than the remaining budget
+func (r *RateLimit) startCycle() {
+	r.Mutex.Lock()
+	defer r.Mutex.Unlock()
+	if r.Rate <= 0 || r.Requests <= r.Rate {
+		r.Requests = 0
+		return
+	}
+	r.Requests -= r.Rate
+}
+
 // StartCycle restarts the cycle time and requests counters
 func (r *Requester) StartCycle() {
 	r.Cycle = time.Now()
-	r.AuthLimit.SetRequests(0)
-	r.UnauthLimit.SetRequests(0)
+	r.AuthLimit.startCycle()
+	r.UnauthLimit.startCycle()
 }
 
 // IsRateLimited returns whether or not the request Requester is rate limited
 func (r *Requester) IsRateLimited(auth bool) bool {
-	if auth {
-		if r.AuthLimit.GetRequests() >= r.AuthLimit.GetRate() && r.IsValidCycle(auth) {
-			return true
-		}
-	} else {
-		if r.UnauthLimit.GetRequests() >= r.UnauthLimit.GetRate() && r.IsValidCycle(auth) {
-			return true
-		}
+	return r.IsRateLimitedWeight(auth, 1)
+}
+
+// IsRateLimitedWeight returns whether a request of the supplied weight would
+// exceed the remaining rate limit budget. A request heavier than the whole
+// budget is allowed once nothing has been used this cycle
+func (r *Requester) IsRateLimitedWeight(auth bool, weight int) bool {
+	limit := r.GetRateLimit(auth)
+	requests := limit.GetRequests()
+	if requests == 0 || requests+weight <= limit.GetRate() {
+		return false
 	}
-	return false
+
+	if r.IsValidCycle(auth) {
+		return true
+	}
+
+	// The cycle restarted, check against any weight carried over
+	requests = limit.GetRequests()
+	return requests != 0 && requests+weight > limit.GetRate()
 }
 
 // RequiresRateLimiter returns whether or not the request Requester requires a rate limiter
@@ -148,31 +213,31 @@ func (r *Requester) RequiresRateLimiter() bool {
 // IncrementRequests increments the ratelimiter request counter for either auth or unauth
 // requests
 func (r *Requester) IncrementRequests(auth bool) {
-	if auth {
-		reqs := r.AuthLimit.GetRequests()
-		reqs++
-		r.AuthLimit.SetRequests(reqs)
-		return
-	}
+	r.IncrementRequestsWeight(auth, 1)
+}
 
-	reqs := r.UnauthLimit.GetRequests()
-	reqs++
-	r.UnauthLimit.SetRequests(reqs)
+// IncrementRequestsWeight adds the request weight to the ratelimiter request
+// counter for either auth or unauth requests
+func (r *Requester) IncrementRequestsWeight(auth bool, weight int) {
+	limit := r.GetRateLimit(auth)
+	limit.Mutex.Lock()
+	limit.Requests += weight
+	limit.Mutex.Unlock()
 }
 
 // DecrementRequests decrements the ratelimiter request counter for either auth or unauth
 // requests
 func (r *Requester) DecrementRequests(auth bool) {
-	if auth {
-		reqs := r.AuthLimit.GetRequests()
-		reqs--
-		r.AuthLimit.SetRequests(reqs)
-		return
-	}
+	r.DecrementRequestsWeight(auth, 1)
+}
 
-	reqs := r.AuthLimit.GetRequests()
-	reqs--
-	r.UnauthLimit.SetRequests(reqs)
+// DecrementRequestsWeight subtracts the request weight from the ratelimiter
+// request counter for either auth or unauth requests
+func (r *Requester) DecrementRequestsWeight(auth bool, weight int) {
+	limit := r.GetRateLimit(auth)
+	limit.Mutex.Lock()
+	limit.Requests -= weight
+	limit.Mutex.Unlock()
 }
 
 // SetRateLimit sets the request Requester ratelimiter
@@ -204,6 +269,129 @@ func (r *Requester) SetTimeoutRetryAttempts(n int) error {
 	return nil
 }
 
+// EnableMetrics toggles request instrumentation. Enabling resets any
+// previously recorded stats. When disabled no metrics are recorded.
+func (r *Requester) EnableMetrics(enabled bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if !enabled {
+		r.metrics = nil
+		return
+	}
+	r.metrics = &metrics{
+		stats: Stats{
+			Name:    r.Name,
+			Latency: make([]int64, len(LatencyBuckets)+1),
+		},
+	}
+}
+
+// Stats returns a snapshot of the recorded request instrumentation
+func (r *Requester) Stats() (Stats, error) {
+	r.m.Lock()
+	m := r.metrics
+	r.m.Unlock()
+
+	if m == nil {
+		return Stats{}, fmt.Errorf("%s request metrics not enabled", r.Name)
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+	stats := m.stats
+	stats.Latency = make([]int64, len(m.stats.Latency))
+	copy(stats.Latency, m.stats.Latency)
+	return stats, nil
+}
+
+// recordRequest records a completed request, it is a no-op when metrics are
+// disabled
+func (r *Requester) recordRequest(latency time.Duration, err error) {
+	r.m.Lock()
+	m := r.metrics
+	r.m.Unlock()
+
+	if m == nil {
+		return
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+	m.stats.Requests++
+	if err != nil {
+		m.stats.Errors++
+	}
+	m.stats.TotalLatency += latency
+
+	bucket := len(LatencyBuckets)
+	for x := range LatencyBuckets {
+		if latency <= LatencyBuckets[x] {
+			bucket = x
+			break
+		}
+	}
+	m.stats.Latency[bucket]++
+}
+
+// SetUserAgents sets a list of User-Agent strings which are rotated through
+// round-robin on each request. Supplying an empty list reverts to the single
+// UserAgent value.
+func (r *Requester) SetUserAgents(userAgents []string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.userAgents = nil
+	for x := range userAgents {
+		if userAgents[x] == "" {
+			continue
+		}
+		r.userAgents = append(r.userAgents, userAgents[x])
+	}
+	r.userAgentIndex = 0
+}
+
+// nextUserAgent returns the User-Agent to use for the next request
+func (r *Requester) nextUserAgent() string {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if len(r.userAgents) == 0 {
+		return r.UserAgent
+	}
+
+	ua := r.userAgents[r.userAgentIndex%len(r.userAgents)]
+	r.userAgentIndex = (r.userAgentIndex + 1) % len(r.userAgents)
+	return ua
+}
+
+// setServerDate records the server time from a HTTP Date response header
+func (r *Requester) setServerDate(date string) {
+	if date == "" {
+		return
+	}
+
+	serverDate, err := http.ParseTime(date)
+	if err != nil {
+		return
+	}
+
+	r.m.Lock()
+	r.serverDate = serverDate
+	r.m.Unlock()
+}
+
+// LastServerDate returns the server time from the Date header of the most
+// recent response. The header has a resolution of one second
+func (r *Requester) LastServerDate() (time.Time, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if r.serverDate.IsZero() {
+		return time.Time{}, errors.New("no server date has been received")
+	}
+	return r.serverDate, nil
+}
+
 // New returns a new Requester
 func New(name string, authLimit, unauthLimit *RateLimit, httpRequester *http.Client) *Requester {
 	return &Requester{
@@ -213,6 +401,7 @@ func New(name string, authLimit, unauthLimit *RateLimit, httpRequester *http.Cli
 		Name:                 name,
 		Jobs:                 make(chan Job, maxRequestJobs),
 		timeoutRetryAttempts: defaultTimeoutRetryAttempts,
+		maxResponseSize:      DefaultMaxResponseSize,
 	}
 }
 
@@ -247,8 +436,10 @@ func (r *Requester) checkRequest(method, path string, body io.Reader, headers ma
 		req.Header.Add(k, v)
 	}
 
-	if r.UserAgent != "" && req.Header.Get("User-Agent") == "" {
-		req.Header.Add("User-Agent", r.UserAgent)
+	if req.Header.Get("User-Agent") == "" {
+		if ua := r.nextUserAgent(); ua != "" {
+			req.Header.Add("User-Agent", ua)
+		}
 	}
 
 	return req, nil
@@ -256,13 +447,36 @@ func (r *Requester) checkRequest(method, path string, body io.Reader, headers ma
 
 // DoRequest performs a HTTP/HTTPS request with the supplied params
 func (r *Requester) DoRequest(req *http.Request, method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, verbose bool) error {
+	return r.doRequest(req, method, path, headers, body, result, authRequest, verbose, 1)
+}
+
+// doRequest performs a HTTP/HTTPS request, returning the request weight to the
+// rate limit budget if the request could not be sent
+func (r *Requester) doRequest(req *http.Request, method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, verbose bool, weight int) (err error) {
+	start := time.Now()
+	var failed bool
+	defer func() {
+		r.recordRequest(time.Since(start), err)
+		r.recordCircuitBreaker(failed)
+	}()
+
 	if verbose {
 		log.Printf("%s exchange request path: %s requires rate limiter: %v", r.Name, path, r.RequiresRateLimiter())
+		if len(headers) != 0 {
+			log.Printf("%s exchange request headers: %v", r.Name, RedactHeaders(headers))
+		}
+	}
+
+	if delay := r.quota.reserve(time.Now()); delay > 0 {
+		if verbose {
+			log.Printf("%s request. Rate limit quota low, delaying request for %v", r.Name, delay)
+		}
+		time.Sleep(delay)
 	}
 
 	var timeoutError error
 	for i := 0; i < r.timeoutRetryAttempts+1; i++ {
-		resp, err := r.HTTPClient.Do(req)
+		resp, err := r.Client().Do(req)
 		if err != nil {
 			if timeoutErr, ok := err.(net.Error); ok && timeoutErr.Timeout() {
 				if verbose {
@@ -275,25 +489,45 @@ func (r *Requester) DoRequest(req *http.Request, method, path string, headers ma
 			}
 
 			if r.RequiresRateLimiter() {
-				r.DecrementRequests(authRequest)
+				r.DecrementRequestsWeight(authRequest, weight)
 			}
+			failed = true
 			return err
 		}
 		if resp == nil {
 			if r.RequiresRateLimiter() {
-				r.DecrementRequests(authRequest)
+				r.DecrementRequestsWeight(authRequest, weight)
 			}
+			failed = true
 			return errors.New("resp is nil")
 		}
 
-		contents, err := ioutil.ReadAll(resp.Body)
+		failed = resp.StatusCode >= http.StatusInternalServerError
+		maxSize := r.getMaxResponseSize()
+		contents, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+		resp.Body.Close()
 		if err != nil {
+			failed = true
 			return err
 		}
 
-		resp.Body.Close()
+		if int64(len(contents)) > maxSize {
+			return fmt.Errorf("%s response exceeds the maximum response size of %d bytes",
+				r.Name, maxSize)
+		}
+
+		r.setServerDate(resp.Header.Get("Date"))
+		r.quota.update(resp.Header, time.Now())
 		if verbose {
-			log.Printf("%s exchange raw response: %s", r.Name, string(contents[:]))
+			log.Printf("%s exchange raw response: %s", r.Name, RedactBody(string(contents[:])))
+		}
+
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+			return &HTTPError{
+				Exchange:   r.Name,
+				StatusCode: resp.StatusCode,
+				Body:       string(contents),
+			}
 		}
 
 		if result != nil {
@@ -302,17 +536,18 @@ func (r *Requester) DoRequest(req *http.Request, method, path string, headers ma
 
 		return nil
 	}
-	return fmt.Errorf("request.go error - failed to retry request %s",
+	failed = true
+	return fmt.Errorf("request.go error - failed to retry request %w",
 		timeoutError)
 }
 
 func (r *Requester) worker() {
 	for {
 		for x := range r.Jobs {
-			if !r.IsRateLimited(x.AuthRequest) {
-				r.IncrementRequests(x.AuthRequest)
+			if !r.IsRateLimitedWeight(x.AuthRequest, x.Weight) {
+				r.IncrementRequestsWeight(x.AuthRequest, x.Weight)
 
-				err := r.DoRequest(x.Request, x.Method, x.Path, x.Headers, x.Body, x.Result, x.AuthRequest, x.Verbose)
+				err := r.doRequest(x.Request, x.Method, x.Path, x.Headers, x.Body, x.Result, x.AuthRequest, x.Verbose, x.Weight)
 				x.JobResult <- &JobResult{
 					Error:  err,
 					Result: x.Result,
@@ -326,14 +561,14 @@ func (r *Requester) worker() {
 				time.Sleep(diff)
 
 				for {
-					if !r.IsRateLimited(x.AuthRequest) {
-						r.IncrementRequests(x.AuthRequest)
+					if !r.IsRateLimitedWeight(x.AuthRequest, x.Weight) {
+						r.IncrementRequestsWeight(x.AuthRequest, x.Weight)
 
 						if x.Verbose {
 							log.Printf("%s request. No longer rate limited! Doing request", r.Name)
 						}
 
-						err := r.DoRequest(x.Request, x.Method, x.Path, x.Headers, x.Body, x.Result, x.AuthRequest, x.Verbose)
+						err := r.doRequest(x.Request, x.Method, x.Path, x.Headers, x.Body, x.Result, x.AuthRequest, x.Verbose, x.Weight)
 						x.JobResult <- &JobResult{
 							Error:  err,
 							Result: x.Result,
@@ -346,8 +581,21 @@ func (r *Requester) worker() {
 	}
 }
 
-// SendPayload handles sending HTTP/HTTPS requests
+// SendPayload handles sending HTTP/HTTPS requests. Along with the static rate
+// limit, requests are slowed down as the quota reported in X-RateLimit response
+// headers depletes
 func (r *Requester) SendPayload(method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, verbose bool) error {
+	return r.SendWeightedPayload(1, method, path, headers, body, result, authRequest, verbose)
+}
+
+// SendWeightedPayload sends a HTTP/HTTPS request which consumes the supplied
+// weight from the rate limit budget, for exchanges which charge heavier
+// requests more against their limits. Weights below 1 are treated as 1
+func (r *Requester) SendWeightedPayload(weight int, method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, verbose bool) error {
+	if weight < 1 {
+		weight = 1
+	}
+
 	if r == nil || r.Name == "" {
 		return errors.New("not initiliased, SetDefaults() called before making request?")
 	}
@@ -366,13 +614,20 @@ func (r *Requester) SendPayload(method, path string, headers map[string]string,
 	}
 
 	if !r.RequiresRateLimiter() {
-		return r.DoRequest(req, method, path, headers, body, result, authRequest, verbose)
+		if err = r.allowRequest(); err != nil {
+			return err
+		}
+		return r.doRequest(req, method, path, headers, body, result, authRequest, verbose, weight)
 	}
 
 	if len(r.Jobs) == maxRequestJobs {
 		return errors.New("max request jobs reached")
 	}
 
+	if err = r.allowRequest(); err != nil {
+		return err
+	}
+
 	r.m.Lock()
 	if !r.WorkerStarted {
 		r.StartCycle()
@@ -393,6 +648,7 @@ func (r *Requester) SendPayload(method, path string, headers map[string]string,
 		JobResult:   jobResult,
 		AuthRequest: authRequest,
 		Verbose:     verbose,
+		Weight:      weight,
 	}
 
 	if verbose {
@@ -411,15 +667,123 @@ func (r *Requester) SendPayload(method, path string, headers map[string]string,
 	return resp.Error
 }
 
-// SetProxy sets a proxy address to the client transport
+// SetProxy sets a proxy address to the client transport. A socks5:// scheme
+// configures a SOCKS5 dialer, any other scheme is used as a HTTP proxy.
 func (r *Requester) SetProxy(p *url.URL) error {
 	if p.String() == "" {
 		return errors.New("No proxy URL supplied")
 	}
 
-	r.HTTPClient.Transport = &http.Transport{
+	// Retain a disabled HTTP/2 setting and any certificate pins from the
+	// existing transport
+	var tlsNextProto map[string]func(string, *tls.Conn) http.RoundTripper
+	var tlsConfig *tls.Config
+	existing := r.Client().Transport
+	if f, ok := existing.(*proxyFallbackTransport); ok {
+		existing = f.proxied
+	}
+	if t, ok := existing.(*http.Transport); ok {
+		tlsNextProto = t.TLSNextProto
+		tlsConfig = t.TLSClientConfig
+	}
+
+	if p.Scheme == "socks5" {
+		dialer, err := proxy.FromURL(p, proxy.Direct)
+		if err != nil {
+			return err
+		}
+
+		r.setProxyTransport(&http.Transport{
+			Dial:                dialer.Dial,
+			TLSClientConfig:     tlsConfig,
+			TLSHandshakeTimeout: proxyTLSTimeout,
+			TLSNextProto:        tlsNextProto,
+		})
+		return nil
+	}
+
+	r.setProxyTransport(&http.Transport{
 		Proxy:               http.ProxyURL(p),
+		TLSClientConfig:     tlsConfig,
 		TLSHandshakeTimeout: proxyTLSTimeout,
-	}
+		TLSNextProto:        tlsNextProto,
+	})
 	return nil
 }
+
+// SetProxyFallback allows requests to bypass the proxy for the bypass duration
+// once this many consecutive requests through it fail with a connection error.
+// Zero failures disables the fallback so all traffic uses the proxy. It
+// applies to proxies set after it is called
+func (r *Requester) SetProxyFallback(failures int, bypass time.Duration) {
+	if bypass <= 0 {
+		bypass = defaultProxyBypassDuration
+	}
+	r.proxyFallback = failures
+	r.proxyBypass = bypass
+}
+
+// GetProxyFallback returns the proxy fallback failure count and bypass
+// duration
+func (r *Requester) GetProxyFallback() (int, time.Duration) {
+	return r.proxyFallback, r.proxyBypass
+}
+
+// setProxyTransport sets the proxied transport as the client transport,
+// wrapping it with a direct connection fallback if enabled
+func (r *Requester) setProxyTransport(t *http.Transport) {
+	if r.proxyFallback <= 0 {
+		r.updateClient(func(c *http.Client) { c.Transport = t })
+		return
+	}
+
+	fallback := &proxyFallbackTransport{
+		name:    r.Name,
+		proxied: t,
+		direct: &http.Transport{
+			TLSClientConfig:     t.TLSClientConfig,
+			TLSHandshakeTimeout: proxyTLSTimeout,
+			TLSNextProto:        t.TLSNextProto,
+		},
+		threshold: r.proxyFallback,
+		bypass:    r.proxyBypass,
+	}
+	r.updateClient(func(c *http.Client) { c.Transport = fallback })
+}
+
+// Client returns the HTTP client used for requests
+func (r *Requester) Client() *http.Client {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.HTTPClient
+}
+
+// SetTimeout sets the HTTP client timeout. It is safe to call while requests
+// are being sent
+func (r *Requester) SetTimeout(t time.Duration) {
+	r.updateClient(func(c *http.Client) { c.Timeout = t })
+}
+
+// SetTransport sets the HTTP client transport. It is safe to call while
+// requests are being sent
+func (r *Requester) SetTransport(t http.RoundTripper) {
+	r.updateClient(func(c *http.Client) { c.Transport = t })
+}
+
+// SetUserAgent sets the User-Agent sent when no rotating user agents are set.
+// It is safe to call while requests are being sent
+func (r *Requester) SetUserAgent(ua string) {
+	r.m.Lock()
+	r.UserAgent = ua
+	r.m.Unlock()
+}
+
+// updateClient applies update to a copy of the HTTP client and swaps it in, so
+// requests in flight keep using the client they started with
+func (r *Requester) updateClient(update func(*http.Client)) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	c := *r.HTTPClient
+	update(&c)
+	r.HTTPClient = &c
+}
@@ -0,0 +1,28 @@
+package request
+
+// DefaultMaxResponseSize is the maximum response body size in bytes read when
+// no maximum response size is configured
+const DefaultMaxResponseSize = 50 * 1024 * 1024
+
+// SetMaxResponseSize sets the maximum response body size in bytes. Larger
+// responses are rejected with an error instead of being read into memory. A
+// size of zero or less uses DefaultMaxResponseSize
+func (r *Requester) SetMaxResponseSize(size int64) {
+	if size <= 0 {
+		size = DefaultMaxResponseSize
+	}
+
+	r.m.Lock()
+	r.maxResponseSize = size
+	r.m.Unlock()
+}
+
+// getMaxResponseSize returns the maximum response body size in bytes
+func (r *Requester) getMaxResponseSize() int64 {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.maxResponseSize <= 0 {
+		return DefaultMaxResponseSize
+	}
+	return r.maxResponseSize
+}
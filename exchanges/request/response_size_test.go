@@ -0,0 +1,38 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaxResponseSize(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"data":"` + strings.Repeat("a", 100) + `"}`))
+	}))
+	defer srv.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	if r.getMaxResponseSize() != DefaultMaxResponseSize {
+		t.Fatal("unexpected values")
+	}
+
+	var result interface{}
+	err := r.SendPayload("GET", srv.URL, nil, nil, &result, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.SetMaxResponseSize(50)
+	err = r.SendPayload("GET", srv.URL, nil, nil, &result, false, false)
+	if err == nil || !strings.Contains(err.Error(), "maximum response size") {
+		t.Fatalf("unexpected values %v", err)
+	}
+
+	r.SetMaxResponseSize(0)
+	if r.getMaxResponseSize() != DefaultMaxResponseSize {
+		t.Fatal("unexpected values")
+	}
+}
@@ -2,6 +2,8 @@ package ticker
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -15,14 +17,17 @@ const (
 	ErrTickerForExchangeNotFound = "Ticker for exchange does not exist."
 	ErrPrimaryCurrencyNotFound   = "Error primary currency for ticker not found."
 	ErrSecondaryCurrencyNotFound = "Error secondary currency for ticker not found."
+	ErrTickerExpired             = "Error ticker has expired."
 
-	Spot = "SPOT"
+	Spot   = "SPOT"
+	Margin = "MARGIN"
 )
 
 // Vars for the ticker package
 var (
 	Tickers []Ticker
 	m       sync.Mutex
+	ttl     time.Duration
 )
 
 // Price struct stores the currency pair and pricing information
@@ -39,10 +44,16 @@ type Price struct {
 	PriceATH     float64           `json:"PriceATH"`
 }
 
+// Update holds a ticker price for a currency pair processed by ProcessTickers
+type Update struct {
+	Pair  pair.CurrencyPair
+	Price Price
+}
+
 // Ticker struct holds the ticker information for a currency pair and type
 type Ticker struct {
-	Price        map[pair.CurrencyItem]map[pair.CurrencyItem]map[string]Price
-	ExchangeName string
+	Price        map[pair.CurrencyItem]map[pair.CurrencyItem]map[string]Price `json:"price"`
+	ExchangeName string                                                       `json:"exchangeName"`
 }
 
 // PriceToString returns the string version of a stored price field
@@ -71,20 +82,59 @@ func (t *Ticker) PriceToString(p pair.CurrencyPair, priceType, tickerType string
 
 // GetTicker checks and returns a requested ticker if it exists
 func GetTicker(exchange string, p pair.CurrencyPair, tickerType string) (Price, error) {
-	ticker, err := GetTickerByExchange(exchange)
-	if err != nil {
-		return Price{}, err
+	m.Lock()
+	defer m.Unlock()
+	ticker := findTicker(exchange)
+	if ticker == nil {
+		return Price{}, errors.New(ErrTickerForExchangeNotFound)
 	}
 
-	if !FirstCurrencyExists(exchange, p.FirstCurrency) {
+	seconds, ok := ticker.Price[p.FirstCurrency]
+	if !ok {
 		return Price{}, errors.New(ErrPrimaryCurrencyNotFound)
 	}
 
-	if !SecondCurrencyExists(exchange, p) {
+	types, ok := seconds[p.SecondCurrency]
+	if !ok {
 		return Price{}, errors.New(ErrSecondaryCurrencyNotFound)
 	}
 
-	return ticker.Price[p.FirstCurrency][p.SecondCurrency][tickerType], nil
+	result, ok := types[tickerType]
+	if ok && isExpired(result.LastUpdated, time.Now()) {
+		remove(exchange, p, tickerType)
+		return Price{}, errors.New(ErrTickerExpired)
+	}
+	return result, nil
+}
+
+// GetTickers returns a snapshot of all cached, unexpired tickers for an
+// exchange and ticker type sorted by currency pair
+func GetTickers(exchange, tickerType string) ([]Price, error) {
+	m.Lock()
+	defer m.Unlock()
+	for x := range Tickers {
+		if Tickers[x].ExchangeName != exchange {
+			continue
+		}
+
+		var prices []Price
+		now := time.Now()
+		for _, seconds := range Tickers[x].Price {
+			for _, types := range seconds {
+				price, ok := types[tickerType]
+				if !ok || isExpired(price.LastUpdated, now) {
+					continue
+				}
+				prices = append(prices, price)
+			}
+		}
+
+		sort.Slice(prices, func(i, j int) bool {
+			return prices[i].CurrencyPair < prices[j].CurrencyPair
+		})
+		return prices, nil
+	}
+	return nil, errors.New(ErrTickerForExchangeNotFound)
 }
 
 // GetTickerByExchange returns an exchange Ticker
@@ -158,26 +208,200 @@ func ProcessTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price, ti
 	tickerNew.CurrencyPair = p.Pair().String()
 	tickerNew.LastUpdated = time.Now()
 
-	ticker, err := GetTickerByExchange(exchangeName)
-	if err != nil {
-		CreateNewTicker(exchangeName, p, tickerNew, tickerType)
-		return
+	m.Lock()
+	setPrice(getOrCreateTicker(exchangeName), p, tickerNew, tickerType)
+	m.Unlock()
+}
+
+// findTicker returns the cached Ticker for an exchange, or nil if there is
+// none. The returned pointer is only valid while m is held by the caller
+func findTicker(exchangeName string) *Ticker {
+	for x := range Tickers {
+		if Tickers[x].ExchangeName == exchangeName {
+			return &Tickers[x]
+		}
+	}
+	return nil
+}
+
+// getOrCreateTicker returns the Ticker for an exchange, appending a new one to
+// the Tickers list if it does not exist. m must be held by the caller
+func getOrCreateTicker(exchangeName string) *Ticker {
+	if ticker := findTicker(exchangeName); ticker != nil {
+		return ticker
 	}
 
-	if FirstCurrencyExists(exchangeName, p.FirstCurrency) {
-		m.Lock()
-		a := make(map[string]Price)
-		a[tickerType] = tickerNew
-		ticker.Price[p.FirstCurrency][p.SecondCurrency] = a
-		m.Unlock()
-		return
+	Tickers = append(Tickers, Ticker{
+		ExchangeName: exchangeName,
+		Price:        make(map[pair.CurrencyItem]map[pair.CurrencyItem]map[string]Price),
+	})
+	return &Tickers[len(Tickers)-1]
+}
+
+// setPrice stores a price in the Ticker, creating the currency maps if needed.
+// m must be held by the caller
+func setPrice(ticker *Ticker, p pair.CurrencyPair, tickerNew Price, tickerType string) {
+	if _, ok := ticker.Price[p.FirstCurrency]; !ok {
+		ticker.Price[p.FirstCurrency] = make(map[pair.CurrencyItem]map[string]Price)
+	}
+	if _, ok := ticker.Price[p.FirstCurrency][p.SecondCurrency]; !ok {
+		ticker.Price[p.FirstCurrency][p.SecondCurrency] = make(map[string]Price)
 	}
+	ticker.Price[p.FirstCurrency][p.SecondCurrency][tickerType] = tickerNew
+}
 
+// ProcessTickers processes multiple incoming tickers for an exchange under a
+// single lock acquisition, creating or updating the Tickers list. An error is
+// returned for each update which could not be processed
+func ProcessTickers(exchangeName string, updates []Update, tickerType string) []error {
 	m.Lock()
-	a := make(map[pair.CurrencyItem]map[string]Price)
-	b := make(map[string]Price)
-	b[tickerType] = tickerNew
-	a[p.SecondCurrency] = b
-	ticker.Price[p.FirstCurrency] = a
+	defer m.Unlock()
+
+	ticker := getOrCreateTicker(exchangeName)
+
+	var errs []error
+	now := time.Now()
+	for x := range updates {
+		p := updates[x].Pair
+		if p.FirstCurrency == "" || p.SecondCurrency == "" {
+			errs = append(errs, fmt.Errorf("%s ticker update %d has an empty currency pair",
+				exchangeName, x))
+			continue
+		}
+
+		tickerNew := updates[x].Price
+		if tickerNew.Pair.Pair() == "" {
+			tickerNew.Pair = p
+		}
+		tickerNew.CurrencyPair = p.Pair().String()
+		tickerNew.LastUpdated = now
+		setPrice(ticker, p, tickerNew, tickerType)
+	}
+	return errs
+}
+
+// SetTTL sets the duration after which a cached ticker which has not been
+// updated is evicted. A TTL of zero disables eviction
+func SetTTL(d time.Duration) {
+	m.Lock()
+	ttl = d
 	m.Unlock()
 }
+
+// GetTTL returns the duration after which a cached ticker is evicted
+func GetTTL() time.Duration {
+	m.Lock()
+	defer m.Unlock()
+	return ttl
+}
+
+// Remove removes the cached ticker for an exchange, currency pair and asset
+// type
+func Remove(exchange string, p pair.CurrencyPair, tickerType string) error {
+	m.Lock()
+	defer m.Unlock()
+	if !remove(exchange, p, tickerType) {
+		return fmt.Errorf("%s %s %s ticker not found", exchange, p.Pair(), tickerType)
+	}
+	return nil
+}
+
+// EvictExpired removes all cached tickers which have not been updated within
+// the TTL and returns the amount evicted
+func EvictExpired() int {
+	m.Lock()
+	defer m.Unlock()
+	if ttl <= 0 {
+		return 0
+	}
+
+	now := time.Now()
+	var evicted int
+	for x := len(Tickers) - 1; x >= 0; x-- {
+		exchange := Tickers[x].ExchangeName
+		for first, seconds := range Tickers[x].Price {
+			for second, types := range seconds {
+				for tickerType, item := range types {
+					if isExpired(item.LastUpdated, now) {
+						remove(exchange, pair.NewCurrencyPair(first.String(), second.String()), tickerType)
+						evicted++
+					}
+				}
+			}
+		}
+	}
+	return evicted
+}
+
+// isExpired returns whether a ticker last updated at lastUpdated has passed
+// the TTL. m must be held by the caller
+func isExpired(lastUpdated, now time.Time) bool {
+	return ttl > 0 && now.Sub(lastUpdated) > ttl
+}
+
+// remove deletes a cached ticker and any maps left empty by its removal,
+// returning whether it existed. m must be held by the caller
+func remove(exchange string, p pair.CurrencyPair, tickerType string) bool {
+	for x := range Tickers {
+		if Tickers[x].ExchangeName != exchange {
+			continue
+		}
+
+		seconds, ok := Tickers[x].Price[p.FirstCurrency]
+		if !ok {
+			return false
+		}
+		types, ok := seconds[p.SecondCurrency]
+		if !ok {
+			return false
+		}
+		if _, ok = types[tickerType]; !ok {
+			return false
+		}
+
+		delete(types, tickerType)
+		if len(types) == 0 {
+			delete(seconds, p.SecondCurrency)
+		}
+		if len(seconds) == 0 {
+			delete(Tickers[x].Price, p.FirstCurrency)
+		}
+		if len(Tickers[x].Price) == 0 {
+			Tickers = append(Tickers[:x], Tickers[x+1:]...)
+		}
+		return true
+	}
+	return false
+}
+
+// MarshalTickers returns a JSON snapshot of all cached tickers
+func MarshalTickers() ([]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	return common.JSONEncode(Tickers)
+}
+
+// UnmarshalTickers loads a JSON snapshot created by MarshalTickers into the
+// cache, keeping the LastUpdated time of each snapshot ticker
+func UnmarshalTickers(data []byte) error {
+	var snapshot []Ticker
+	err := common.JSONDecode(data, &snapshot)
+	if err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	for x := range snapshot {
+		ticker := getOrCreateTicker(snapshot[x].ExchangeName)
+		for first, seconds := range snapshot[x].Price {
+			for second, types := range seconds {
+				p := pair.NewCurrencyPair(first.String(), second.String())
+				for tickerType, price := range types {
+					setPrice(ticker, p, price, tickerType)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -333,3 +333,211 @@ func TestProcessTicker(t *testing.T) { //non-appending function to tickers
 	wg.Wait()
 
 }
+
+func TestProcessTickers(t *testing.T) {
+	Tickers = []Ticker{}
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	btcaud := pair.NewCurrencyPair("BTC", "AUD")
+	ltcbtc := pair.NewCurrencyPair("LTC", "BTC")
+
+	errs := ProcessTickers("btcc", []Update{
+		{Pair: btcusd, Price: Price{Last: 1200}},
+		{Pair: btcaud, Price: Price{Last: 1600}},
+		{Pair: ltcbtc, Price: Price{Last: 0.02}},
+		{Price: Price{Last: 1}},
+	}, Spot)
+	if len(errs) != 1 {
+		t.Fatalf("Test failed. TestProcessTickers unexpected errors %v", errs)
+	}
+
+	for p, last := range map[pair.CurrencyPair]float64{btcusd: 1200, btcaud: 1600, ltcbtc: 0.02} {
+		result, err := GetTicker("btcc", p, Spot)
+		if err != nil {
+			t.Fatal("Test failed. TestProcessTickers failed to return a ticker", err)
+		}
+		if result.Last != last || result.Pair.Pair() != p.Pair() {
+			t.Errorf("Test failed. TestProcessTickers unexpected ticker %+v", result)
+		}
+	}
+
+	errs = ProcessTickers("btcc", []Update{{Pair: btcusd, Price: Price{Last: 1250}}}, Spot)
+	if len(errs) != 0 {
+		t.Fatalf("Test failed. TestProcessTickers unexpected errors %v", errs)
+	}
+
+	result, err := GetTicker("btcc", btcusd, Spot)
+	if err != nil || result.Last != 1250 {
+		t.Errorf("Test failed. TestProcessTickers failed to update ticker %+v", result)
+	}
+
+	if len(Tickers) != 1 {
+		t.Errorf("Test failed. TestProcessTickers unexpected ticker count %d", len(Tickers))
+	}
+}
+
+func TestRemove(t *testing.T) {
+	Tickers = []Ticker{}
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	ltcbtc := pair.NewCurrencyPair("LTC", "BTC")
+	ProcessTicker("btcc", btcusd, Price{Last: 1200}, Spot)
+	ProcessTicker("btcc", ltcbtc, Price{Last: 0.02}, Spot)
+
+	if err := Remove("btcc", btcusd, Spot); err != nil {
+		t.Fatal("Test failed. TestRemove error", err)
+	}
+
+	if _, err := GetTicker("btcc", btcusd, Spot); err == nil {
+		t.Error("Test failed. TestRemove returned a removed ticker")
+	}
+
+	if err := Remove("btcc", btcusd, Spot); err == nil {
+		t.Error("Test failed. TestRemove returned nil error on a removed ticker")
+	}
+
+	if err := Remove("btcc", ltcbtc, Spot); err != nil {
+		t.Fatal("Test failed. TestRemove error", err)
+	}
+
+	if len(Tickers) != 0 {
+		t.Errorf("Test failed. TestRemove left %d empty exchange tickers", len(Tickers))
+	}
+}
+
+func TestEvictExpired(t *testing.T) {
+	Tickers = []Ticker{}
+	defer SetTTL(0)
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	ltcbtc := pair.NewCurrencyPair("LTC", "BTC")
+	ProcessTicker("btcc", btcusd, Price{Last: 1200}, Spot)
+	ProcessTicker("btcc", ltcbtc, Price{Last: 0.02}, Spot)
+
+	stale := Tickers[0].Price["BTC"]["USD"][Spot]
+	stale.LastUpdated = time.Now().Add(-time.Hour)
+	Tickers[0].Price["BTC"]["USD"][Spot] = stale
+
+	if evicted := EvictExpired(); evicted != 0 {
+		t.Errorf("Test failed. TestEvictExpired evicted %d tickers with no TTL set", evicted)
+	}
+
+	SetTTL(time.Minute)
+	if GetTTL() != time.Minute {
+		t.Errorf("Test failed. TestEvictExpired unexpected TTL %v", GetTTL())
+	}
+
+	if _, err := GetTicker("btcc", btcusd, Spot); err == nil {
+		t.Error("Test failed. TestEvictExpired returned an expired ticker")
+	}
+
+	Tickers[0].Price["LTC"]["BTC"][Spot] = Price{LastUpdated: time.Now().Add(-time.Hour)}
+	ProcessTicker("btcc", btcusd, Price{Last: 1250}, Spot)
+
+	if evicted := EvictExpired(); evicted != 1 {
+		t.Errorf("Test failed. TestEvictExpired unexpected evicted count %d", evicted)
+	}
+
+	if _, err := GetTicker("btcc", ltcbtc, Spot); err == nil {
+		t.Error("Test failed. TestEvictExpired returned an evicted ticker")
+	}
+
+	result, err := GetTicker("btcc", btcusd, Spot)
+	if err != nil || result.Last != 1250 {
+		t.Errorf("Test failed. TestEvictExpired evicted an updated ticker %+v", result)
+	}
+}
+
+func TestGetTickers(t *testing.T) {
+	Tickers = []Ticker{}
+	if _, err := GetTickers("btcc", Spot); err == nil {
+		t.Error("Test failed. TestGetTickers returned nil error on invalid exchange")
+	}
+
+	ProcessTicker("btcc", pair.NewCurrencyPair("LTC", "BTC"), Price{Last: 0.02}, Spot)
+	ProcessTicker("btcc", pair.NewCurrencyPair("BTC", "USD"), Price{Last: 1200}, Spot)
+	ProcessTicker("btcc", pair.NewCurrencyPair("BTC", "USD"), Price{Last: 1300}, "futures_3m")
+
+	prices, err := GetTickers("btcc", Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestGetTickers error", err)
+	}
+
+	if len(prices) != 2 {
+		t.Fatalf("Test failed. TestGetTickers unexpected ticker count %d", len(prices))
+	}
+
+	if prices[0].CurrencyPair != "BTCUSD" || prices[0].Last != 1200 ||
+		prices[1].CurrencyPair != "LTCBTC" {
+		t.Errorf("Test failed. TestGetTickers unexpected tickers %+v", prices)
+	}
+}
+
+func TestMarshalTickers(t *testing.T) {
+	Tickers = []Ticker{}
+	p := pair.NewCurrencyPairDelimiter("BTC-USD", "-")
+	ProcessTicker("btcc", p, Price{Last: 1200, Bid: 1195, Ask: 1220, Volume: 5}, Spot)
+	original, err := GetTicker("btcc", p, Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestMarshalTickers error", err)
+	}
+
+	data, err := MarshalTickers()
+	if err != nil {
+		t.Fatal("Test failed. TestMarshalTickers marshal error", err)
+	}
+
+	Tickers = []Ticker{}
+	err = UnmarshalTickers(data)
+	if err != nil {
+		t.Fatal("Test failed. TestMarshalTickers unmarshal error", err)
+	}
+
+	result, err := GetTicker("btcc", p, Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestMarshalTickers failed to return a reloaded ticker", err)
+	}
+
+	if !result.LastUpdated.Equal(original.LastUpdated) {
+		t.Errorf("Test failed. TestMarshalTickers LastUpdated %v != %v",
+			result.LastUpdated, original.LastUpdated)
+	}
+
+	result.LastUpdated = original.LastUpdated
+	if !reflect.DeepEqual(result, original) {
+		t.Errorf("Test failed. TestMarshalTickers reloaded ticker %+v != %+v",
+			result, original)
+	}
+
+	if err = UnmarshalTickers([]byte("{")); err == nil {
+		t.Error("Test failed. TestMarshalTickers returned nil error on invalid JSON")
+	}
+}
+
+func TestGetTickerConcurrentEviction(t *testing.T) {
+	Tickers = []Ticker{}
+	SetTTL(time.Minute)
+	defer SetTTL(0)
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	for x := 0; x < 50; x++ {
+		ProcessTicker("stale"+strconv.Itoa(x), btcusd, Price{Last: 1}, Spot)
+		Tickers[x].Price["BTC"]["USD"][Spot] = Price{LastUpdated: time.Now().Add(-time.Hour)}
+	}
+	ProcessTicker("live", btcusd, Price{Last: 1200}, Spot)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		EvictExpired()
+	}()
+
+	for x := 0; x < 100; x++ {
+		result, err := GetTicker("live", btcusd, Spot)
+		if err != nil || result.Last != 1200 {
+			t.Fatalf("Test failed. TestGetTickerConcurrentEviction unexpected ticker %+v %v", result, err)
+		}
+	}
+	wg.Wait()
+
+	if len(Tickers) != 1 {
+		t.Errorf("Test failed. TestGetTickerConcurrentEviction unexpected tickers %d", len(Tickers))
+	}
+}
@@ -77,7 +77,7 @@ func (w *WEX) SetDefaults() {
 }
 
 // Setup sets exchange configuration parameters for WEX
-func (w *WEX) Setup(exch config.ExchangeConfig) {
+func (w *WEX) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		w.SetEnabled(false)
 	} else {
@@ -93,25 +93,26 @@ func (w *WEX) Setup(exch config.ExchangeConfig) {
 		w.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := w.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = w.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = w.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = w.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = w.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetTradablePairs returns a list of available pairs from the exchange
@@ -355,7 +356,7 @@ func (w *WEX) RedeemCoupon(coupon string) (RedeemCoupon, error) {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (w *WEX) SendHTTPRequest(path string, result interface{}) error {
-	return w.SendPayload("GET", path, nil, nil, result, false, w.Verbose)
+	return w.SendPayload("GET", path, nil, nil, result, false, w.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to WEX
@@ -376,7 +377,7 @@ func (w *WEX) SendAuthenticatedHTTPRequest(method string, values url.Values, res
 	encoded := values.Encode()
 	hmac := common.GetHMAC(common.HashSHA512, []byte(encoded), []byte(w.APISecret))
 
-	if w.Verbose {
+	if w.IsVerbose() {
 		log.Printf("Sending POST request to %s calling method %s with params %s\n",
 			w.APIUrlSecondary,
 			method,
@@ -394,7 +395,7 @@ func (w *WEX) SendAuthenticatedHTTPRequest(method string, values url.Values, res
 		strings.NewReader(encoded),
 		result,
 		true,
-		w.Verbose)
+		w.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
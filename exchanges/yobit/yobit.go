@@ -76,7 +76,7 @@ func (y *Yobit) SetDefaults() {
 }
 
 // Setup sets exchange configuration parameters for Yobit
-func (y *Yobit) Setup(exch config.ExchangeConfig) {
+func (y *Yobit) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		y.SetEnabled(false)
 	} else {
@@ -93,25 +93,26 @@ func (y *Yobit) Setup(exch config.ExchangeConfig) {
 		y.SetHTTPClientUserAgent(exch.HTTPUserAgent)
 		err := y.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = y.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = y.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = y.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = y.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // GetInfo returns the Yobit info
@@ -320,7 +321,7 @@ func (y *Yobit) RedeemCoupon(coupon string) (RedeemCoupon, error) {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (y *Yobit) SendHTTPRequest(path string, result interface{}) error {
-	return y.SendPayload("GET", path, nil, nil, result, false, y.Verbose)
+	return y.SendPayload("GET", path, nil, nil, result, false, y.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to Yobit
@@ -344,7 +345,7 @@ func (y *Yobit) SendAuthenticatedHTTPRequest(path string, params url.Values, res
 	encoded := params.Encode()
 	hmac := common.GetHMAC(common.HashSHA512, []byte(encoded), []byte(y.APISecret))
 
-	if y.Verbose {
+	if y.IsVerbose() {
 		log.Printf("Sending POST request to %s calling path %s with params %s\n", apiPrivateURL, path, encoded)
 	}
 
@@ -353,7 +354,7 @@ func (y *Yobit) SendAuthenticatedHTTPRequest(path string, params url.Values, res
 	headers["Sign"] = common.HexEncodeToString(hmac)
 	headers["Content-Type"] = "application/x-www-form-urlencoded"
 
-	return y.SendPayload("POST", apiPrivateURL, headers, strings.NewReader(encoded), result, true, y.Verbose)
+	return y.SendPayload("POST", apiPrivateURL, headers, strings.NewReader(encoded), result, true, y.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
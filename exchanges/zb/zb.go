@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/url"
 	"strconv"
 	"strings"
@@ -69,7 +68,7 @@ func (z *ZB) SetDefaults() {
 }
 
 // Setup sets user configuration
-func (z *ZB) Setup(exch config.ExchangeConfig) {
+func (z *ZB) Setup(exch config.ExchangeConfig) error {
 	if !exch.Enabled {
 		z.SetEnabled(false)
 	} else {
@@ -87,25 +86,26 @@ func (z *ZB) Setup(exch config.ExchangeConfig) {
 		z.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		err := z.SetCurrencyPairFormat()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = z.SetAssetTypes()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = z.SetAutoPairDefaults()
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = z.SetAPIURL(exch)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		err = z.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+	return nil
 }
 
 // SpotNewOrder submits an order to ZB
@@ -311,7 +311,7 @@ func (z *ZB) GetSpotKline(arg KlinesRequestParams) (KLineResponse, error) {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (z *ZB) SendHTTPRequest(path string, result interface{}) error {
-	return z.SendPayload("GET", path, nil, nil, result, false, z.Verbose)
+	return z.SendPayload("GET", path, nil, nil, result, false, z.IsVerbose())
 }
 
 // SendAuthenticatedHTTPRequest sends authenticated requests to the zb API
@@ -342,7 +342,7 @@ func (z *ZB) SendAuthenticatedHTTPRequest(method, endpoint string, values url.Va
 		strings.NewReader(""),
 		result,
 		true,
-		z.Verbose)
+		z.IsVerbose())
 }
 
 // GetFee returns an estimate of fee based on type of transaction
@@ -10,5 +10,5 @@ require (
 	github.com/toorop/go-pusher v0.0.0-20180107133620-4549deda5702
 	github.com/ugorji/go v0.0.0-20180112141927-9831f2c3ac10 // indirect
 	golang.org/x/crypto v0.0.0-20180602220124-df8d4716b347
-	golang.org/x/net v0.0.0-20180201030042-309822c5b9b9 // indirect
+	golang.org/x/net v0.0.0-20180201030042-309822c5b9b9
 )
@@ -273,6 +273,22 @@ func GetSpecificOrderbook(currency, exchangeName, assetType string) (orderbook.B
 	return specificOrderbook, err
 }
 
+// ExportSpecificOrderbookCSV fetches a specific orderbook given the currency,
+// exchangeName and assetType and writes it to w as CSV
+func ExportSpecificOrderbookCSV(w io.Writer, currency, exchangeName, assetType string) error {
+	exch := GetExchangeByName(exchangeName)
+	if exch == nil {
+		return ErrExchangeNotFound
+	}
+
+	ob, err := exch.GetOrderbookEx(pair.NewCurrencyPairFromString(currency),
+		assetType)
+	if err != nil {
+		return err
+	}
+	return ob.ExportCSV(w)
+}
+
 // GetSpecificTicker returns a specific ticker given the currency,
 // exchangeName and assetType
 func GetSpecificTicker(currency, exchangeName, assetType string) (ticker.Price, error) {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"log"
 	"testing"
 
@@ -252,6 +253,32 @@ func TestGetSpecificOrderbook(t *testing.T) {
 	UnloadExchange("Bitstamp")
 }
 
+func TestExportSpecificOrderbookCSV(t *testing.T) {
+	SetupTestHelpers(t)
+
+	LoadExchange("Bitstamp", false, nil)
+	p := pair.NewCurrencyPair("BTC", "USD")
+	bids := []orderbook.Item{{Price: 1000, Amount: 1}}
+
+	orderbook.ProcessOrderbook("Bitstamp", p, orderbook.Base{Pair: p, Bids: bids}, ticker.Spot)
+	var buf bytes.Buffer
+	err := ExportSpecificOrderbookCSV(&buf, "BTCUSD", "Bitstamp", ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != "price,amount,side\n1000,1,bid\n" {
+		t.Fatal("Unexpected result")
+	}
+
+	err = ExportSpecificOrderbookCSV(&buf, "BTCUSD", "RAWR", ticker.Spot)
+	if err != ErrExchangeNotFound {
+		t.Fatal("Unexpected result")
+	}
+
+	UnloadExchange("Bitstamp")
+}
+
 func TestGetSpecificTicker(t *testing.T) {
 	SetupTestHelpers(t)
 
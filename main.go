@@ -161,6 +161,9 @@ func main() {
 
 	go TickerUpdaterRoutine()
 	go OrderbookUpdaterRoutine()
+	if bot.config.CacheTTL > 0 {
+		go CacheEvictionRoutine(bot.config.CacheTTL)
+	}
 	go WebsocketRoutine(*verbosity)
 
 	<-bot.shutdown
@@ -186,7 +186,7 @@ func TickerUpdaterRoutine() {
 		for x := range bot.exchanges {
 			go func(x int, wg *sync.WaitGroup) {
 				defer wg.Done()
-				if bot.exchanges[x] == nil {
+				if bot.exchanges[x] == nil || !bot.exchanges[x].IsEnabled() {
 					return
 				}
 				exchangeName := bot.exchanges[x].GetName()
@@ -244,7 +244,7 @@ func OrderbookUpdaterRoutine() {
 			go func(x int, wg *sync.WaitGroup) {
 				defer wg.Done()
 
-				if bot.exchanges[x] == nil {
+				if bot.exchanges[x] == nil || !bot.exchanges[x].IsEnabled() {
 					return
 				}
 				exchangeName := bot.exchanges[x].GetName()
@@ -280,6 +280,25 @@ func OrderbookUpdaterRoutine() {
 	}
 }
 
+// CacheEvictionRoutine periodically evicts tickers and orderbooks which have
+// not been updated within the cache TTL
+func CacheEvictionRoutine(ttl time.Duration) {
+	log.Printf("Starting cache eviction routine. Cache TTL: %v.\n", ttl)
+	ticker.SetTTL(ttl)
+	orderbook.SetTTL(ttl)
+
+	t := time.NewTicker(ttl)
+	defer t.Stop()
+	for range t.C {
+		tickers := ticker.EvictExpired()
+		orderbooks := orderbook.EvictExpired()
+		if tickers > 0 || orderbooks > 0 {
+			log.Printf("Evicted %d expired tickers and %d expired orderbooks.\n",
+				tickers, orderbooks)
+		}
+	}
+}
+
 // WebsocketRoutine Initial routine management system for websocket
 func WebsocketRoutine(verbose bool) {
 	log.Println("Connecting exchange websocket services...")
@@ -418,6 +437,11 @@ func WebsocketDataHandler(ws *exchange.Websocket, verbose bool) {
 				if verbose {
 					log.Println("Websocket Kline Updated:    ", data.(exchange.KlineData))
 				}
+			case exchange.WebsocketConnectionState:
+				// Connection state changes while reconnecting
+				if verbose {
+					log.Println("Websocket Connection State: ", data.(exchange.WebsocketConnectionState))
+				}
 			case exchange.WebsocketOrderbookUpdate:
 				// Orderbook data
 				if verbose {
@@ -438,25 +462,11 @@ func WebsocketReconnect(ws *exchange.Websocket, verbose bool) {
 		log.Printf("Websocket reconnection requested for %s", ws.GetName())
 	}
 
-	err := ws.Shutdown()
-	if err != nil {
-		log.Fatal(err)
-	}
-
 	wg.Add(1)
 	defer wg.Done()
 
-	ticker := time.NewTicker(3 * time.Second)
-	for {
-		select {
-		case <-shutdowner:
-			return
-
-		case <-ticker.C:
-			err = ws.Connect()
-			if err == nil {
-				return
-			}
-		}
+	err := ws.Reconnect(shutdowner)
+	if err != nil {
+		log.Println(err)
 	}
 }
@@ -33,6 +33,7 @@ const (
 	currencyTranslationPath         = "..%s..%scurrency%stranslation%s"
 	eventsPath                      = "..%s..%sevents%s"
 	exchangesPath                   = "..%s..%sexchanges%s"
+	exchangesFixturePath            = "..%s..%sexchanges%sfixture%s"
 	exchangesNoncePath              = "..%s..%sexchanges%snonce%s"
 	exchangesOrderbookPath          = "..%s..%sexchanges%sorderbook%s"
 	exchangesStatsPath              = "..%s..%sexchanges%sstats%s"
@@ -214,6 +215,7 @@ func addPaths() {
 	codebasePaths["root"] = fmt.Sprintf(rootPath, path, path)
 
 	codebasePaths["exchanges"] = fmt.Sprintf(exchangesPath, path, path, path)
+	codebasePaths["exchanges fixture"] = fmt.Sprintf(exchangesFixturePath, path, path, path, path)
 	codebasePaths["exchanges nonce"] = fmt.Sprintf(exchangesNoncePath, path, path, path, path)
 	codebasePaths["exchanges orderbook"] = fmt.Sprintf(exchangesOrderbookPath, path, path, path, path)
 	codebasePaths["exchanges stats"] = fmt.Sprintf(exchangesStatsPath, path, path, path, path)